@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/domain/session"
+	"github.com/yanatoritakuma/budget/back/model"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+// 認証に使うCookie名
+const (
+	AccessTokenCookie  = "token"
+	RefreshTokenCookie = "refresh_token"
+	loggedInCookie     = "logged_in"
+	// CSRFSessionCookie はCSRFトークンを紐付けるブラウザごとの識別子です。
+	CSRFSessionCookie = "csrf_session"
+	// CSRFTokenCookie はヘッダーと二重に送られるCSRFトークンです。
+	CSRFTokenCookie = "csrf_token"
+)
+
+// SetAuthCookies は発行したトークンをCookieに設定します。
+// リフレッシュトークンが更新されていない場合はアクセストークンのみ設定します。
+func SetAuthCookies(c *gin.Context, tokens usecase.AuthTokens) {
+	http.SetCookie(c.Writer, newAuthCookie(AccessTokenCookie, tokens.AccessToken, int(usecase.AccessTokenTTL.Seconds()), true))
+	if tokens.RefreshToken == "" {
+		return
+	}
+	http.SetCookie(c.Writer, newAuthCookie(RefreshTokenCookie, tokens.RefreshToken, int(usecase.RefreshTokenTTL.Seconds()), true))
+	http.SetCookie(c.Writer, newAuthCookie(loggedInCookie, "true", int(usecase.RefreshTokenTTL.Seconds()), false))
+}
+
+// ClearAuthCookies は認証に使うCookieを削除します。
+func ClearAuthCookies(c *gin.Context) {
+	http.SetCookie(c.Writer, newAuthCookie(AccessTokenCookie, "", -1, true))
+	http.SetCookie(c.Writer, newAuthCookie(RefreshTokenCookie, "", -1, true))
+	http.SetCookie(c.Writer, newAuthCookie(loggedInCookie, "", -1, false))
+}
+
+// CSRFClientID はブラウザのCSRF識別子を返します。
+// 無い場合は新しく発行してCookieに設定します。
+func CSRFClientID(c *gin.Context) (string, error) {
+	if clientID, err := c.Cookie(CSRFSessionCookie); err == nil && clientID != "" {
+		return clientID, nil
+	}
+	clientID, err := model.GenerateTokenValue()
+	if err != nil {
+		return "", err
+	}
+	http.SetCookie(c.Writer, newAuthCookie(CSRFSessionCookie, clientID, int(usecase.RefreshTokenTTL.Seconds()), true))
+	return clientID, nil
+}
+
+// ClientInfo はリクエストからセッションの端末情報を取得します。
+func ClientInfo(c *gin.Context) session.ClientInfo {
+	return session.ClientInfo{
+		DeviceName: c.GetHeader("X-Device-Name"),
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+}
+
+func newAuthCookie(name, value string, maxAge int, httpOnly bool) *http.Cookie {
+	isSecure := os.Getenv("GO_ENV") != "dev"
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		MaxAge:   maxAge,
+		Path:     "/",
+		Domain:   os.Getenv("API_DOMAIN"),
+		Secure:   isSecure,
+		HttpOnly: httpOnly,
+		SameSite: http.SameSiteNoneMode,
+	}
+	if !isSecure {
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	return cookie
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type BudgetController interface {
+	CreateBudget(c *gin.Context)
+	GetBudgets(c *gin.Context)
+	UpdateBudget(c *gin.Context)
+	DeleteBudget(c *gin.Context)
+	GetBudgetStatus(c *gin.Context)
+}
+
+type budgetController struct {
+	bu usecase.BudgetUsecase
+}
+
+func NewBudgetController(bu usecase.BudgetUsecase) BudgetController {
+	return &budgetController{bu}
+}
+
+func (bc *budgetController) CreateBudget(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	budgetRes, err := bc.bu.CreateBudget(c.Request.Context(), userID, req)
+	if err != nil {
+		if respondBudgetError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の作成に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, budgetRes)
+}
+
+func (bc *budgetController) GetBudgets(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	year, month, ok := bindYearMonth(c)
+	if !ok {
+		return
+	}
+
+	budgets, err := bc.bu.GetBudgets(c.Request.Context(), userID, year, month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+func (bc *budgetController) UpdateBudget(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	budgetId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	var req api.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	budgetRes, err := bc.bu.UpdateBudget(c.Request.Context(), userID, req, uint(budgetId))
+	if err != nil {
+		if respondBudgetError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の更新に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, budgetRes)
+}
+
+func (bc *budgetController) DeleteBudget(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	budgetId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	if err := bc.bu.DeleteBudget(c.Request.Context(), userID, uint(budgetId)); err != nil {
+		if respondBudgetError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の削除に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (bc *budgetController) GetBudgetStatus(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	year, month, ok := bindYearMonth(c)
+	if !ok {
+		return
+	}
+
+	status, err := bc.bu.GetBudgetStatus(c.Request.Context(), userID, year, month)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算状況の取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// bindYearMonth はクエリパラメータから年と月を取得し、不正な場合はエラーレスポンスを返します。
+func bindYearMonth(c *gin.Context) (int, int, bool) {
+	year := c.Query("year")
+	month := c.Query("month")
+	if year == "" || month == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "年と月は必須パラメータです"})
+		return 0, 0, false
+	}
+
+	yearInt, err := strconv.Atoi(year)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正な年のフォーマットです"})
+		return 0, 0, false
+	}
+
+	monthInt, err := strconv.Atoi(month)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正な月のフォーマットです"})
+		return 0, 0, false
+	}
+
+	if monthInt < 1 || monthInt > 12 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "月は1から12の間で指定してください"})
+		return 0, 0, false
+	}
+
+	return yearInt, monthInt, true
+}
+
+// respondBudgetError は権限がない・予算が存在しない・入力が不正な場合にエラーを返し、レスポンスを返したかを返します。
+func respondBudgetError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "予算が見つかりません"})
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type CategoryController interface {
+	GetCategories(c *gin.Context)
+	CreateCategory(c *gin.Context)
+	UpdateCategory(c *gin.Context)
+	DeleteCategory(c *gin.Context)
+}
+
+type categoryController struct {
+	cu usecase.CategoryUsecase
+}
+
+func NewCategoryController(cu usecase.CategoryUsecase) CategoryController {
+	return &categoryController{cu}
+}
+
+func (cc *categoryController) GetCategories(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	includeArchived := c.Query("include_archived") == "true"
+
+	categories, err := cc.cu.GetCategories(c.Request.Context(), userID, includeArchived)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "カテゴリの取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+func (cc *categoryController) CreateCategory(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	categoryRes, err := cc.cu.CreateCategory(c.Request.Context(), userID, req)
+	if err != nil {
+		if respondCategoryError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "カテゴリの作成に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoryRes)
+}
+
+func (cc *categoryController) UpdateCategory(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	categoryId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	var req api.CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	categoryRes, err := cc.cu.UpdateCategory(c.Request.Context(), userID, req, uint(categoryId))
+	if err != nil {
+		if respondCategoryError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "カテゴリの更新に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, categoryRes)
+}
+
+func (cc *categoryController) DeleteCategory(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	categoryId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	if err := cc.cu.DeleteCategory(c.Request.Context(), userID, uint(categoryId)); err != nil {
+		if respondCategoryError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "カテゴリの削除に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondCategoryError は権限がない・カテゴリが存在しない・名前が重複しているなどの場合にエラーを返し、レスポンスを返したかを返します。
+func respondCategoryError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, usecase.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "カテゴリが見つかりません"})
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
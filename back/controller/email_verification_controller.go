@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type EmailVerificationController interface {
+	ResendVerification(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+}
+
+type emailVerificationController struct {
+	evu usecase.EmailVerificationUsecase
+}
+
+func NewEmailVerificationController(evu usecase.EmailVerificationUsecase) EmailVerificationController {
+	return &emailVerificationController{evu}
+}
+
+func (ec *emailVerificationController) ResendVerification(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	if err := ec.evu.ResendVerification(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "確認メールの送信に失敗しました: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+func (ec *emailVerificationController) VerifyEmail(c *gin.Context) {
+	var req api.EmailVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	if err := ec.evu.VerifyEmail(c.Request.Context(), req); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "メールアドレスの確認に失敗しました: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
 	"github.com/yanatoritakuma/budget/back/internal/api"
 	"github.com/yanatoritakuma/budget/back/usecase"
 )
@@ -14,6 +17,7 @@ type ExpenseController interface {
 	GetExpense(c *gin.Context)
 	UpdateExpense(c *gin.Context)
 	DeleteExpense(c *gin.Context)
+	GetExpenseSummary(c *gin.Context)
 }
 
 type expenseController struct {
@@ -45,6 +49,14 @@ func (ec *expenseController) CreateExpense(c *gin.Context) {
 	// 支出を作成
 	expenseRes, err := ec.eu.CreateExpense(c.Request.Context(), req)
 	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, usecase.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の作成に失敗しました: " + err.Error()})
 		return
 	}
@@ -60,52 +72,59 @@ func (ec *expenseController) GetExpense(c *gin.Context) {
 		return
 	}
 
-	// クエリパラメータから年と月を取得
-	year := c.Query("year")
-	month := c.Query("month")
-	category := c.Query("category")
-
-	// 年月の必須チェック
-	if year == "" || month == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "年と月は必須パラメータです"})
-		return
-	}
-
-	// 文字列を数値に変換
-	yearInt := 0
-	monthInt := 0
-	var err error
-
-	yearInt, err = strconv.Atoi(year)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "不正な年のフォーマットです"})
-		return
-	}
-
-	monthInt, err = strconv.Atoi(month)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "不正な月のフォーマットです"})
-		return
+	// クエリパラメータから検索条件を取得
+	var params api.GetExpensesParams
+	query := c.Request.URL.Query()
+	bindings := []struct {
+		name string
+		dest any
+	}{
+		{"year", &params.Year},
+		{"month", &params.Month},
+		{"from", &params.From},
+		{"to", &params.To},
+		{"category", &params.Category},
+		{"category_id", &params.CategoryId},
+		{"payer_id", &params.PayerId},
+		{"amount_min", &params.AmountMin},
+		{"amount_max", &params.AmountMax},
+		{"q", &params.Q},
+		{"sort", &params.Sort},
+		{"order", &params.Order},
+		{"cursor", &params.Cursor},
+		{"limit", &params.Limit},
+	}
+	for _, b := range bindings {
+		if err := runtime.BindQueryParameter("form", true, false, b.name, query, b.dest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "不正なパラメータです: " + b.name})
+			return
+		}
 	}
 
 	// 月の範囲チェック
-	if monthInt < 1 || monthInt > 12 {
+	if params.Month != nil && (*params.Month < 1 || *params.Month > 12) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "月は1から12の間で指定してください"})
 		return
 	}
 
-	var categoryPtr *string
-	if category != "" {
-		categoryPtr = &category
-	}
-
 	// 支出データを取得
-	expenses, err := ec.eu.GetExpense(c.Request.Context(), userID, yearInt, monthInt, categoryPtr)
+	expenses, nextCursor, err := ec.eu.GetExpense(c.Request.Context(), userID, params)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if status, message, ok := expenseAccessError(err); ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出データの取得に失敗しました: " + err.Error()})
 		return
 	}
 
+	if nextCursor != nil {
+		c.Header("X-Next-Cursor", *nextCursor)
+	}
 	c.JSON(http.StatusOK, expenses)
 }
 
@@ -133,8 +152,16 @@ func (ec *expenseController) UpdateExpense(c *gin.Context) {
 	}
 
 	// 支出を更新
-	expenseRes, err := ec.eu.UpdateExpense(c.Request.Context(), req, uint(expenseId))
+	expenseRes, err := ec.eu.UpdateExpense(c.Request.Context(), userID, req, uint(expenseId))
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if status, message, ok := expenseAccessError(err); ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の更新に失敗しました: " + err.Error()})
 		return
 	}
@@ -143,6 +170,13 @@ func (ec *expenseController) UpdateExpense(c *gin.Context) {
 }
 
 func (ec *expenseController) DeleteExpense(c *gin.Context) {
+	// ユーザーIDを取得（認証済みユーザーのコンテキストから）
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
 	// パスパラメータからIDを取得
 	id := c.Param("id")
 	expenseId, err := strconv.Atoi(id)
@@ -152,10 +186,57 @@ func (ec *expenseController) DeleteExpense(c *gin.Context) {
 	}
 
 	// 支出を削除
-	if err := ec.eu.DeleteExpense(c.Request.Context(), uint(expenseId)); err != nil {
+	if err := ec.eu.DeleteExpense(c.Request.Context(), userID, uint(expenseId)); err != nil {
+		if status, message, ok := expenseAccessError(err); ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の削除に失敗しました: " + err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+func (ec *expenseController) GetExpenseSummary(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	from, err := time.Parse(time.DateOnly, c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "開始日はYYYY-MM-DD形式で指定してください"})
+		return
+	}
+	to, err := time.Parse(time.DateOnly, c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "終了日はYYYY-MM-DD形式で指定してください"})
+		return
+	}
+
+	summary, err := ec.eu.GetExpenseSummary(c.Request.Context(), userID, from, to, c.Query("group_by"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の集計に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// expenseAccessError は支出が存在しない・権限がない場合のHTTPステータスとメッセージを返します。
+func expenseAccessError(err error) (int, string, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		return http.StatusNotFound, "支出が見つかりません", true
+	case errors.Is(err, usecase.ErrForbidden):
+		return http.StatusForbidden, "この支出を操作する権限がありません", true
+	default:
+		return 0, "", false
+	}
+}
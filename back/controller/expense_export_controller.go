@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/oapi-codegen/runtime"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type ExpenseExportController interface {
+	ExportExpenses(c *gin.Context)
+}
+
+type expenseExportController struct {
+	xu usecase.ExpenseExportUsecase
+}
+
+func NewExpenseExportController(xu usecase.ExpenseExportUsecase) ExpenseExportController {
+	return &expenseExportController{xu}
+}
+
+func (xc *expenseExportController) ExportExpenses(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var params api.GetExpensesExportParams
+	query := c.Request.URL.Query()
+	if err := runtime.BindQueryParameter("form", true, true, "format", query, &params.Format); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "出力形式を指定してください"})
+		return
+	}
+	bindings := []struct {
+		name string
+		dest any
+	}{
+		{"encoding", &params.Encoding},
+		{"year", &params.Year},
+		{"month", &params.Month},
+		{"from", &params.From},
+		{"to", &params.To},
+		{"category", &params.Category},
+		{"category_id", &params.CategoryId},
+		{"payer_id", &params.PayerId},
+		{"amount_min", &params.AmountMin},
+		{"amount_max", &params.AmountMax},
+		{"q", &params.Q},
+		{"sort", &params.Sort},
+		{"order", &params.Order},
+	}
+	for _, b := range bindings {
+		if err := runtime.BindQueryParameter("form", true, false, b.name, query, b.dest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "不正なパラメータです: " + b.name})
+			return
+		}
+	}
+
+	var contentType, ext string
+	switch params.Format {
+	case api.GetExpensesExportParamsFormatCsv:
+		contentType, ext = "text/csv; charset=utf-8", "csv"
+		if params.Encoding != nil && *params.Encoding == api.GetExpensesExportParamsEncodingShiftJis {
+			contentType = "text/csv; charset=Shift_JIS"
+		}
+	case api.GetExpensesExportParamsFormatJsonl:
+		contentType, ext = "application/x-ndjson", "jsonl"
+	case api.GetExpensesExportParamsFormatXlsx:
+		contentType, ext = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "出力形式は csv, jsonl, xlsx のいずれかを指定してください"})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="expenses_%s.%s"`, time.Now().Format("20060102"), ext))
+
+	if err := xc.xu.ExportExpenses(c.Request.Context(), userID, params, c.Writer); err != nil {
+		// 書き出し開始後はステータスを変更できないため、ログに残して打ち切る
+		if c.Writer.Written() {
+			log.Printf("failed to export expenses: %v", err)
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の書き出しに失敗しました: " + err.Error()})
+		return
+	}
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type ExpenseImportController interface {
+	ImportExpenses(c *gin.Context)
+}
+
+type expenseImportController struct {
+	iu usecase.ExpenseImportUsecase
+}
+
+func NewExpenseImportController(iu usecase.ExpenseImportUsecase) ExpenseImportController {
+	return &expenseImportController{iu}
+}
+
+func (ic *expenseImportController) ImportExpenses(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルを指定してください"})
+		return
+	}
+	if fileHeader.Size > usecase.MaxImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルサイズが大きすぎます"})
+		return
+	}
+
+	var mapping api.ExpenseImportMapping
+	if err := json.Unmarshal([]byte(c.PostForm("mapping")), &mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "列の対応付けが不正です: " + err.Error()})
+		return
+	}
+
+	opts := usecase.ExpenseImportOptions{
+		Mapping:         mapping,
+		Encoding:        c.DefaultPostForm("encoding", string(api.PostExpensesImportMultipartBodyEncodingAuto)),
+		HasHeader:       c.DefaultPostForm("has_header", "true") == "true",
+		DryRun:          c.PostForm("dry_run") == "true",
+		DefaultCategory: c.PostForm("default_category"),
+	}
+	if payerID := c.PostForm("payer_id"); payerID != "" {
+		id, err := strconv.Atoi(payerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "不正な支払者IDです"})
+			return
+		}
+		opts.PayerID = &id
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルを開けません: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	result, err := ic.iu.ImportExpenses(c.Request.Context(), userID, file, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, usecase.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の取り込みに失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
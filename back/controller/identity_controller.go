@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type IdentityController interface {
+	ListIdentities(c *gin.Context)
+	UnlinkIdentity(c *gin.Context)
+}
+
+type identityController struct {
+	iu usecase.IdentityUsecase
+}
+
+func NewIdentityController(iu usecase.IdentityUsecase) IdentityController {
+	return &identityController{iu}
+}
+
+func (ic *identityController) ListIdentities(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	identities, err := ic.iu.ListIdentities(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "連携アカウントの取得に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, identities)
+}
+
+func (ic *identityController) UnlinkIdentity(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効な連携アカウントIDです"})
+		return
+	}
+
+	if err := ic.iu.UnlinkIdentity(c.Request.Context(), userID, uint(id)); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "連携アカウントが見つかりません"})
+		case errors.Is(err, usecase.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "連携の解除に失敗しました: " + err.Error()})
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
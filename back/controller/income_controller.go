@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type IncomeController interface {
+	CreateIncome(c *gin.Context)
+	GetIncomes(c *gin.Context)
+	UpdateIncome(c *gin.Context)
+	DeleteIncome(c *gin.Context)
+	GetCashFlow(c *gin.Context)
+}
+
+type incomeController struct {
+	iu usecase.IncomeUsecase
+}
+
+func NewIncomeController(iu usecase.IncomeUsecase) IncomeController {
+	return &incomeController{iu}
+}
+
+func (ic *incomeController) CreateIncome(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.IncomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	incomeRes, err := ic.iu.CreateIncome(c.Request.Context(), userID, req)
+	if err != nil {
+		if respondIncomeError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "収入の作成に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, incomeRes)
+}
+
+func (ic *incomeController) GetIncomes(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	year, month, ok := bindYearMonth(c)
+	if !ok {
+		return
+	}
+
+	var categoryPtr *string
+	if category := c.Query("category"); category != "" {
+		categoryPtr = &category
+	}
+
+	incomes, err := ic.iu.GetIncomes(c.Request.Context(), userID, year, month, categoryPtr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "収入の取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, incomes)
+}
+
+func (ic *incomeController) UpdateIncome(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	incomeId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	var req api.IncomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	incomeRes, err := ic.iu.UpdateIncome(c.Request.Context(), userID, req, uint(incomeId))
+	if err != nil {
+		if respondIncomeError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "収入の更新に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, incomeRes)
+}
+
+func (ic *incomeController) DeleteIncome(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	incomeId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	if err := ic.iu.DeleteIncome(c.Request.Context(), userID, uint(incomeId)); err != nil {
+		if respondIncomeError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "収入の削除に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (ic *incomeController) GetCashFlow(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	year, err := strconv.Atoi(c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正な年のフォーマットです"})
+		return
+	}
+
+	cashFlow, err := ic.iu.GetCashFlow(c.Request.Context(), userID, year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "収支の取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cashFlow)
+}
+
+// respondIncomeError は権限がない・収入が存在しない・入力が不正な場合にエラーを返し、レスポンスを返したかを返します。
+func respondIncomeError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "収入が見つかりません"})
+	default:
+		return false
+	}
+	return true
+}
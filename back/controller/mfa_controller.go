@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type MFAController interface {
+	GetStatus(c *gin.Context)
+	SetupTOTP(c *gin.Context)
+	ConfirmTOTP(c *gin.Context)
+	DisableTOTP(c *gin.Context)
+	RegenerateRecoveryCodes(c *gin.Context)
+	CompleteLogin(c *gin.Context)
+}
+
+type mfaController struct {
+	mu usecase.MFAUsecase
+	su usecase.SessionUsecase
+}
+
+func NewMFAController(mu usecase.MFAUsecase, su usecase.SessionUsecase) MFAController {
+	return &mfaController{mu, su}
+}
+
+func (mc *mfaController) GetStatus(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	status, err := mc.mu.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "2段階認証の状態の取得に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+func (mc *mfaController) SetupTOTP(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	res, err := mc.mu.SetupTOTP(c.Request.Context(), userID)
+	if err != nil {
+		if status, ok := mfaErrorStatus(err); ok {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "2段階認証の登録に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (mc *mfaController) ConfirmTOTP(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.TOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	res, err := mc.mu.ConfirmTOTP(c.Request.Context(), userID, req)
+	if err != nil {
+		if status, ok := mfaErrorStatus(err); ok {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "2段階認証の登録に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (mc *mfaController) DisableTOTP(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.TOTPDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	if err := mc.mu.DisableTOTP(c.Request.Context(), userID, req); err != nil {
+		if RespondTooManyAttempts(c, err) {
+			return
+		}
+		if status, ok := mfaErrorStatus(err); ok {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "2段階認証の解除に失敗しました: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (mc *mfaController) RegenerateRecoveryCodes(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.TOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	res, err := mc.mu.RegenerateRecoveryCodes(c.Request.Context(), userID, req)
+	if err != nil {
+		if RespondTooManyAttempts(c, err) {
+			return
+		}
+		if status, ok := mfaErrorStatus(err); ok {
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "リカバリーコードの発行に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// CompleteLogin はパスワード認証後の2段階目の確認を行い、セッションを開始します。
+func (mc *mfaController) CompleteLogin(c *gin.Context) {
+	var req api.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	loginUser, err := mc.mu.CompleteLogin(c.Request.Context(), req)
+	if err != nil {
+		if RespondTooManyAttempts(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrUnauthorized) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := mc.su.StartSession(c.Request.Context(), loginUser, ClientInfo(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	SetAuthCookies(c, tokens)
+	c.JSON(http.StatusOK, api.LoginResponse{MfaRequired: false})
+}
+
+// mfaErrorStatus はユースケースのエラーに対応するHTTPステータスを返します。
+func mfaErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidInput):
+		return http.StatusBadRequest, true
+	case errors.Is(err, usecase.ErrNotFound):
+		return http.StatusNotFound, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/model"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+// OIDCLoginController はLINEやGoogleなどのOpenID Connectによるログインのコントローラのインターフェースです。
+type OIDCLoginController interface {
+	Login(c *gin.Context)
+	Callback(c *gin.Context)
+	LinkAccount(c *gin.Context)
+	CreateAccount(c *gin.Context)
+}
+
+// OIDCLoginControllerImpl はOIDCLoginControllerの実装です。
+type OIDCLoginControllerImpl struct {
+	oidcLoginUsecase usecase.OIDCLoginUsecase
+	sessionUsecase   usecase.SessionUsecase
+	mfaUsecase       usecase.MFAUsecase
+	tokenStore       model.TokenStore // CSRF stateの保存用
+}
+
+// oidcStateTTL はログインのstateの有効期間です。
+const oidcStateTTL = 5 * time.Minute
+
+// preAuthCookie は未登録ユーザーの一次トークンを保存するCookie名です。
+const preAuthCookie = "oidc_pre_auth"
+
+// NewOIDCLoginController はOIDCLoginControllerの新しいインスタンスを生成します。
+func NewOIDCLoginController(oidcLoginUsecase usecase.OIDCLoginUsecase, sessionUsecase usecase.SessionUsecase, mfaUsecase usecase.MFAUsecase, tokenStore model.TokenStore) OIDCLoginController {
+	return &OIDCLoginControllerImpl{
+		oidcLoginUsecase: oidcLoginUsecase,
+		sessionUsecase:   sessionUsecase,
+		mfaUsecase:       mfaUsecase,
+		tokenStore:       tokenStore,
+	}
+}
+
+// Login はIDプロバイダでの認証開始のためのURLを返します。
+func (ctrl *OIDCLoginControllerImpl) Login(c *gin.Context) {
+	provider := providerName(c)
+
+	// CSRF対策のためのstateを生成し、トークンストアに保存
+	// stateは「ログインごとの乱数.ストアが発行したトークン」の形式とする
+	nonce, err := usecase.GenerateState()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate state"})
+		return
+	}
+	token, err := ctrl.tokenStore.IssueToken(c.Request.Context(), oidcStateSessionID(provider, nonce), oidcStateTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate state"})
+		return
+	}
+	state := nonce + "." + token
+
+	authURL, err := ctrl.oidcLoginUsecase.GetAuthURL(c.Request.Context(), provider, state)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to get %s auth URL: %v", provider, err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"auth_url": authURL})
+}
+
+// Callback はIDプロバイダでの認証後のコールバックを処理します。
+func (ctrl *OIDCLoginControllerImpl) Callback(c *gin.Context) {
+	provider := providerName(c)
+	code := c.Query("code")
+	state := c.Query("state")
+
+	if code == "" || state == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing code or state in callback"})
+		return
+	}
+
+	// stateの検証
+	nonce, token, ok := strings.Cut(state, ".")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid state"})
+		return
+	}
+	sessionID := oidcStateSessionID(provider, nonce)
+	valid, err := ctrl.tokenStore.ValidateToken(c.Request.Context(), sessionID, token)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify state"})
+		return
+	}
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid state"})
+		return
+	}
+	if err := ctrl.tokenStore.DeleteToken(c.Request.Context(), sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify state"})
+		return
+	}
+
+	loginUser, claims, err := ctrl.oidcLoginUsecase.Callback(c.Request.Context(), provider, code)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s login failed: %v", provider, err)})
+		return
+	}
+
+	// ユーザーが存在する場合（ログイン成功）
+	if loginUser != nil {
+		mfaToken, err := ctrl.mfaUsecase.BeginLogin(c.Request.Context(), loginUser)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start session"})
+			return
+		}
+		if mfaToken != "" {
+			c.JSON(http.StatusOK, gin.H{"status": "mfa_required", "mfa_token": mfaToken})
+			return
+		}
+		if err := ctrl.setLoginCookies(c, loginUser); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start session"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "logged_in", "message": "ログインに成功しました"})
+		return
+	}
+
+	// ユーザーが存在しない場合（未登録）
+	if claims != nil {
+		// プレ認証トークンの生成
+		preAuthToken, err := ctrl.oidcLoginUsecase.GeneratePreAuthToken(provider, claims)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate pre-auth token"})
+			return
+		}
+
+		http.SetCookie(c.Writer, newAuthCookie(preAuthCookie, preAuthToken, int(usecase.PreAuthTokenTTL.Seconds()), true))
+
+		res := gin.H{
+			"status":   "unregistered",
+			"provider": provider,
+			"name":     claims.Name,
+			"picture":  claims.Picture,
+		}
+		// LINEログインの既存のフロントエンド向けの項目
+		if provider == identity.ProviderLINE {
+			res["line_name"] = claims.Name
+			res["line_picture"] = claims.Picture
+		}
+		c.JSON(http.StatusOK, res)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Unexpected login state"})
+}
+
+// LinkAccount は既存アカウントとIDプロバイダのアカウントを紐付けます。
+func (ctrl *OIDCLoginControllerImpl) LinkAccount(c *gin.Context) {
+	preAuthToken, err := c.Cookie(preAuthCookie)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No pending login found"})
+		return
+	}
+
+	var req api.LinkAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	linkedUser, err := ctrl.oidcLoginUsecase.LinkAccount(c.Request.Context(), providerName(c), preAuthToken, string(req.Email), req.Password, c.ClientIP())
+	if err != nil {
+		if RespondTooManyAttempts(c, err) {
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	mfaToken, err := ctrl.mfaUsecase.BeginLogin(c.Request.Context(), linkedUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start session"})
+		return
+	}
+	if mfaToken != "" {
+		// 紐付けは完了しているため、2段階目の確認後にログインする
+		http.SetCookie(c.Writer, newAuthCookie(preAuthCookie, "", -1, true))
+		c.JSON(http.StatusOK, gin.H{"message": "Account linked successfully", "status": "mfa_required", "mfa_token": mfaToken})
+		return
+	}
+
+	// 成功時のCookie設定
+	if err := ctrl.setLoginCookies(c, linkedUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start session"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account linked successfully"})
+}
+
+// CreateAccount はIDプロバイダのアカウントから新規ユーザーを作成します。
+func (ctrl *OIDCLoginControllerImpl) CreateAccount(c *gin.Context) {
+	preAuthToken, err := c.Cookie(preAuthCookie)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No pending login found"})
+		return
+	}
+
+	createdUser, err := ctrl.oidcLoginUsecase.CreateAccount(c.Request.Context(), providerName(c), preAuthToken)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 成功時のCookie設定
+	if err := ctrl.setLoginCookies(c, createdUser); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start session"})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Account created successfully"})
+}
+
+// setLoginCookies はセッションを開始し、ログイン成功時の共通Cookie設定を行います。
+func (ctrl *OIDCLoginControllerImpl) setLoginCookies(c *gin.Context, loginUser *user.User) error {
+	tokens, err := ctrl.sessionUsecase.StartSession(c.Request.Context(), loginUser, ClientInfo(c))
+	if err != nil {
+		return err
+	}
+
+	// Pre-Auth Cookieの削除
+	http.SetCookie(c.Writer, newAuthCookie(preAuthCookie, "", -1, true))
+	SetAuthCookies(c, tokens)
+	return nil
+}
+
+// providerName はURLのプロバイダ名を返します。
+// LINEログインの既存のルートはプロバイダ名を含まないため、LINEとみなします。
+func providerName(c *gin.Context) string {
+	if provider := c.Param("provider"); provider != "" {
+		return provider
+	}
+	return identity.ProviderLINE
+}
+
+// oidcStateSessionID はログインのstateを保存するセッションIDを返します。
+func oidcStateSessionID(provider, nonce string) string {
+	return fmt.Sprintf("oidc-login-%s-%s", provider, nonce)
+}
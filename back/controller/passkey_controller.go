@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type PasskeyController interface {
+	BeginRegistration(c *gin.Context)
+	FinishRegistration(c *gin.Context)
+	ListPasskeys(c *gin.Context)
+	DeletePasskey(c *gin.Context)
+	BeginLogin(c *gin.Context)
+	FinishLogin(c *gin.Context)
+}
+
+type passkeyController struct {
+	pu usecase.PasskeyUsecase
+	su usecase.SessionUsecase
+}
+
+func NewPasskeyController(pu usecase.PasskeyUsecase, su usecase.SessionUsecase) PasskeyController {
+	return &passkeyController{pu, su}
+}
+
+func (pc *passkeyController) BeginRegistration(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	options, err := pc.pu.BeginRegistration(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスキーの登録の開始に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, options)
+}
+
+func (pc *passkeyController) FinishRegistration(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.PasskeyRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	res, err := pc.pu.FinishRegistration(c.Request.Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスキーの登録に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (pc *passkeyController) ListPasskeys(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	res, err := pc.pu.ListPasskeys(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスキーの取得に失敗しました: " + err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (pc *passkeyController) DeletePasskey(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なパスキーIDです"})
+		return
+	}
+
+	if err := pc.pu.DeletePasskey(c.Request.Context(), userID, uint(id)); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "パスキーが見つかりません"})
+			return
+		}
+		if errors.Is(err, usecase.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスキーの削除に失敗しました: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (pc *passkeyController) BeginLogin(c *gin.Context) {
+	options, err := pc.pu.BeginLogin(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスキーでのログインの開始に失敗しました"})
+		return
+	}
+	c.JSON(http.StatusOK, options)
+}
+
+// FinishLogin はパスキーの署名を検証し、パスワードでのログインと同じ認証Cookieを発行します。
+func (pc *passkeyController) FinishLogin(c *gin.Context) {
+	var req api.PasskeyAssertionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	loginUser, err := pc.pu.FinishLogin(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrUnauthorized):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "パスキーでのログインに失敗しました"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	tokens, err := pc.su.StartSession(c.Request.Context(), loginUser, ClientInfo(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	SetAuthCookies(c, tokens)
+	c.JSON(http.StatusOK, api.LoginResponse{MfaRequired: false})
+}
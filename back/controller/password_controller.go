@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type PasswordController interface {
+	ChangePassword(c *gin.Context)
+	SetPassword(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
+}
+
+type passwordController struct {
+	pu usecase.PasswordUsecase
+}
+
+func NewPasswordController(pu usecase.PasswordUsecase) PasswordController {
+	return &passwordController{pu}
+}
+
+func (pc *passwordController) ChangePassword(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.PasswordChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	if err := pc.pu.ChangePassword(c.Request.Context(), userID, c.GetUint("session_id"), req); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスワードの変更に失敗しました: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetPassword は外部IDプロバイダから登録したユーザーにメールアドレスとパスワードを設定します。
+func (pc *passwordController) SetPassword(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.PasswordSetupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	if err := pc.pu.SetPassword(c.Request.Context(), userID, req); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "パスワードの設定に失敗しました: " + err.Error()})
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ForgotPassword はパスワード再設定用のリンクを送信します。
+// メールアドレスが登録されているかどうかに関わらず同じレスポンスを返します。
+func (pc *passwordController) ForgotPassword(c *gin.Context) {
+	var req api.PasswordForgotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	if err := pc.pu.RequestPasswordReset(c.Request.Context(), req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+func (pc *passwordController) ResetPassword(c *gin.Context) {
+	var req api.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "無効なリクエストです: " + err.Error()})
+		return
+	}
+
+	if err := pc.pu.ResetPassword(c.Request.Context(), req); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "パスワードの再設定に失敗しました: " + err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type RecurringExpenseController interface {
+	CreateRecurringExpense(c *gin.Context)
+	GetRecurringExpenses(c *gin.Context)
+	UpdateRecurringExpense(c *gin.Context)
+	DeleteRecurringExpense(c *gin.Context)
+}
+
+type recurringExpenseController struct {
+	ru usecase.RecurringExpenseUsecase
+}
+
+func NewRecurringExpenseController(ru usecase.RecurringExpenseUsecase) RecurringExpenseController {
+	return &recurringExpenseController{ru}
+}
+
+func (rc *recurringExpenseController) CreateRecurringExpense(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	var req api.RecurringExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	res, err := rc.ru.CreateRecurringExpense(c.Request.Context(), userID, req)
+	if err != nil {
+		if respondRecurringExpenseError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "定期支出の作成に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+func (rc *recurringExpenseController) GetRecurringExpenses(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	res, err := rc.ru.GetRecurringExpenses(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "定期支出の取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (rc *recurringExpenseController) UpdateRecurringExpense(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	var req api.RecurringExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なリクエストデータです: " + err.Error()})
+		return
+	}
+
+	res, err := rc.ru.UpdateRecurringExpense(c.Request.Context(), userID, req, uint(id))
+	if err != nil {
+		if respondRecurringExpenseError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "定期支出の更新に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (rc *recurringExpenseController) DeleteRecurringExpense(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	if err := rc.ru.DeleteRecurringExpense(c.Request.Context(), userID, uint(id)); err != nil {
+		if respondRecurringExpenseError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "定期支出の削除に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondRecurringExpenseError は権限がない・定期支出が存在しない・入力が不正な場合にエラーを返し、レスポンスを返したかを返します。
+func respondRecurringExpenseError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "定期支出が見つかりません"})
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+type SessionController interface {
+	Refresh(c *gin.Context)
+	ListSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	RevokeAllSessions(c *gin.Context)
+}
+
+type sessionController struct {
+	su usecase.SessionUsecase
+}
+
+func NewSessionController(su usecase.SessionUsecase) SessionController {
+	return &sessionController{su}
+}
+
+// Refresh はリフレッシュトークンでアクセストークンを再発行します。
+func (sc *sessionController) Refresh(c *gin.Context) {
+	refreshToken, err := c.Cookie(RefreshTokenCookie)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tokens, err := sc.su.Refresh(c.Request.Context(), refreshToken, ClientInfo(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrUnauthorized) {
+			ClearAuthCookies(c)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "トークンの更新に失敗しました: " + err.Error()})
+		return
+	}
+
+	SetAuthCookies(c, tokens)
+	c.Status(http.StatusOK)
+}
+
+func (sc *sessionController) ListSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	sessions, err := sc.su.ListSessions(c.Request.Context(), userID, c.GetUint("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "セッションの取得に失敗しました: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+func (sc *sessionController) RevokeSession(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不正なIDフォーマットです"})
+		return
+	}
+
+	if err := sc.su.RevokeSession(c.Request.Context(), userID, uint(sessionID)); err != nil {
+		if errors.Is(err, usecase.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "セッションが見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "セッションの失効に失敗しました: " + err.Error()})
+		return
+	}
+
+	if uint(sessionID) == c.GetUint("session_id") {
+		ClearAuthCookies(c)
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeAllSessions は現在の端末を含むすべてのセッションを失効させます。
+func (sc *sessionController) RevokeAllSessions(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "ユーザーが認証されていません"})
+		return
+	}
+
+	if err := sc.su.RevokeAllSessions(c.Request.Context(), userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "セッションの失効に失敗しました: " + err.Error()})
+		return
+	}
+
+	ClearAuthCookies(c)
+	c.Status(http.StatusNoContent)
+}
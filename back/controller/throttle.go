@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+// RespondTooManyAttempts は試行回数の超過によるエラーであれば、Retry-After ヘッダー付きの429を返して true を返します。
+func RespondTooManyAttempts(c *gin.Context, err error) bool {
+	var tooMany *usecase.TooManyAttemptsError
+	if !errors.As(err, &tooMany) {
+		return false
+	}
+	c.Header("Retry-After", strconv.Itoa(tooMany.RetryAfterSeconds()))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": tooMany.Error(), "code": "too_many_attempts"})
+	return true
+}
@@ -1,12 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
-	"os"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/yanatoritakuma/budget/back/internal/api"
 	"github.com/yanatoritakuma/budget/back/usecase"
 )
@@ -26,10 +24,12 @@ type UserController interface {
 
 type userController struct {
 	uu usecase.UserUsecase
+	su usecase.SessionUsecase
+	mu usecase.MFAUsecase
 }
 
-func NewUserController(uu usecase.UserUsecase) UserController {
-	return &userController{uu}
+func NewUserController(uu usecase.UserUsecase, su usecase.SessionUsecase, mu usecase.MFAUsecase) UserController {
+	return &userController{uu, su, mu}
 }
 
 func (uc *userController) SignUp(c *gin.Context) {
@@ -46,53 +46,68 @@ func (uc *userController) SignUp(c *gin.Context) {
 	c.JSON(http.StatusCreated, userRes)
 }
 
+// LogIn はパスワードを検証してセッションを開始します。
+// 2段階認証が有効な場合はセッションを開始せず、2段階目のためのトークンを返します。
 func (uc *userController) LogIn(c *gin.Context) {
 	user := api.SignUpRequest{}
 	if err := c.ShouldBindJSON(&user); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	tokenString, err := uc.uu.Login(user)
+	loginUser, err := uc.uu.Login(user, c.ClientIP())
 	if err != nil {
+		if RespondTooManyAttempts(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrUnauthorized) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	http.SetCookie(c.Writer, &http.Cookie{
-		Name:     "token",
-		Value:    tokenString,
-		MaxAge:   int(time.Hour * 24 / time.Second),
-		Path:     "/",
-		Domain:   os.Getenv("API_DOMAIN"),
-		SameSite: http.SameSiteNoneMode,
-		Secure:   true,
-		HttpOnly: true,
-	})
-	c.Status(http.StatusOK)
+	mfaToken, err := uc.mu.BeginLogin(c.Request.Context(), loginUser)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if mfaToken != "" {
+		c.JSON(http.StatusOK, api.LoginResponse{MfaRequired: true, MfaToken: &mfaToken})
+		return
+	}
+
+	tokens, err := uc.su.StartSession(c.Request.Context(), loginUser, ClientInfo(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	SetAuthCookies(c, tokens)
+	c.JSON(http.StatusOK, api.LoginResponse{MfaRequired: false})
 }
 
+// LogOut は現在のセッションを失効させ、認証Cookieを削除します。
 func (uc *userController) LogOut(c *gin.Context) {
-	http.SetCookie(c.Writer, &http.Cookie{
-		Name:     "token",
-		Value:    "",
-		MaxAge:   -1,
-		Path:     "/",
-		Domain:   os.Getenv("API_DOMAIN"),
-		SameSite: http.SameSiteNoneMode,
-		Secure:   true,
-		HttpOnly: true,
-	})
+	accessToken, _ := c.Cookie(AccessTokenCookie)
+	refreshToken, _ := c.Cookie(RefreshTokenCookie)
+	if err := uc.su.Logout(c.Request.Context(), accessToken, refreshToken); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ClearAuthCookies(c)
 	c.Status(http.StatusOK)
 }
 
 func (uc *userController) GetLoggedInUser(c *gin.Context) {
-	cookie, err := c.Cookie("token")
-	if err != nil {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
 
-	userRes, err := uc.uu.GetLoggedInUser(cookie)
+	userRes, err := uc.uu.GetLoggedInUser(userID)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
@@ -102,11 +117,13 @@ func (uc *userController) GetLoggedInUser(c *gin.Context) {
 }
 
 func (uc *userController) CsrfToken(c *gin.Context) {
-	// セッションIDの取得
-	sessionID, err := c.Cookie("token")
+	// ブラウザごとの識別子の取得
+	clientID, err := CSRFClientID(c)
 	if err != nil {
-		sessionID = "default" // フォールバック値
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to handle CSRF token"})
+		return
 	}
+	sessionID := usecase.CSRFSessionKey(clientID)
 
 	// 既存のトークンを取得または新しいトークンを生成
 	token, err := uc.uu.GetOrGenerateCSRFToken(sessionID)
@@ -115,16 +132,8 @@ func (uc *userController) CsrfToken(c *gin.Context) {
 		return
 	}
 
-	http.SetCookie(c.Writer, &http.Cookie{
-		Name:     "csrf_token",
-		Value:    token,
-		MaxAge:   int(time.Hour / time.Second),
-		Path:     "/",
-		Domain:   os.Getenv("API_DOMAIN"),
-		SameSite: http.SameSiteNoneMode,
-		Secure:   true,
-		HttpOnly: false,
-	})
+	// ヘッダーのトークンと照合するため、トークンと同じ期間Cookieにも設定する
+	http.SetCookie(c.Writer, newAuthCookie(CSRFTokenCookie, token, int(usecase.CSRFTokenTTL.Seconds()), false))
 
 	c.JSON(http.StatusOK, gin.H{
 		"csrf_token": token,
@@ -137,13 +146,11 @@ func (uc *userController) ValidateCSRFToken(sessionID, token string) bool {
 }
 
 func (uc *userController) UpdateUser(c *gin.Context) {
-	userClaims, exists := c.Get("user")
-	if !exists {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
-	claims := userClaims.(jwt.MapClaims)
-	userId := uint(claims["user_id"].(float64))
 
 	var req api.UserUpdate
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -160,16 +167,18 @@ func (uc *userController) UpdateUser(c *gin.Context) {
 }
 
 func (uc *userController) DeleteUser(c *gin.Context) {
-	userClaims, exists := c.Get("user")
-	if !exists {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
-	claims := userClaims.(jwt.MapClaims)
-	userId := uint(claims["user_id"].(float64))
 
 	err := uc.uu.DeleteUser(userId)
 	if err != nil {
+		if errors.Is(err, usecase.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -177,13 +186,11 @@ func (uc *userController) DeleteUser(c *gin.Context) {
 }
 
 func (uc *userController) GetHouseholdUsers(c *gin.Context) {
-	userClaims, exists := c.Get("user")
-	if !exists {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
-	claims := userClaims.(jwt.MapClaims)
-	userId := uint(claims["user_id"].(float64))
 
 	users, err := uc.uu.GetHouseholdUsers(userId)
 	if err != nil {
@@ -195,16 +202,16 @@ func (uc *userController) GetHouseholdUsers(c *gin.Context) {
 
 type JoinHouseholdRequest struct {
 	InviteCode string `json:"invite_code"`
+	// Expenses は元の世帯で登録した記録の扱いです(stay, move, anonymize)。
+	Expenses string `json:"expenses"`
 }
 
 func (uc *userController) JoinHousehold(c *gin.Context) {
-	userClaims, exists := c.Get("user")
-	if !exists {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 		return
 	}
-	claims := userClaims.(jwt.MapClaims)
-	userId := uint(claims["user_id"].(float64))
 
 	var req JoinHouseholdRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -212,7 +219,21 @@ func (uc *userController) JoinHousehold(c *gin.Context) {
 		return
 	}
 
-	if err := uc.uu.JoinHousehold(userId, req.InviteCode); err != nil {
+	if err := uc.uu.JoinHousehold(userId, req.InviteCode, req.Expenses); err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, usecase.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, usecase.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, usecase.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,71 @@
+package budget
+
+import (
+	"math"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+)
+
+// Budget はカテゴリごとの月次予算を表すドメインエンティティです。
+type Budget struct {
+	ID          BudgetID
+	HouseholdID uint
+	Category    expense.Category
+	Year        Year
+	Month       Month
+	LimitAmount LimitAmount
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewBudget は新しいBudgetドメインエンティティを生成します。
+func NewBudget(householdID uint, category string, year int, month int, limitAmount int) (*Budget, error) {
+	voCategory, err := expense.NewCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	voYear, err := NewYear(year)
+	if err != nil {
+		return nil, err
+	}
+
+	voMonth, err := NewMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	voLimitAmount, err := NewLimitAmount(limitAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Budget{
+		HouseholdID: householdID,
+		Category:    voCategory,
+		Year:        voYear,
+		Month:       voMonth,
+		LimitAmount: voLimitAmount,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// Status は実績額を元に予算の消化状況を計算します。
+func (b *Budget) Status(spent int) Status {
+	limit := b.LimitAmount.Value()
+	percentUsed := float64(spent) * 100 / float64(limit)
+	return Status{
+		Spent:       spent,
+		Remaining:   limit - spent,
+		PercentUsed: math.Round(percentUsed*10) / 10,
+	}
+}
+
+// Status は予算に対する実績を示します。
+type Status struct {
+	Spent       int
+	Remaining   int
+	PercentUsed float64
+}
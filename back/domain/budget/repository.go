@@ -0,0 +1,14 @@
+package budget
+
+import "context"
+
+// BudgetRepository defines the interface for budget data operations.
+type BudgetRepository interface {
+	Create(ctx context.Context, budget *Budget) error
+	FindByID(ctx context.Context, id uint) (*Budget, error)
+	FindByHouseholdAndMonth(ctx context.Context, householdID uint, year int, month int) ([]*Budget, error)
+	Update(ctx context.Context, budget *Budget) error
+	Delete(ctx context.Context, id BudgetID) error
+	// RenameCategory は世帯の予算のカテゴリ名を変更します。
+	RenameCategory(ctx context.Context, householdID uint, oldName string, newName string) error
+}
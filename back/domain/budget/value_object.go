@@ -0,0 +1,57 @@
+package budget
+
+import "fmt"
+
+// BudgetID は予算のIDを示す値オブジェクト
+type BudgetID uint
+
+func (id BudgetID) Value() uint {
+	return uint(id)
+}
+
+// Year は予算対象の年を示す値オブジェクト
+type Year int
+
+const (
+	MinYear = 2000
+	MaxYear = 2100
+)
+
+func NewYear(year int) (Year, error) {
+	if year < MinYear || year > MaxYear {
+		return 0, fmt.Errorf("年は%dから%dの間で指定してください", MinYear, MaxYear)
+	}
+	return Year(year), nil
+}
+
+func (y Year) Value() int {
+	return int(y)
+}
+
+// Month は予算対象の月を示す値オブジェクト
+type Month int
+
+func NewMonth(month int) (Month, error) {
+	if month < 1 || month > 12 {
+		return 0, fmt.Errorf("月は1から12の間で指定してください")
+	}
+	return Month(month), nil
+}
+
+func (m Month) Value() int {
+	return int(m)
+}
+
+// LimitAmount は予算額を示す値オブジェクト
+type LimitAmount int
+
+func NewLimitAmount(amount int) (LimitAmount, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("予算額は0より大きい値を入力してください")
+	}
+	return LimitAmount(amount), nil
+}
+
+func (a LimitAmount) Value() int {
+	return int(a)
+}
@@ -0,0 +1,102 @@
+package category
+
+import (
+	"fmt"
+	"time"
+)
+
+// Category は世帯ごとに管理される支出カテゴリを表すドメインエンティティです。
+type Category struct {
+	ID          CategoryID
+	HouseholdID uint
+	Name        Name
+	Color       Color
+	Icon        Icon
+	SortOrder   int
+	Archived    bool
+	// ParentID はサブカテゴリの場合の親カテゴリIDです。
+	ParentID  *uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCategory は新しいCategoryドメインエンティティを生成します。
+func NewCategory(householdID uint, name string, color string, icon string, sortOrder int, parentID *uint) (*Category, error) {
+	voName, err := NewName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	voColor, err := NewColor(color)
+	if err != nil {
+		return nil, err
+	}
+
+	voIcon, err := NewIcon(icon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Category{
+		HouseholdID: householdID,
+		Name:        voName,
+		Color:       voColor,
+		Icon:        voIcon,
+		SortOrder:   sortOrder,
+		ParentID:    parentID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// SetParent は親カテゴリを設定します。サブカテゴリは1階層までとします。
+func (c *Category) SetParent(parent *Category) error {
+	if parent == nil {
+		c.ParentID = nil
+		return nil
+	}
+	if parent.HouseholdID != c.HouseholdID {
+		return fmt.Errorf("親カテゴリが見つかりません")
+	}
+	if c.ID != 0 && parent.ID == c.ID {
+		return fmt.Errorf("自身を親カテゴリに指定することはできません")
+	}
+	if parent.ParentID != nil {
+		return fmt.Errorf("サブカテゴリを親カテゴリに指定することはできません")
+	}
+	parentID := parent.ID.Value()
+	c.ParentID = &parentID
+	return nil
+}
+
+// defaultCategory は世帯作成時に登録する初期カテゴリの定義です。
+type defaultCategory struct {
+	name  string
+	color string
+	icon  string
+}
+
+var defaultCategories = []defaultCategory{
+	{name: "食費", color: "#F4A261", icon: "restaurant"},
+	{name: "日用品", color: "#2A9D8F", icon: "shopping_cart"},
+	{name: "住居費", color: "#264653", icon: "home"},
+	{name: "水道光熱費", color: "#E9C46A", icon: "bolt"},
+	{name: "通信費", color: "#8AB17D", icon: "wifi"},
+	{name: "交通費", color: "#457B9D", icon: "train"},
+	{name: "医療費", color: "#E76F51", icon: "local_hospital"},
+	{name: "娯楽費", color: "#B5838D", icon: "celebration"},
+	{name: "その他", color: "#6C757D", icon: "more_horiz"},
+}
+
+// NewDefaultCategories は世帯の初期カテゴリを生成します。
+func NewDefaultCategories(householdID uint) ([]*Category, error) {
+	categories := make([]*Category, 0, len(defaultCategories))
+	for i, d := range defaultCategories {
+		c, err := NewCategory(householdID, d.name, d.color, d.icon, i+1, nil)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, nil
+}
@@ -0,0 +1,14 @@
+package category
+
+import "context"
+
+// CategoryRepository defines the interface for category data operations.
+type CategoryRepository interface {
+	Create(ctx context.Context, category *Category) error
+	FindByID(ctx context.Context, id uint) (*Category, error)
+	// FindByName は世帯内で同じ名前のカテゴリを取得します。存在しない場合はnilを返します。
+	FindByName(ctx context.Context, householdID uint, name string) (*Category, error)
+	FindByHouseholdID(ctx context.Context, householdID uint, includeArchived bool) ([]*Category, error)
+	Update(ctx context.Context, category *Category) error
+	Delete(ctx context.Context, id CategoryID) error
+}
@@ -0,0 +1,68 @@
+package category
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CategoryID はカテゴリのIDを示す値オブジェクト
+type CategoryID uint
+
+func (id CategoryID) Value() uint {
+	return uint(id)
+}
+
+// Name はカテゴリ名を示す値オブジェクト
+type Name string
+
+const MaxNameLength = 50
+
+// NewName は前後の空白（全角空白を含む）を取り除いたカテゴリ名を生成します。
+func NewName(name string) (Name, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("カテゴリ名は必須です")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("カテゴリ名は%d文字以内で入力してください", MaxNameLength)
+	}
+	return Name(name), nil
+}
+
+func (n Name) Value() string {
+	return string(n)
+}
+
+// Color は表示色を示す値オブジェクト
+type Color string
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+func NewColor(color string) (Color, error) {
+	if color != "" && !colorPattern.MatchString(color) {
+		return "", fmt.Errorf("色は#RRGGBB形式で指定してください")
+	}
+	return Color(color), nil
+}
+
+func (c Color) Value() string {
+	return string(c)
+}
+
+// Icon はアイコン名を示す値オブジェクト
+type Icon string
+
+const MaxIconLength = 50
+
+func NewIcon(icon string) (Icon, error) {
+	if utf8.RuneCountInString(icon) > MaxIconLength {
+		return "", fmt.Errorf("アイコンは%d文字以内で入力してください", MaxIconLength)
+	}
+	return Icon(icon), nil
+}
+
+func (i Icon) Value() string {
+	return string(i)
+}
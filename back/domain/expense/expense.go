@@ -1,26 +1,35 @@
 package expense
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/yanatoritakuma/budget/back/domain/user"
 )
 
 type Expense struct {
-	ID        ExpenseID
-	Amount    Amount
-	StoreName StoreName
-	Date      time.Time
-	Category  Category
-	Memo      Memo
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	UserID    UserID
-	PayerID   PayerID
+	ID ExpenseID
+	// HouseholdID は支出が属する世帯です。登録時に決まり、登録者が世帯を移っても変わりません。
+	HouseholdID uint
+	Amount      Amount
+	StoreName   StoreName
+	Date        time.Time
+	Category    Category
+	// CategoryID は世帯のカテゴリエンティティへの参照です。
+	CategoryID *uint
+	Memo       Memo
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	// UserID は登録者です。登録者が退会した場合は0になります。
+	UserID  UserID
+	PayerID PayerID
+	Shares  []Share
+	// RecurringExpenseID は定期支出から生成された場合の生成元IDです。
+	RecurringExpenseID *uint
 }
 
 // NewExpense creates a new Expense domain entity.
-func NewExpense(amount int, storeName string, date time.Time, category string, memo string, userID uint, payerID uint) (*Expense, error) {
+func NewExpense(householdID uint, amount int, storeName string, date time.Time, category string, memo string, userID uint, payerID uint) (*Expense, error) {
 	voAmount, err := NewAmount(amount)
 	if err != nil {
 		return nil, err
@@ -42,14 +51,62 @@ func NewExpense(amount int, storeName string, date time.Time, category string, m
 	}
 
 	return &Expense{
-		Amount:    voAmount,
-		StoreName: voStoreName,
-		Date:      date,
-		Category:  voCategory,
-		Memo:      voMemo,
-		UserID:    UserID(user.UserID(userID)),
-		PayerID:   PayerID(user.UserID(payerID)),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		HouseholdID: householdID,
+		Amount:      voAmount,
+		StoreName:   voStoreName,
+		Date:        date,
+		Category:    voCategory,
+		Memo:        voMemo,
+		UserID:      UserID(user.UserID(userID)),
+		PayerID:     PayerID(user.UserID(payerID)),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}, nil
 }
+
+// InvolvesFormerMember は支払者または負担者に匿名化された元メンバーが含まれるかどうかを返します。
+func (e *Expense) InvolvesFormerMember() bool {
+	if e.PayerID == FormerMemberID {
+		return true
+	}
+	for _, share := range e.Shares {
+		if share.UserID == FormerMemberID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCategory は支出を世帯のカテゴリに紐付けます。
+func (e *Expense) SetCategory(categoryID uint, name string) error {
+	voCategory, err := NewCategory(name)
+	if err != nil {
+		return err
+	}
+	e.Category = voCategory
+	e.CategoryID = &categoryID
+	return nil
+}
+
+// SetShares sets custom per-member shares which override the household split rule.
+func (e *Expense) SetShares(shares []Share) error {
+	total := 0
+	seen := make(map[UserID]bool, len(shares))
+	for _, share := range shares {
+		if seen[share.UserID] {
+			return fmt.Errorf("同じユーザーの負担額が重複しています")
+		}
+		seen[share.UserID] = true
+		total += share.Amount.Value()
+	}
+	if len(shares) > 0 && total != e.Amount.Value() {
+		return fmt.Errorf("負担額の合計(%d)が金額(%d)と一致しません", total, e.Amount.Value())
+	}
+	e.Shares = shares
+	return nil
+}
+
+// HasCustomShares reports whether the expense has custom per-member shares.
+func (e *Expense) HasCustomShares() bool {
+	return len(e.Shares) > 0
+}
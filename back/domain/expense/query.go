@@ -0,0 +1,126 @@
+package expense
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SortField は支出一覧の並び替え項目を示す値オブジェクト
+type SortField string
+
+const (
+	SortFieldDate      SortField = "date"
+	SortFieldAmount    SortField = "amount"
+	SortFieldCreatedAt SortField = "created_at"
+)
+
+func NewSortField(field string) (SortField, error) {
+	switch f := SortField(field); f {
+	case "":
+		return SortFieldDate, nil
+	case SortFieldDate, SortFieldAmount, SortFieldCreatedAt:
+		return f, nil
+	default:
+		return "", fmt.Errorf("不正な並び替え項目です: %s", field)
+	}
+}
+
+// SortOrder は並び順を示す値オブジェクト
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+func NewSortOrder(order string) (SortOrder, error) {
+	switch o := SortOrder(order); o {
+	case "":
+		return SortOrderDesc, nil
+	case SortOrderAsc, SortOrderDesc:
+		return o, nil
+	default:
+		return "", fmt.Errorf("不正な並び順です: %s", order)
+	}
+}
+
+// ErrInvalidCursor はカーソルの形式や値が不正であることを表します。
+var ErrInvalidCursor = errors.New("不正なカーソルです")
+
+// Cursor はカーソルページネーションの位置を示します。
+// 直前のページ最後の支出の並び替え項目の値とIDを保持します。
+type Cursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// Encode はカーソルをクエリパラメータで扱える文字列に変換します。
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// MaxQueryLimit は1ページで取得できる最大件数です。
+const MaxQueryLimit = 500
+
+// Query は支出一覧の検索条件です。
+// From、To はその日を含む日付で、Limit が0の場合は件数を制限しません。
+type Query struct {
+	HouseholdID uint
+	From        *time.Time
+	To          *time.Time
+	Categories  []string
+	CategoryIDs []uint
+	PayerID     *uint
+	AmountMin   *int
+	AmountMax   *int
+	Text        string
+	SortField   SortField
+	SortOrder   SortOrder
+	Cursor      *Cursor
+	Limit       int
+}
+
+// Validate は検索条件の整合性を検証します。
+func (q Query) Validate() error {
+	if q.From != nil && q.To != nil && q.To.Before(*q.From) {
+		return fmt.Errorf("終了日は開始日以降の日付を指定してください")
+	}
+	if q.AmountMin != nil && q.AmountMax != nil && *q.AmountMax < *q.AmountMin {
+		return fmt.Errorf("金額の上限は下限以上の値を指定してください")
+	}
+	if q.Limit < 0 || q.Limit > MaxQueryLimit {
+		return fmt.Errorf("取得件数は1から%dの間で指定してください", MaxQueryLimit)
+	}
+	return nil
+}
+
+// CursorOf は支出の位置を示すカーソルを返します。
+func (q Query) CursorOf(e *Expense) Cursor {
+	var value string
+	switch q.SortField {
+	case SortFieldAmount:
+		value = strconv.Itoa(e.Amount.Value())
+	case SortFieldCreatedAt:
+		value = e.CreatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		value = e.Date.UTC().Format(time.RFC3339Nano)
+	}
+	return Cursor{Value: value, ID: e.ID.Value()}
+}
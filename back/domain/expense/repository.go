@@ -1,11 +1,35 @@
 package expense
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ExpenseRepository defines the interface for expense data operations.
 type ExpenseRepository interface {
 	CreateExpense(ctx context.Context, expense *Expense) error
-	GetExpense(ctx context.Context, householdID uint, year int, month int, category *string) ([]*Expense, error)
+	// CreateExpenseIfNotExists は同じ定期支出・日付の支出が未登録の場合のみ作成し、作成したかどうかを返します。
+	CreateExpenseIfNotExists(ctx context.Context, expense *Expense) (bool, error)
+	// GetExpense は期間内の支出を日付順に返します。from、toともにその日を含みます。
+	GetExpense(ctx context.Context, householdID uint, from time.Time, to time.Time, category *string) ([]*Expense, error)
+	// FindByID は支出を取得します。存在しない場合は nil を返します。
+	FindByID(ctx context.Context, id ExpenseID) (*Expense, error)
+	// Find は検索条件に一致する支出を並び替え順に返します。
+	Find(ctx context.Context, q Query) ([]*Expense, error)
 	UpdateExpense(ctx context.Context, expense *Expense) error
 	DeleteExpense(ctx context.Context, expenseId ExpenseID) error
+	// GetCategoryTotals は期間内のカテゴリごとの支出合計を返します。from、toともにその日を含みます。
+	GetCategoryTotals(ctx context.Context, householdID uint, from time.Time, to time.Time) (map[string]int, error)
+	// GetMonthlyTotals は期間内の月ごとの支出合計を返します。キーは月(1-12)で、月は monthStartDay 日から始まります。
+	GetMonthlyTotals(ctx context.Context, householdID uint, from time.Time, to time.Time, monthStartDay int) (map[int]int, error)
+	// GetSummary は期間内の支出を集計単位ごとに集計します。週と月の区切りは calendar に従います。
+	GetSummary(ctx context.Context, householdID uint, period SummaryPeriod, groupBy SummaryGroupBy, calendar Calendar) ([]SummaryRow, error)
+	// RenameCategory はカテゴリに紐付く支出のカテゴリ名を更新します。
+	RenameCategory(ctx context.Context, categoryID uint, name string) error
+	// Anonymize は世帯の支出から userID への参照を外します。
+	// 登録者は未設定とし、支払者と負担者は FormerMemberID に置き換えます。
+	Anonymize(ctx context.Context, householdID uint, userID uint) error
+	// MoveToHousehold はユーザーが世帯で登録した支出を、ユーザー個人の支出として別の世帯に移します。
+	// 支払者をユーザー本人とし、負担額の設定を削除し、カテゴリを categoryIDs に従って付け替えます。
+	MoveToHousehold(ctx context.Context, fromHouseholdID uint, userID uint, toHouseholdID uint, categoryIDs map[uint]uint) error
 }
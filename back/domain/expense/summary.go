@@ -0,0 +1,67 @@
+package expense
+
+import (
+	"fmt"
+	"time"
+)
+
+// SummaryGroupBy は支出集計の集計単位を示す値オブジェクト
+type SummaryGroupBy string
+
+const (
+	SummaryGroupByCategory SummaryGroupBy = "category"
+	SummaryGroupByPayer    SummaryGroupBy = "payer"
+	SummaryGroupByStore    SummaryGroupBy = "store"
+	SummaryGroupByDay      SummaryGroupBy = "day"
+	SummaryGroupByWeek     SummaryGroupBy = "week"
+	SummaryGroupByMonth    SummaryGroupBy = "month"
+)
+
+func NewSummaryGroupBy(groupBy string) (SummaryGroupBy, error) {
+	switch g := SummaryGroupBy(groupBy); g {
+	case SummaryGroupByCategory, SummaryGroupByPayer, SummaryGroupByStore,
+		SummaryGroupByDay, SummaryGroupByWeek, SummaryGroupByMonth:
+		return g, nil
+	default:
+		return "", fmt.Errorf("不正な集計単位です: %s", groupBy)
+	}
+}
+
+// IsPeriod は日・週・月といった期間による集計かどうかを返します。
+func (g SummaryGroupBy) IsPeriod() bool {
+	return g == SummaryGroupByDay || g == SummaryGroupByWeek || g == SummaryGroupByMonth
+}
+
+// SummaryPeriod は集計対象の期間を示す値オブジェクトです。From、Toともにその日を含みます。
+type SummaryPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// MaxSummaryDays は一度に集計できる最大日数です。
+const MaxSummaryDays = 366 * 5
+
+func NewSummaryPeriod(from time.Time, to time.Time) (SummaryPeriod, error) {
+	if to.Before(from) {
+		return SummaryPeriod{}, fmt.Errorf("終了日は開始日以降の日付を指定してください")
+	}
+	if to.Sub(from) > MaxSummaryDays*24*time.Hour {
+		return SummaryPeriod{}, fmt.Errorf("集計期間は%d日以内で指定してください", MaxSummaryDays)
+	}
+	return SummaryPeriod{From: from, To: to}, nil
+}
+
+// Calendar は期間による集計での週と月の区切りを示します。
+type Calendar struct {
+	WeekStart time.Weekday
+	// MonthStartDay は月の始まりの日です。月はその月の始まりの日から翌月の始まりの日の前日までです。
+	MonthStartDay int
+}
+
+// SummaryRow は集計結果の1行です。
+type SummaryRow struct {
+	Key   string
+	Label string
+	Total int
+	Count int
+}
@@ -2,6 +2,7 @@ package expense
 
 import (
 	"fmt"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/yanatoritakuma/budget/back/domain/user"
@@ -49,8 +50,9 @@ func (n StoreName) Value() string {
 // Category はカテゴリを示す値オブジェクト
 type Category string
 
+// 表記揺れを防ぐため前後の空白は取り除きます。
 func NewCategory(category string) (Category, error) {
-	// TODO: カテゴリのバリデーションルール（例: 許容リスト）を追加
+	category = strings.TrimSpace(category)
 	if category == "" {
 		return "", fmt.Errorf("カテゴリは必須です")
 	}
@@ -82,3 +84,20 @@ type UserID user.UserID
 
 // PayerID は支払者IDの値オブジェクト
 type PayerID user.UserID
+
+// FormerMemberID は匿名化により参照を外した、世帯を抜けたメンバーを示す支払者・負担者のIDです。どのユーザーも指しません。
+const FormerMemberID = 0
+
+// Share はメンバーごとの負担額を示す値オブジェクト
+type Share struct {
+	UserID UserID
+	Amount Amount
+}
+
+func NewShare(userID uint, amount int) (Share, error) {
+	voAmount, err := NewAmount(amount)
+	if err != nil {
+		return Share{}, fmt.Errorf("負担額が不正です: %w", err)
+	}
+	return Share{UserID: UserID(user.UserID(userID)), Amount: voAmount}, nil
+}
@@ -0,0 +1,32 @@
+package household
+
+import "fmt"
+
+// Handoff はメンバーが世帯を抜けるときの、メンバーが登録した過去の記録の扱いを示す値オブジェクト
+type Handoff string
+
+const (
+	// HandoffStay は記録を世帯に残します。支出の登録者・支払者・負担者は変更しません。
+	HandoffStay Handoff = "stay"
+	// HandoffMove は記録をメンバーとともに移動先の世帯へ移し、メンバー個人の記録とします。
+	HandoffMove Handoff = "move"
+	// HandoffAnonymize は記録を世帯に残し、登録者・支払者・負担者・受取人からメンバーへの参照を外します。
+	// 精算では元メンバーの負担分を支払者が負担し、元メンバーが支払った支出は対象外になります。
+	HandoffAnonymize Handoff = "anonymize"
+)
+
+// NewHandoff は記録の扱いを生成します。指定がない場合は世帯に残します。
+func NewHandoff(handoff string) (Handoff, error) {
+	switch Handoff(handoff) {
+	case HandoffStay, HandoffMove, HandoffAnonymize:
+		return Handoff(handoff), nil
+	case "":
+		return HandoffStay, nil
+	default:
+		return "", fmt.Errorf("記録の扱いが不正です: %s", handoff)
+	}
+}
+
+func (h Handoff) Value() string {
+	return string(h)
+}
@@ -4,29 +4,27 @@ import "time"
 
 // Household is the domain entity for a household.
 type Household struct {
-	ID         HouseholdID
-	Name       Name
-	InviteCode InviteCode
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID        HouseholdID
+	Name      Name
+	SplitRule SplitRule
+	Settings  Settings
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // NewHousehold creates a new Household domain entity.
-func NewHousehold(name string, inviteCode string) (*Household, error) {
+func NewHousehold(name string) (*Household, error) {
 	voName, err := NewName(name)
 	if err != nil {
 		return nil, err
 	}
-	voInviteCode, err := NewInviteCode(inviteCode)
-	if err != nil {
-		return nil, err
-	}
 
 	return &Household{
-		Name:       voName,
-		InviteCode: voInviteCode,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		Name:      voName,
+		SplitRule: SplitRule{Method: SplitMethodEqual},
+		Settings:  DefaultSettings(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}, nil
 }
 
@@ -36,8 +34,14 @@ func (h *Household) UpdateName(newName Name) {
 	h.UpdatedAt = time.Now()
 }
 
-// GenerateNewInviteCode generates a new invite code for the household.
-func (h *Household) GenerateNewInviteCode(newCode InviteCode) {
-	h.InviteCode = newCode
+// ChangeSplitRule changes the household's expense split rule.
+func (h *Household) ChangeSplitRule(rule SplitRule) {
+	h.SplitRule = rule
+	h.UpdatedAt = time.Now()
+}
+
+// ChangeSettings changes the household's currency, timezone and period settings.
+func (h *Household) ChangeSettings(settings Settings) {
+	h.Settings = settings
 	h.UpdatedAt = time.Now()
 }
@@ -0,0 +1,131 @@
+package household
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultInvitationTTL は有効期間を指定しない招待の有効期間です。
+	DefaultInvitationTTL = 7 * 24 * time.Hour
+	// MaxInvitationTTL は招待の有効期間の上限です。
+	MaxInvitationTTL = 30 * 24 * time.Hour
+)
+
+// InvitationStatus は招待の状態を示します。
+type InvitationStatus string
+
+const (
+	InvitationStatusActive  InvitationStatus = "active"
+	InvitationStatusExpired InvitationStatus = "expired"
+	InvitationStatusRevoked InvitationStatus = "revoked"
+	InvitationStatusUsedUp  InvitationStatus = "used_up"
+)
+
+// Invitation は世帯への招待です。世帯は複数の招待を同時に発行できます。
+type Invitation struct {
+	ID          uint
+	HouseholdID uint
+	Code        InviteCode
+	// CreatedBy は招待を発行したユーザーです。ユーザーが削除された場合は0になります。
+	CreatedBy uint
+	// Role は招待で参加したユーザーの役割です。
+	Role Role
+	// TargetEmail が設定されている場合、そのメールアドレスを確認済みのユーザーのみ参加できます。
+	TargetEmail string
+	// TargetProvider と TargetSubject が設定されている場合、その外部IDプロバイダのアカウントを紐付けたユーザーのみ参加できます。
+	TargetProvider string
+	TargetSubject  string
+	// MaxUses は参加できる人数の上限です。0の場合は制限しません。
+	MaxUses   int
+	Uses      int
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewInvitation は招待を生成します。所有者として招待することはできません。
+func NewInvitation(householdID, createdBy uint, code InviteCode, role Role, ttl time.Duration, maxUses int) (*Invitation, error) {
+	if role == RoleOwner {
+		return nil, fmt.Errorf("所有者として招待することはできません")
+	}
+	if ttl <= 0 || ttl > MaxInvitationTTL {
+		return nil, fmt.Errorf("有効期間は%d日以内で指定してください", int(MaxInvitationTTL.Hours()/24))
+	}
+	if maxUses < 0 {
+		return nil, fmt.Errorf("利用回数の上限は0以上で指定してください")
+	}
+	now := time.Now()
+	return &Invitation{
+		HouseholdID: householdID,
+		Code:        code,
+		CreatedBy:   createdBy,
+		Role:        role,
+		MaxUses:     maxUses,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}, nil
+}
+
+// RestrictToEmail は招待をメールアドレスの持ち主に限定します。
+func (i *Invitation) RestrictToEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return fmt.Errorf("無効なメールアドレス形式です: %s", email)
+	}
+	i.TargetEmail = email
+	return nil
+}
+
+// RestrictToIdentity は招待を外部IDプロバイダのアカウントの持ち主に限定します。
+// subject はプロバイダが発行したユーザーID（LINEのユーザーIDなど）です。
+func (i *Invitation) RestrictToIdentity(provider, subject string) error {
+	if provider == "" || subject == "" {
+		return fmt.Errorf("プロバイダとユーザーIDは両方指定してください")
+	}
+	i.TargetProvider = provider
+	i.TargetSubject = subject
+	return nil
+}
+
+// Status は招待の現在の状態を返します。
+func (i *Invitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	case i.MaxUses > 0 && i.Uses >= i.MaxUses:
+		return InvitationStatusUsedUp
+	default:
+		return InvitationStatusActive
+	}
+}
+
+// AllowsEmail は確認済みのメールアドレスを持つユーザーが招待の対象かを返します。
+func (i *Invitation) AllowsEmail(verifiedEmail string) bool {
+	return i.TargetEmail == "" || strings.EqualFold(i.TargetEmail, verifiedEmail)
+}
+
+// IsTargetedToIdentity は招待が外部IDプロバイダのアカウントに限定されているかを返します。
+func (i *Invitation) IsTargetedToIdentity() bool {
+	return i.TargetProvider != ""
+}
+
+// Revoke は招待を取り消します。
+func (i *Invitation) Revoke(now time.Time) {
+	if i.RevokedAt == nil {
+		i.RevokedAt = &now
+	}
+}
+
+// Redemption は招待でユーザーが世帯に参加した記録です。
+type Redemption struct {
+	ID           uint
+	InvitationID uint
+	// UserID は参加したユーザーです。ユーザーが削除された場合は0になります。
+	UserID   uint
+	JoinedAt time.Time
+}
@@ -0,0 +1,89 @@
+package household
+
+import (
+	"fmt"
+	"time"
+)
+
+// Role は世帯メンバーの役割を示す値オブジェクト
+type Role string
+
+const (
+	// RoleOwner は世帯の所有者です。世帯に1人だけ存在し、招待コードの発行や所有権の移譲ができます。
+	RoleOwner Role = "owner"
+	// RoleAdmin は世帯の設定やメンバーの役割を管理できます。
+	RoleAdmin Role = "admin"
+	// RoleMember は支出などの記録を登録・編集できます。
+	RoleMember Role = "member"
+	// RoleViewer は閲覧のみできます。
+	RoleViewer Role = "viewer"
+)
+
+func NewRole(role string) (Role, error) {
+	switch Role(role) {
+	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
+		return Role(role), nil
+	default:
+		return "", fmt.Errorf("役割が不正です: %s", role)
+	}
+}
+
+func (r Role) Value() string {
+	return string(r)
+}
+
+// CanEdit は支出などの記録を登録・編集できるかを返します。
+func (r Role) CanEdit() bool {
+	return r != RoleViewer
+}
+
+// CanManage は世帯の設定やメンバーの役割を変更できるかを返します。
+func (r Role) CanManage() bool {
+	return r == RoleOwner || r == RoleAdmin
+}
+
+// CanAssign は他のメンバーの役割を from から to に変更できるかを返します。
+// 所有者は所有者以外の役割を変更でき、管理者は一般メンバーと閲覧者の間でのみ変更できます。
+// 所有者の変更は所有権の移譲で行います。
+func (r Role) CanAssign(from, to Role) bool {
+	if from == RoleOwner || to == RoleOwner {
+		return false
+	}
+	switch r {
+	case RoleOwner:
+		return true
+	case RoleAdmin:
+		return from != RoleAdmin && to != RoleAdmin
+	default:
+		return false
+	}
+}
+
+// CanRemove は target の役割のメンバーを世帯から外せるかを返します。
+// 所有者は所有者以外を、管理者は一般メンバーと閲覧者を外せます。
+func (r Role) CanRemove(target Role) bool {
+	return r.CanAssign(target, target)
+}
+
+// Member はユーザーの世帯への所属と役割を示します。
+type Member struct {
+	HouseholdID uint
+	UserID      uint
+	Role        Role
+	JoinedAt    time.Time
+}
+
+// NewMember は世帯のメンバーを生成します。
+func NewMember(householdID, userID uint, role Role) *Member {
+	return &Member{
+		HouseholdID: householdID,
+		UserID:      userID,
+		Role:        role,
+		JoinedAt:    time.Now(),
+	}
+}
+
+// ChangeRole はメンバーの役割を変更します。
+func (m *Member) ChangeRole(role Role) {
+	m.Role = role
+}
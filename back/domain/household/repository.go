@@ -1,11 +1,38 @@
 package household
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // HouseholdRepository is the interface for persisting Household domain entities.
 type HouseholdRepository interface {
 	FindByID(ctx context.Context, id uint) (*Household, error)
-	FindByInviteCode(ctx context.Context, inviteCode string) (*Household, error)
 	Create(ctx context.Context, household *Household) error
 	Update(ctx context.Context, household *Household) error
+	// Delete は世帯を削除します。世帯のカテゴリ・予算・定期支出・招待も削除されます。
+	Delete(ctx context.Context, id uint) error
+}
+
+// MemberRepository は世帯のメンバーと役割を永続化するためのインターフェースです。
+type MemberRepository interface {
+	// FindByUserID はユーザーの所属を返します。所属していない場合は nil を返します。
+	FindByUserID(ctx context.Context, userID uint) (*Member, error)
+	FindByHouseholdID(ctx context.Context, householdID uint) ([]*Member, error)
+	// Save はユーザーの所属を作成または更新します。ユーザーは1つの世帯にのみ所属します。
+	Save(ctx context.Context, member *Member) error
+}
+
+// InvitationRepository は世帯への招待と参加の記録を永続化するためのインターフェースです。
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *Invitation) error
+	// FindByID と FindByCode は招待が存在しない場合に nil を返します。
+	FindByID(ctx context.Context, id uint) (*Invitation, error)
+	FindByCode(ctx context.Context, code string) (*Invitation, error)
+	FindByHouseholdID(ctx context.Context, householdID uint) ([]*Invitation, error)
+	Update(ctx context.Context, invitation *Invitation) error
+	// Redeem は招待の利用回数を1増やし、ユーザーの参加を記録します。
+	// 取り消し済み・有効期限切れ・上限に達した招待の場合は記録せずに false を返します。
+	Redeem(ctx context.Context, invitationID uint, userID uint, now time.Time) (bool, error)
+	FindRedemptionsByHouseholdID(ctx context.Context, householdID uint) ([]*Redemption, error)
 }
@@ -0,0 +1,123 @@
+package household
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	// 実行環境にタイムゾーンデータベースが無くても IANA タイムゾーンを扱えるよう埋め込む
+	_ "time/tzdata"
+)
+
+const (
+	DefaultCurrency = "JPY"
+	DefaultTimezone = "Asia/Tokyo"
+	// MaxMonthStartDay は月の始まりに指定できる最後の日です。全ての月に存在する日に限ります。
+	MaxMonthStartDay = 28
+)
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// weekdayNames は週の始まりの曜日の表記です。
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Settings は世帯の表示通貨・タイムゾーンと、集計に使う月と週の始まりを示す値オブジェクト
+// 支出の日付はUTCの0時で表したカレンダー上の日付として扱い、タイムゾーンは時刻の日付への変換に使用します。
+type Settings struct {
+	// Currency は ISO 4217 の通貨コードです。
+	Currency string
+	// Timezone は IANA タイムゾーン名です。
+	Timezone string
+	// MonthStartDay は月の始まりの日です。25 の場合、1月は1月25日から2月24日までになります。
+	MonthStartDay int
+	WeekStart     time.Weekday
+	location      *time.Location
+}
+
+// DefaultSettings は設定を変更していない世帯の設定を返します。
+func DefaultSettings() Settings {
+	settings, _ := NewSettings(DefaultCurrency, DefaultTimezone, 1, "monday")
+	return settings
+}
+
+// NewSettings は世帯の設定を生成します。
+func NewSettings(currency string, timezone string, monthStartDay int, weekStart string) (Settings, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !currencyPattern.MatchString(currency) {
+		return Settings{}, fmt.Errorf("通貨は ISO 4217 の3文字のコードで指定してください: %s", currency)
+	}
+	// "Local" や空文字は実行環境によって意味が変わるため受け付けない
+	if timezone == "" || timezone == "Local" {
+		return Settings{}, fmt.Errorf("タイムゾーンを指定してください")
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Settings{}, fmt.Errorf("タイムゾーンが不正です: %s", timezone)
+	}
+	if monthStartDay < 1 || monthStartDay > MaxMonthStartDay {
+		return Settings{}, fmt.Errorf("月の始まりの日は1から%dの間で指定してください", MaxMonthStartDay)
+	}
+	weekday, ok := weekdayNames[strings.ToLower(weekStart)]
+	if !ok {
+		return Settings{}, fmt.Errorf("週の始まりの曜日が不正です: %s", weekStart)
+	}
+
+	return Settings{
+		Currency:      currency,
+		Timezone:      timezone,
+		MonthStartDay: monthStartDay,
+		WeekStart:     weekday,
+		location:      location,
+	}, nil
+}
+
+// WeekStartName は週の始まりの曜日の表記を返します。
+func (s Settings) WeekStartName() string {
+	return strings.ToLower(s.WeekStart.String())
+}
+
+// Location は世帯のタイムゾーンを返します。
+func (s Settings) Location() *time.Location {
+	if s.location == nil {
+		return time.UTC
+	}
+	return s.location
+}
+
+// DateOf は時刻を世帯のタイムゾーンでのカレンダー上の日付に変換し、UTCの0時で返します。
+// 日付のみの値は変換せずにそのまま扱ってください。
+func (s Settings) DateOf(t time.Time) time.Time {
+	local := t.In(s.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// MonthRange は year 年 month 月の初日と末日を返します。
+// 月の始まりの日が1日以外の場合、月はその月の始まりの日から翌月の始まりの日の前日までです。
+func (s Settings) MonthRange(year int, month int) (time.Time, time.Time) {
+	from := time.Date(year, time.Month(month), s.monthStartDay(), 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+	return from, to
+}
+
+// YearRange は year 年の1月の初日と12月の末日を返します。
+func (s Settings) YearRange(year int) (time.Time, time.Time) {
+	from, _ := s.MonthRange(year, 1)
+	_, to := s.MonthRange(year, 12)
+	return from, to
+}
+
+func (s Settings) monthStartDay() int {
+	if s.MonthStartDay < 1 {
+		return 1
+	}
+	return s.MonthStartDay
+}
@@ -0,0 +1,52 @@
+package household
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettingsDateOf(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		timezone string
+		t        time.Time
+		want     time.Time
+	}{
+		{"positive offset, next day", "Asia/Tokyo", time.Date(2026, 9, 30, 16, 0, 0, 0, time.UTC), date(2026, 10, 1)},
+		{"positive offset, same day", "Asia/Tokyo", time.Date(2026, 10, 1, 14, 59, 0, 0, time.UTC), date(2026, 10, 1)},
+		{"negative offset, previous day", "America/Los_Angeles", time.Date(2026, 10, 1, 6, 59, 0, 0, time.UTC), date(2026, 9, 30)},
+		{"negative offset, same day", "America/Los_Angeles", time.Date(2026, 10, 1, 7, 0, 0, 0, time.UTC), date(2026, 10, 1)},
+		// UTCの0時ちょうどでも日付とはみなさず、世帯のタイムゾーンに変換する
+		{"negative offset at UTC midnight", "America/Los_Angeles", date(2026, 10, 1), date(2026, 9, 30)},
+		{"negative offset, standard time", "America/Los_Angeles", time.Date(2026, 12, 1, 7, 59, 0, 0, time.UTC), date(2026, 11, 30)},
+		{"instant in another zone", "America/Los_Angeles", time.Date(2026, 10, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60)), date(2026, 9, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := NewSettings(DefaultCurrency, tt.timezone, 1, "monday")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := settings.DateOf(tt.t); !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("DateOf(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettingsMonthRange(t *testing.T) {
+	settings, err := NewSettings(DefaultCurrency, "America/Los_Angeles", 25, "sunday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, to := settings.MonthRange(2026, 12)
+	if want := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("MonthRange() from = %v, want %v", from, want)
+	}
+	if want := time.Date(2027, 1, 24, 0, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Errorf("MonthRange() to = %v, want %v", to, want)
+	}
+}
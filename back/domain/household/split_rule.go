@@ -0,0 +1,140 @@
+package household
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SplitMethod は世帯メンバー間の支出の分担方法を示す値オブジェクト
+type SplitMethod string
+
+const (
+	// SplitMethodEqual はメンバー全員で均等に分担します。
+	SplitMethodEqual SplitMethod = "equal"
+	// SplitMethodFixed はメンバーごとに設定した割合で分担します。
+	SplitMethodFixed SplitMethod = "fixed"
+)
+
+func NewSplitMethod(method string) (SplitMethod, error) {
+	switch SplitMethod(method) {
+	case SplitMethodEqual, SplitMethodFixed:
+		return SplitMethod(method), nil
+	case "":
+		return SplitMethodEqual, nil
+	default:
+		return "", fmt.Errorf("分担方法が不正です: %s", method)
+	}
+}
+
+func (m SplitMethod) Value() string {
+	return string(m)
+}
+
+// SplitShare はメンバーごとの分担割合(%)を示します。
+type SplitShare struct {
+	UserID  uint
+	Percent int
+}
+
+// SplitRule は世帯の支出分担ルールを示す値オブジェクト
+type SplitRule struct {
+	Method SplitMethod
+	Shares []SplitShare
+}
+
+// NewSplitRule は分担ルールを生成します。固定割合の場合は合計が100%である必要があります。
+func NewSplitRule(method string, shares []SplitShare) (SplitRule, error) {
+	voMethod, err := NewSplitMethod(method)
+	if err != nil {
+		return SplitRule{}, err
+	}
+	if voMethod == SplitMethodEqual {
+		return SplitRule{Method: voMethod}, nil
+	}
+
+	total := 0
+	seen := make(map[uint]bool, len(shares))
+	for _, share := range shares {
+		if share.Percent < 0 || share.Percent > 100 {
+			return SplitRule{}, fmt.Errorf("分担割合は0から100の間で指定してください")
+		}
+		if seen[share.UserID] {
+			return SplitRule{}, fmt.Errorf("同じユーザーの分担割合が重複しています")
+		}
+		seen[share.UserID] = true
+		total += share.Percent
+	}
+	if total != 100 {
+		return SplitRule{}, fmt.Errorf("分担割合の合計は100%%である必要があります")
+	}
+
+	return SplitRule{Method: voMethod, Shares: shares}, nil
+}
+
+// Allocate は金額をルールに従ってメンバーに割り当てます。
+// 端数は割り当て後の余りが大きいメンバーから1円ずつ配分し、合計が元の金額と一致するようにします。
+func (r SplitRule) Allocate(amount int, memberIDs []uint) map[uint]int {
+	weights := make(map[uint]int)
+	switch r.Method {
+	case SplitMethodFixed:
+		for _, share := range r.Shares {
+			if share.Percent > 0 {
+				weights[share.UserID] = share.Percent
+			}
+		}
+	default:
+		for _, id := range memberIDs {
+			weights[id] = 1
+		}
+	}
+	return allocateByWeight(amount, weights)
+}
+
+func allocateByWeight(amount int, weights map[uint]int) map[uint]int {
+	result := make(map[uint]int, len(weights))
+	totalWeight := 0
+	ids := make([]uint, 0, len(weights))
+	for id, w := range weights {
+		totalWeight += w
+		ids = append(ids, id)
+	}
+	if totalWeight == 0 {
+		return result
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	remainders := make(map[uint]int, len(ids))
+	allocated := 0
+	for _, id := range ids {
+		share := amount * weights[id] / totalWeight
+		result[id] = share
+		remainders[id] = amount * weights[id] % totalWeight
+		allocated += share
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool { return remainders[ids[i]] > remainders[ids[j]] })
+	for i := 0; allocated < amount; i++ {
+		result[ids[i%len(ids)]]++
+		allocated++
+	}
+	return result
+}
+
+// WithoutMember は世帯を抜けたメンバーの分担割合を除いたルールを返します。
+// 固定割合で残りの合計が100%にならない場合は均等割に戻します。
+func (r SplitRule) WithoutMember(userID uint) SplitRule {
+	if r.Method != SplitMethodFixed {
+		return r
+	}
+	shares := make([]SplitShare, 0, len(r.Shares))
+	for _, share := range r.Shares {
+		if share.UserID != userID {
+			shares = append(shares, share)
+		}
+	}
+	rule, err := NewSplitRule(SplitMethodFixed.Value(), shares)
+	if err != nil {
+		return SplitRule{Method: SplitMethodEqual}
+	}
+	return rule
+}
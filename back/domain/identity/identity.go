@@ -0,0 +1,35 @@
+package identity
+
+import (
+	"errors"
+	"time"
+)
+
+// ProviderLINE はLINEログインのプロバイダ名です。
+const ProviderLINE = "line"
+
+// Identity はユーザーに紐付けられた外部IDプロバイダのアカウントです。
+// 1人のユーザーは複数のプロバイダのアカウントを紐付けられます。
+type Identity struct {
+	ID        uint
+	UserID    uint
+	Provider  string
+	Subject   string
+	CreatedAt time.Time
+}
+
+// NewIdentity は新しいIdentityを生成します。Subject はプロバイダが発行したIDトークンの sub クレームです。
+func NewIdentity(userID uint, provider, subject string) (*Identity, error) {
+	if provider == "" {
+		return nil, errors.New("provider is required")
+	}
+	if subject == "" {
+		return nil, errors.New("subject is required")
+	}
+	return &Identity{
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		CreatedAt: time.Now(),
+	}, nil
+}
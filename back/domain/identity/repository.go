@@ -0,0 +1,13 @@
+package identity
+
+import "context"
+
+// IdentityRepository は外部IDプロバイダとの紐付けの永続化を扱います。
+type IdentityRepository interface {
+	// FindByProviderSubject はプロバイダとsubjectに一致する紐付けを返します。存在しない場合は nil を返します。
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*Identity, error)
+	FindByUserID(ctx context.Context, userID uint) ([]*Identity, error)
+	Create(ctx context.Context, identity *Identity) error
+	// Delete はユーザーの紐付けを削除し、削除したかどうかを返します。
+	Delete(ctx context.Context, userID uint, id uint) (bool, error)
+}
@@ -0,0 +1,56 @@
+package income
+
+import (
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/user"
+)
+
+// Income は収入を表すドメインエンティティです。
+type Income struct {
+	ID          IncomeID
+	Amount      Amount
+	Source      Source
+	Date        time.Time
+	Category    Category
+	Memo        Memo
+	UserID      UserID
+	RecipientID RecipientID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewIncome は新しいIncomeドメインエンティティを生成します。
+func NewIncome(amount int, source string, date time.Time, category string, memo string, userID uint, recipientID uint) (*Income, error) {
+	voAmount, err := NewAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	voSource, err := NewSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	voCategory, err := NewCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	voMemo, err := NewMemo(memo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Income{
+		Amount:      voAmount,
+		Source:      voSource,
+		Date:        date,
+		Category:    voCategory,
+		Memo:        voMemo,
+		UserID:      UserID(user.UserID(userID)),
+		RecipientID: RecipientID(user.UserID(recipientID)),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,24 @@
+package income
+
+import (
+	"context"
+	"time"
+)
+
+// IncomeRepository defines the interface for income data operations.
+type IncomeRepository interface {
+	CreateIncome(ctx context.Context, income *Income) error
+	FindByID(ctx context.Context, id uint) (*Income, error)
+	// GetIncome は期間内の収入を返します。from、toともにその日を含みます。
+	GetIncome(ctx context.Context, householdID uint, from time.Time, to time.Time, category *string) ([]*Income, error)
+	UpdateIncome(ctx context.Context, income *Income) error
+	DeleteIncome(ctx context.Context, incomeId IncomeID) error
+	// GetMonthlyTotals は期間内の月ごとの収入合計を返します。キーは月(1-12)で、月は monthStartDay 日から始まります。
+	GetMonthlyTotals(ctx context.Context, householdID uint, from time.Time, to time.Time, monthStartDay int) (map[int]int, error)
+	// ReassignCreator はユーザーが登録した収入の登録者を toUserID に置き換えます。
+	ReassignCreator(ctx context.Context, fromUserID uint, toUserID uint) error
+	// AnonymizeRecipient は世帯の収入の受取人が userID であれば、どのユーザーも指さない0に置き換えます。
+	AnonymizeRecipient(ctx context.Context, householdID uint, userID uint) error
+	// MakePersonal はユーザーが登録した収入の受取人をユーザー本人にします。
+	MakePersonal(ctx context.Context, userID uint) error
+}
@@ -0,0 +1,81 @@
+package income
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/yanatoritakuma/budget/back/domain/user"
+)
+
+// IncomeID は収入のIDを示す値オブジェクト
+type IncomeID uint
+
+func (id IncomeID) Value() uint {
+	return uint(id)
+}
+
+// Amount は金額を示す値オブジェクト
+type Amount int
+
+func NewAmount(amount int) (Amount, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("金額は0より大きい値を入力してください")
+	}
+	return Amount(amount), nil
+}
+
+func (a Amount) Value() int {
+	return int(a)
+}
+
+// Source は収入源（勤務先など）を示す値オブジェクト
+type Source string
+
+const MaxSourceLength = 255
+
+func NewSource(source string) (Source, error) {
+	if utf8.RuneCountInString(source) > MaxSourceLength {
+		return "", fmt.Errorf("収入源は%d文字以内で入力してください", MaxSourceLength)
+	}
+	return Source(source), nil
+}
+
+func (s Source) Value() string {
+	return string(s)
+}
+
+// Category は収入のカテゴリ（給与、賞与、返金など）を示す値オブジェクト
+type Category string
+
+func NewCategory(category string) (Category, error) {
+	if category == "" {
+		return "", fmt.Errorf("カテゴリは必須です")
+	}
+	return Category(category), nil
+}
+
+func (c Category) Value() string {
+	return string(c)
+}
+
+// Memo はメモを示す値オブジェクト
+type Memo string
+
+const MaxMemoLength = 1000
+
+func NewMemo(memo string) (Memo, error) {
+	if utf8.RuneCountInString(memo) > MaxMemoLength {
+		return "", fmt.Errorf("メモは%d文字以内で入力してください", MaxMemoLength)
+	}
+	return Memo(memo), nil
+}
+
+func (m Memo) Value() string {
+	return string(m)
+}
+
+// UserID は登録者のユーザーIDの値オブジェクト
+type UserID user.UserID
+
+// RecipientID は受取人のユーザーIDの値オブジェクト
+type RecipientID user.UserID
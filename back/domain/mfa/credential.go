@@ -0,0 +1,75 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RecoveryCodeCount は一度に発行するリカバリーコードの数です。
+const RecoveryCodeCount = 10
+
+// TOTPCredential はユーザーのTOTP設定を表すドメインエンティティです。
+// ConfirmedAt が nil の間は登録手続き中で、ログイン時の確認には使用しません。
+type TOTPCredential struct {
+	UserID       uint
+	Secret       string
+	ConfirmedAt  *time.Time
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewTOTPCredential は登録手続き中のTOTP設定を生成します。
+func NewTOTPCredential(userID uint, secret string, now time.Time) (*TOTPCredential, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user id is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("secret is required")
+	}
+	return &TOTPCredential{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsEnabled は登録が完了し、ログイン時に確認が必要かを返します。
+func (c *TOTPCredential) IsEnabled() bool {
+	return c.ConfirmedAt != nil
+}
+
+// Confirm は確認コードの検証が成功した時点で登録を完了させます。
+func (c *TOTPCredential) Confirm(step int64, now time.Time) {
+	c.ConfirmedAt = &now
+	c.LastUsedStep = step
+	c.UpdatedAt = now
+}
+
+// recoveryCodeAlphabet は読み間違えやすい文字を除いた32文字の英数字です。
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz023456789"
+
+// GenerateRecoveryCodes は「xxxxx-xxxxx」形式のリカバリーコードを生成します。
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+		codes = append(codes, string(b[:5])+"-"+string(b[5:]))
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode は入力されたリカバリーコードの区切りや大文字小文字の違いを取り除きます。
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
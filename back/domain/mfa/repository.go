@@ -0,0 +1,23 @@
+package mfa
+
+import (
+	"context"
+	"time"
+)
+
+// MFARepository defines the interface for two-factor authentication data operations.
+type MFARepository interface {
+	// FindTOTPByUserID はTOTP設定を取得します。存在しない場合は nil を返します。
+	FindTOTPByUserID(ctx context.Context, userID uint) (*TOTPCredential, error)
+	// SaveTOTP はTOTP設定を作成または更新します。
+	SaveTOTP(ctx context.Context, c *TOTPCredential) error
+	// UseTOTPStep は最後に使用したステップより新しい場合のみ記録し、記録したかどうかを返します。
+	UseTOTPStep(ctx context.Context, userID uint, step int64) (bool, error)
+	// DeleteByUserID はTOTP設定とリカバリーコードを削除します。
+	DeleteByUserID(ctx context.Context, userID uint) error
+	// ReplaceRecoveryCodes は既存のリカバリーコードを破棄し、新しいコードのハッシュを保存します。
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	// UseRecoveryCode は未使用のリカバリーコードを使用済みにし、使用できたかどうかを返します。
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int, error)
+}
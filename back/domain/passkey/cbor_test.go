@@ -0,0 +1,113 @@
+package passkey
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	// RFC 8949 付録Aの例
+	tests := []struct {
+		name string
+		hex  string
+		want any
+	}{
+		{"zero", "00", int64(0)},
+		{"small int", "17", int64(23)},
+		{"one byte int", "1818", int64(24)},
+		{"two byte int", "190100", int64(256)},
+		{"four byte int", "1a000f4240", int64(1000000)},
+		{"eight byte int", "1b000000e8d4a51000", int64(1000000000000)},
+		{"negative int", "20", int64(-1)},
+		{"negative two byte int", "3903e7", int64(-1000)},
+		{"byte string", "4401020304", []byte{1, 2, 3, 4}},
+		{"text string", "6449455446", "IETF"},
+		{"array", "83010203", []any{int64(1), int64(2), int64(3)}},
+		{"nested array", "8301820203820405", []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}}},
+		{"map with int keys", "a201020304", map[any]any{int64(1): int64(2), int64(3): int64(4)}},
+		{"map with text keys", "a26161016162820203", map[any]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+		{"false", "f4", false},
+		{"true", "f5", true},
+		{"null", "f6", nil},
+		{"float64", "fb3ff199999999999a", 1.1},
+		{"float32", "fa47c35000", 100000.0},
+		{"tag", "c11a514b67b0", int64(1363896240)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.hex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, rest, err := decodeCBOR(data)
+			if err != nil {
+				t.Fatalf("decodeCBOR() error = %v", err)
+			}
+			if len(rest) != 0 {
+				t.Errorf("decodeCBOR() rest = %x, want empty", rest)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCBOR() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCBORReturnsRest(t *testing.T) {
+	got, rest, err := decodeCBOR([]byte{0x01, 0x02, 0x03})
+	if err != nil {
+		t.Fatalf("decodeCBOR() error = %v", err)
+	}
+	if got != int64(1) || !bytes.Equal(rest, []byte{0x02, 0x03}) {
+		t.Errorf("decodeCBOR() = %v, %x, want 1, 0203", got, rest)
+	}
+}
+
+func TestDecodeCBORErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"empty", nil, "unexpected end of data"},
+		{"truncated one byte argument", []byte{0x18}, "unexpected end of data"},
+		{"truncated two byte argument", []byte{0x19, 0x01}, "unexpected end of data"},
+		{"truncated four byte argument", []byte{0x1a, 0x00, 0x01}, "unexpected end of data"},
+		{"truncated eight byte argument", []byte{0x1b, 0x00, 0x00, 0x00, 0x01}, "unexpected end of data"},
+		{"truncated byte string", []byte{0x44, 0x01, 0x02}, "unexpected end of data"},
+		{"truncated text string", []byte{0x64, 'I', 'E'}, "unexpected end of data"},
+		{"truncated array item", []byte{0x82, 0x19, 0x01}, "unexpected end of data"},
+		{"truncated map value", []byte{0xa1, 0x01}, "unexpected end of data"},
+		{"truncated float", []byte{0xfb, 0x3f, 0xf1}, "unexpected end of data"},
+		{"array longer than data", []byte{0x9a, 0xff, 0xff, 0xff, 0xff, 0x00}, "array too long"},
+		{"map longer than data", []byte{0xba, 0xff, 0xff, 0xff, 0xff, 0x00}, "map too long"},
+		{"positive overflow", []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "integer overflow"},
+		{"negative overflow", []byte{0x3b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "integer overflow"},
+		{"indefinite byte string", []byte{0x5f, 0x41, 0x01, 0xff}, "indefinite length"},
+		{"indefinite array", []byte{0x9f, 0x01, 0xff}, "indefinite length"},
+		{"reserved additional info", []byte{0x1c}, "indefinite length"},
+		{"unsupported map key", []byte{0xa1, 0xf5, 0x01}, "unsupported map key"},
+		{"unsupported simple value", []byte{0xf0}, "unsupported simple value"},
+		{"deeply nested array", append(bytes.Repeat([]byte{0x81}, maxCBORDepth+1), 0x01), "nesting too deep"},
+		{"deeply nested map", append(bytes.Repeat([]byte{0xa1, 0x01}, maxCBORDepth+1), 0x01), "nesting too deep"},
+		{"deeply nested tag", append(bytes.Repeat([]byte{0xc1}, maxCBORDepth+1), 0x01), "nesting too deep"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeCBOR(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("decodeCBOR() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeCBORMaxDepth(t *testing.T) {
+	data := append(bytes.Repeat([]byte{0x81}, maxCBORDepth), 0x01)
+	if _, _, err := decodeCBOR(data); err != nil {
+		t.Errorf("decodeCBOR() error = %v, want nil at depth %d", err, maxCBORDepth)
+	}
+}
@@ -0,0 +1,65 @@
+package passkey
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxNameLength はパスキーの表示名の最大文字数です。
+const MaxNameLength = 50
+
+// Credential はユーザーが登録したパスキーを表すドメインエンティティです。
+// 1人のユーザーが複数の端末のパスキーを登録できます。
+type Credential struct {
+	ID           uint
+	UserID       uint
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    uint32
+	AAGUID       []byte
+	Transports   []string
+	Name         string
+	CreatedAt    time.Time
+	LastUsedAt   *time.Time
+}
+
+// NewCredential は新しいCredentialドメインエンティティを生成します。
+func NewCredential(userID uint, authData *AuthenticatorData, transports []string, name string, now time.Time) (*Credential, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user id is required")
+	}
+	if len(authData.CredentialID) == 0 || len(authData.PublicKey) == 0 {
+		return nil, fmt.Errorf("credential id and public key are required")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "パスキー"
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return nil, fmt.Errorf("パスキー名は%d文字以内で入力してください", MaxNameLength)
+	}
+
+	return &Credential{
+		UserID:       userID,
+		CredentialID: authData.CredentialID,
+		PublicKey:    authData.PublicKey,
+		SignCount:    authData.SignCount,
+		AAGUID:       authData.AAGUID,
+		Transports:   transports,
+		Name:         name,
+		CreatedAt:    now,
+	}, nil
+}
+
+// RecordUse はログインに使用された際の署名カウンターを記録します。
+// カウンターが増えていない場合は認証器が複製された可能性があるためエラーを返します。
+func (c *Credential) RecordUse(signCount uint32, now time.Time) error {
+	if (signCount != 0 || c.SignCount != 0) && signCount <= c.SignCount {
+		return fmt.Errorf("sign count did not increase: stored=%d received=%d", c.SignCount, signCount)
+	}
+	c.SignCount = signCount
+	c.LastUsedAt = &now
+	return nil
+}
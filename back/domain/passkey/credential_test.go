@@ -0,0 +1,48 @@
+package passkey
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCredentialRecordUse(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		stored   uint32
+		received uint32
+		wantErr  bool
+	}{
+		{"counter not supported", 0, 0, false},
+		{"first use", 0, 1, false},
+		{"increased", 5, 6, false},
+		{"jumped ahead", 5, 100, false},
+		{"not increased", 5, 5, true},
+		{"regressed", 5, 3, true},
+		{"reset to zero", 5, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Credential{SignCount: tt.stored}
+			err := c.RecordUse(tt.received, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("RecordUse() error = nil, want error")
+				}
+				if c.SignCount != tt.stored || c.LastUsedAt != nil {
+					t.Errorf("RecordUse() changed the credential on error: SignCount=%d LastUsedAt=%v", c.SignCount, c.LastUsedAt)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RecordUse() error = %v", err)
+			}
+			if c.SignCount != tt.received {
+				t.Errorf("SignCount = %d, want %d", c.SignCount, tt.received)
+			}
+			if c.LastUsedAt == nil || !c.LastUsedAt.Equal(now) {
+				t.Errorf("LastUsedAt = %v, want %v", c.LastUsedAt, now)
+			}
+		})
+	}
+}
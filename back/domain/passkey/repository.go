@@ -0,0 +1,15 @@
+package passkey
+
+import "context"
+
+// CredentialRepository defines the interface for passkey credential data operations.
+type CredentialRepository interface {
+	Create(ctx context.Context, c *Credential) error
+	// FindByCredentialID はパスキーを取得します。存在しない場合は nil を返します。
+	FindByCredentialID(ctx context.Context, credentialID []byte) (*Credential, error)
+	FindByUserID(ctx context.Context, userID uint) ([]*Credential, error)
+	// UpdateUsage は署名カウンターと最終利用日時を更新します。
+	UpdateUsage(ctx context.Context, c *Credential) error
+	// Delete はユーザーのパスキーを削除し、削除したかどうかを返します。
+	Delete(ctx context.Context, userID uint, id uint) (bool, error)
+}
@@ -0,0 +1,304 @@
+package passkey
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// 認証器データのフラグ（WebAuthn Level 2 §6.1）
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+// COSEアルゴリズム識別子
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms は登録時に受け付ける公開鍵のアルゴリズムを優先順に並べたものです。
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+const (
+	clientDataTypeCreate = "webauthn.create"
+	clientDataTypeGet    = "webauthn.get"
+)
+
+// RelyingParty は認証器に対するこのサービスの情報です。
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// ClientData はブラウザが署名対象として生成するクライアントデータです。
+// Challenge はBase64URLをデコードした値を保持します。
+type ClientData struct {
+	Type      string
+	Challenge string
+	Origin    string
+}
+
+// AuthenticatorData は認証器が返す認証器データです。
+// 登録時のみ AAGUID・CredentialID・PublicKey が設定されます。
+type AuthenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+// HasFlag は指定したフラグが立っているかを返します。
+func (a *AuthenticatorData) HasFlag(flag byte) bool {
+	return a.Flags&flag != 0
+}
+
+// ParseClientData はクライアントデータのJSONを解析します。
+func ParseClientData(raw []byte) (*ClientData, error) {
+	var parsed struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid client data: %w", err)
+	}
+	challenge, err := base64.RawURLEncoding.DecodeString(parsed.Challenge)
+	if err != nil {
+		return nil, fmt.Errorf("invalid challenge encoding: %w", err)
+	}
+	return &ClientData{Type: parsed.Type, Challenge: string(challenge), Origin: parsed.Origin}, nil
+}
+
+// ParseAuthenticatorData は認証器データのバイト列を解析します。
+func ParseAuthenticatorData(data []byte) (*AuthenticatorData, error) {
+	if len(data) < 37 {
+		return nil, fmt.Errorf("authenticator data too short")
+	}
+	authData := &AuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if authData.HasFlag(FlagAttestedCredentialData) {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("attested credential data too short")
+		}
+		authData.AAGUID = rest[:16]
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if len(rest) < idLength {
+			return nil, fmt.Errorf("credential id too short")
+		}
+		authData.CredentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		_, afterKey, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential public key: %w", err)
+		}
+		authData.PublicKey = rest[:len(rest)-len(afterKey)]
+		rest = afterKey
+	}
+	if authData.HasFlag(FlagExtensionData) {
+		_, afterExtensions, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extension data: %w", err)
+		}
+		rest = afterExtensions
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected trailing authenticator data")
+	}
+	return authData, nil
+}
+
+// VerifyRegistration は登録時の応答を検証し、クライアントデータと認証器データを返します。
+// 構成証明（attestation）は要求しないため、構成証明ステートメントの検証は行いません。
+// チャレンジの照合は呼び出し側で行います。
+func (rp RelyingParty) VerifyRegistration(clientDataJSON, attestationObject []byte) (*ClientData, *AuthenticatorData, error) {
+	clientData, err := rp.verifyClientData(clientDataJSON, clientDataTypeCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+	attestation, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid attestation object")
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, nil, fmt.Errorf("authData not found in attestation object")
+	}
+
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !authData.HasFlag(FlagAttestedCredentialData) {
+		return nil, nil, fmt.Errorf("attested credential data not found")
+	}
+	publicKey, err := ParsePublicKey(authData.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !slices.Contains(SupportedAlgorithms, publicKey.Algorithm) {
+		return nil, nil, fmt.Errorf("unsupported algorithm: %d", publicKey.Algorithm)
+	}
+	return clientData, authData, nil
+}
+
+// VerifyAssertion はログイン時の応答の署名を登録済みの公開鍵で検証します。
+// チャレンジの照合と署名カウンターの確認は呼び出し側で行います。
+func (rp RelyingParty) VerifyAssertion(clientDataJSON, rawAuthData, signature, publicKey []byte) (*ClientData, *AuthenticatorData, error) {
+	clientData, err := rp.verifyClientData(clientDataJSON, clientDataTypeGet)
+	if err != nil {
+		return nil, nil, err
+	}
+	authData, err := rp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash[:]...)
+	if err := key.Verify(signed, signature); err != nil {
+		return nil, nil, err
+	}
+	return clientData, authData, nil
+}
+
+func (rp RelyingParty) verifyClientData(raw []byte, expectedType string) (*ClientData, error) {
+	clientData, err := ParseClientData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if clientData.Type != expectedType {
+		return nil, fmt.Errorf("unexpected client data type: %s", clientData.Type)
+	}
+	if !slices.Contains(rp.Origins, clientData.Origin) {
+		return nil, fmt.Errorf("unexpected origin: %s", clientData.Origin)
+	}
+	return clientData, nil
+}
+
+func (rp RelyingParty) verifyAuthenticatorData(raw []byte) (*AuthenticatorData, error) {
+	authData, err := ParseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return nil, fmt.Errorf("unexpected rp id hash")
+	}
+	// パスワードの代わりに使用するため、本人確認（生体認証・PIN）を必須とする
+	if !authData.HasFlag(FlagUserPresent) || !authData.HasFlag(FlagUserVerified) {
+		return nil, fmt.Errorf("user presence and verification are required")
+	}
+	return authData, nil
+}
+
+// PublicKey はCOSE形式から読み込んだ認証器の公開鍵です。
+type PublicKey struct {
+	Algorithm int64
+	key       crypto.PublicKey
+}
+
+// ParsePublicKey はCOSE_Key形式の公開鍵を解析します。
+func ParsePublicKey(cose []byte) (*PublicKey, error) {
+	decoded, rest, err := decodeCBOR(cose)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected trailing public key data")
+	}
+	m, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid public key")
+	}
+	kty, _ := m[int64(1)].(int64)
+	alg, _ := m[int64(3)].(int64)
+
+	switch {
+	case alg == AlgES256 && kty == 2:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid ES256 public key")
+		}
+		point := append(append([]byte{0x04}, x...), y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ES256 public key: %w", err)
+		}
+		return &PublicKey{Algorithm: alg, key: key}, nil
+	case alg == AlgEdDSA && kty == 1:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid EdDSA public key")
+		}
+		return &PublicKey{Algorithm: alg, key: ed25519.PublicKey(x)}, nil
+	case alg == AlgRS256 && kty == 3:
+		n, _ := m[int64(-1)].([]byte)
+		e, _ := m[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RS256 public key")
+		}
+		exponent := int(new(big.Int).SetBytes(e).Int64())
+		return &PublicKey{Algorithm: alg, key: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}}, nil
+	}
+	return nil, fmt.Errorf("unsupported public key: kty=%d alg=%d", kty, alg)
+}
+
+// Verify は署名を検証します。
+func (k *PublicKey) Verify(data, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", k.key)
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package passkey
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const (
+	testRPID      = "budget.example.com"
+	testOrigin    = "https://budget.example.com"
+	testChallenge = "challenge-0123456789"
+)
+
+var testRP = RelyingParty{ID: testRPID, Name: "Budget", Origins: []string{testOrigin}}
+
+// testAuthenticator は実際の鍵で署名するソフトウェア認証器です。
+type testAuthenticator struct {
+	credentialID []byte
+	publicKey    []byte
+	sign         func(data []byte) []byte
+}
+
+func newTestAuthenticator(t *testing.T, alg int64) *testAuthenticator {
+	t.Helper()
+	a := &testAuthenticator{credentialID: []byte("credential-" + t.Name())}
+	switch alg {
+	case AlgES256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		point, err := key.PublicKey.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.publicKey = cborMap(
+			cborInt(1), cborInt(2),
+			cborInt(3), cborInt(AlgES256),
+			cborInt(-1), cborInt(1),
+			cborInt(-2), cborBytes(point[1:33]),
+			cborInt(-3), cborBytes(point[33:]),
+		)
+		a.sign = func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return sig
+		}
+	case AlgEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.publicKey = cborMap(
+			cborInt(1), cborInt(1),
+			cborInt(3), cborInt(AlgEdDSA),
+			cborInt(-1), cborInt(6),
+			cborInt(-2), cborBytes(public),
+		)
+		a.sign = func(data []byte) []byte {
+			return ed25519.Sign(private, data)
+		}
+	case AlgRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exponent := make([]byte, 4)
+		binary.BigEndian.PutUint32(exponent, uint32(key.E))
+		a.publicKey = cborMap(
+			cborInt(1), cborInt(3),
+			cborInt(3), cborInt(AlgRS256),
+			cborInt(-1), cborBytes(key.N.Bytes()),
+			cborInt(-2), cborBytes(exponent[1:]),
+		)
+		a.sign = func(data []byte) []byte {
+			digest := sha256.Sum256(data)
+			sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return sig
+		}
+	default:
+		t.Fatalf("unsupported algorithm %d", alg)
+	}
+	return a
+}
+
+// authData は認証器データを生成します。FlagAttestedCredentialData が立っていれば認証情報を含めます。
+func (a *testAuthenticator) authData(rpID string, flags byte, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte(nil), rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, signCount)
+	if flags&FlagAttestedCredentialData != 0 {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.publicKey...)
+	}
+	return data
+}
+
+func (a *testAuthenticator) attestationObject(authData []byte) []byte {
+	return cborMap(
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborMap(),
+		cborText("authData"), cborBytes(authData),
+	)
+}
+
+func (a *testAuthenticator) assert(authData, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	return a.sign(append(append([]byte(nil), authData...), clientDataHash[:]...))
+}
+
+func clientDataJSON(t *testing.T, typ, challenge, origin string) []byte {
+	t.Helper()
+	raw, err := json.Marshal(map[string]any{
+		"type":        typ,
+		"challenge":   base64.RawURLEncoding.EncodeToString([]byte(challenge)),
+		"origin":      origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+const flagsUPUV = FlagUserPresent | FlagUserVerified
+
+func TestVerifyRegistration(t *testing.T) {
+	for _, alg := range SupportedAlgorithms {
+		a := newTestAuthenticator(t, alg)
+		validClientData := clientDataJSON(t, clientDataTypeCreate, testChallenge, testOrigin)
+		validAttestation := a.attestationObject(a.authData(testRPID, flagsUPUV|FlagAttestedCredentialData, 0))
+
+		tests := []struct {
+			name        string
+			clientData  []byte
+			attestation []byte
+			wantErr     string
+		}{
+			{"valid", validClientData, validAttestation, ""},
+			{"wrong origin", clientDataJSON(t, clientDataTypeCreate, testChallenge, "https://evil.example.com"), validAttestation, "unexpected origin"},
+			{"wrong type", clientDataJSON(t, clientDataTypeGet, testChallenge, testOrigin), validAttestation, "unexpected client data type"},
+			{"malformed client data", []byte(`{"type":`), validAttestation, "invalid client data"},
+			{"wrong rp id hash", validClientData, a.attestationObject(a.authData("evil.example.com", flagsUPUV|FlagAttestedCredentialData, 0)), "unexpected rp id hash"},
+			{"user not present", validClientData, a.attestationObject(a.authData(testRPID, FlagUserVerified|FlagAttestedCredentialData, 0)), "user presence and verification"},
+			{"user not verified", validClientData, a.attestationObject(a.authData(testRPID, FlagUserPresent|FlagAttestedCredentialData, 0)), "user presence and verification"},
+			{"no attested credential data", validClientData, a.attestationObject(a.authData(testRPID, flagsUPUV, 0)), "attested credential data not found"},
+			{"truncated attestation object", validClientData, validAttestation[:len(validAttestation)-10], "invalid attestation object"},
+			{"attestation object is not a map", validClientData, cborBytes([]byte{1, 2, 3}), "invalid attestation object"},
+			{"missing authData", validClientData, cborMap(cborText("fmt"), cborText("none")), "authData not found"},
+			{"truncated authData", validClientData, a.attestationObject(a.authData(testRPID, flagsUPUV|FlagAttestedCredentialData, 0)[:40]), "attested credential data too short"},
+			{"trailing authData", validClientData, a.attestationObject(append(a.authData(testRPID, flagsUPUV|FlagAttestedCredentialData, 0), 0x00)), "unexpected trailing authenticator data"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				clientData, authData, err := testRP.VerifyRegistration(tt.clientData, tt.attestation)
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("VerifyRegistration() error = %v, want %q", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("VerifyRegistration() error = %v", err)
+				}
+				if clientData.Challenge != testChallenge {
+					t.Errorf("Challenge = %q, want %q", clientData.Challenge, testChallenge)
+				}
+				if string(authData.CredentialID) != string(a.credentialID) {
+					t.Errorf("CredentialID = %q, want %q", authData.CredentialID, a.credentialID)
+				}
+				if string(authData.PublicKey) != string(a.publicKey) {
+					t.Errorf("PublicKey = %x, want %x", authData.PublicKey, a.publicKey)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyRegistrationRejectsUnsupportedKey(t *testing.T) {
+	a := newTestAuthenticator(t, AlgES256)
+	// ES256 の鍵を P-384 と偽った鍵
+	a.publicKey = cborMap(
+		cborInt(1), cborInt(2),
+		cborInt(3), cborInt(AlgES256),
+		cborInt(-1), cborInt(2),
+		cborInt(-2), cborBytes(make([]byte, 32)),
+		cborInt(-3), cborBytes(make([]byte, 32)),
+	)
+	attestation := a.attestationObject(a.authData(testRPID, flagsUPUV|FlagAttestedCredentialData, 0))
+	_, _, err := testRP.VerifyRegistration(clientDataJSON(t, clientDataTypeCreate, testChallenge, testOrigin), attestation)
+	if err == nil || !strings.Contains(err.Error(), "invalid ES256 public key") {
+		t.Errorf("VerifyRegistration() error = %v, want invalid ES256 public key", err)
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	for _, alg := range SupportedAlgorithms {
+		a := newTestAuthenticator(t, alg)
+		other := newTestAuthenticator(t, alg)
+		validClientData := clientDataJSON(t, clientDataTypeGet, testChallenge, testOrigin)
+		validAuthData := a.authData(testRPID, flagsUPUV, 7)
+		validSignature := a.assert(validAuthData, validClientData)
+
+		tamperedSignature := append([]byte(nil), validSignature...)
+		tamperedSignature[len(tamperedSignature)-1] ^= 0xff
+
+		type assertion struct {
+			clientData, authData, signature, publicKey []byte
+		}
+		signed := func(clientData, authData []byte) assertion {
+			return assertion{clientData, authData, a.assert(authData, clientData), a.publicKey}
+		}
+		tests := []struct {
+			name    string
+			in      assertion
+			wantErr string
+		}{
+			{"valid", assertion{validClientData, validAuthData, validSignature, a.publicKey}, ""},
+			{"wrong origin", signed(clientDataJSON(t, clientDataTypeGet, testChallenge, "https://evil.example.com"), validAuthData), "unexpected origin"},
+			{"wrong type", signed(clientDataJSON(t, clientDataTypeCreate, testChallenge, testOrigin), validAuthData), "unexpected client data type"},
+			{"wrong rp id hash", signed(validClientData, a.authData("evil.example.com", flagsUPUV, 7)), "unexpected rp id hash"},
+			{"user not present", signed(validClientData, a.authData(testRPID, FlagUserVerified, 7)), "user presence and verification"},
+			{"user not verified", signed(validClientData, a.authData(testRPID, FlagUserPresent, 7)), "user presence and verification"},
+			{"truncated authData", signed(validClientData, validAuthData[:36]), "authenticator data too short"},
+			{"tampered signature", assertion{validClientData, validAuthData, tamperedSignature, a.publicKey}, "invalid signature"},
+			{"signature by another key", assertion{validClientData, validAuthData, other.assert(validAuthData, validClientData), a.publicKey}, "invalid signature"},
+			{"signature over other client data", assertion{clientDataJSON(t, clientDataTypeGet, "other-challenge", testOrigin), validAuthData, validSignature, a.publicKey}, "invalid signature"},
+			{"signature over other authData", assertion{validClientData, a.authData(testRPID, flagsUPUV, 8), validSignature, a.publicKey}, "invalid signature"},
+			{"empty signature", assertion{validClientData, validAuthData, nil, a.publicKey}, "invalid signature"},
+			{"malformed public key", assertion{validClientData, validAuthData, validSignature, a.publicKey[:len(a.publicKey)-1]}, "invalid public key"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				clientData, authData, err := testRP.VerifyAssertion(tt.in.clientData, tt.in.authData, tt.in.signature, tt.in.publicKey)
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("VerifyAssertion() error = %v, want %q", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("VerifyAssertion() error = %v", err)
+				}
+				if clientData.Challenge != testChallenge {
+					t.Errorf("Challenge = %q, want %q", clientData.Challenge, testChallenge)
+				}
+				if authData.SignCount != 7 {
+					t.Errorf("SignCount = %d, want 7", authData.SignCount)
+				}
+			})
+		}
+	}
+}
+
+func TestParseAuthenticatorDataWithExtensions(t *testing.T) {
+	a := newTestAuthenticator(t, AlgEdDSA)
+	data := a.authData(testRPID, flagsUPUV|FlagExtensionData, 1)
+	data = append(data, cborMap(cborText("credProtect"), cborInt(2))...)
+	authData, err := ParseAuthenticatorData(data)
+	if err != nil {
+		t.Fatalf("ParseAuthenticatorData() error = %v", err)
+	}
+	if authData.SignCount != 1 {
+		t.Errorf("SignCount = %d, want 1", authData.SignCount)
+	}
+
+	if _, err := ParseAuthenticatorData(data[:len(data)-1]); err == nil || !strings.Contains(err.Error(), "invalid extension data") {
+		t.Errorf("ParseAuthenticatorData() error = %v, want invalid extension data", err)
+	}
+}
+
+// 以下はテストデータを組み立てるための最小限のCBORエンコーダーです。
+
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	}
+}
+
+func cborInt(v int64) []byte {
+	if v >= 0 {
+		return cborHead(0, uint64(v))
+	}
+	return cborHead(1, uint64(-1-v))
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, uint64(len(b))), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, uint64(len(s))), s...)
+}
+
+// cborMap はキーと値を交互に並べた要素からマップを生成します。
+func cborMap(items ...[]byte) []byte {
+	data := cborHead(5, uint64(len(items)/2))
+	for _, item := range items {
+		data = append(data, item...)
+	}
+	return data
+}
@@ -0,0 +1,16 @@
+package passwordreset
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRepository defines the interface for password reset token data operations.
+type TokenRepository interface {
+	// Create はトークンを保存し、同じユーザーの未使用のトークンを無効にします。
+	Create(ctx context.Context, t *Token) error
+	// FindByTokenHash はトークンを取得します。存在しない場合は nil を返します。
+	FindByTokenHash(ctx context.Context, hash string) (*Token, error)
+	// MarkUsed はトークンが未使用の場合のみ使用済みにし、更新したかどうかを返します。
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error)
+}
@@ -0,0 +1,39 @@
+package passwordreset
+
+import (
+	"fmt"
+	"time"
+)
+
+// Token はパスワード再設定用のトークンを表すドメインエンティティです。
+// トークンはハッシュ値のみを保持し、一度使用すると無効になります。
+type Token struct {
+	ID        uint
+	UserID    uint
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// NewToken は新しいTokenドメインエンティティを生成します。
+func NewToken(userID uint, tokenHash string, now time.Time, ttl time.Duration) (*Token, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user id is required")
+	}
+	if tokenHash == "" {
+		return nil, fmt.Errorf("token hash is required")
+	}
+
+	return &Token{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// IsUsable はトークンが未使用かつ有効期限内かを返します。
+func (t *Token) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
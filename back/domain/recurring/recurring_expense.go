@@ -0,0 +1,108 @@
+package recurring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+)
+
+// RecurringExpense は定期的に発生する支出のテンプレートを表すドメインエンティティです。
+type RecurringExpense struct {
+	ID                RecurringExpenseID
+	HouseholdID       uint
+	UserID            uint
+	PayerID           uint
+	Amount            expense.Amount
+	StoreName         expense.StoreName
+	Category          expense.Category
+	Memo              expense.Memo
+	Rule              Rule
+	StartDate         time.Time
+	EndDate           *time.Time
+	LastGeneratedDate *time.Time
+	Active            bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// NewRecurringExpense は新しいRecurringExpenseドメインエンティティを生成します。
+func NewRecurringExpense(householdID, userID, payerID uint, amount int, storeName, category, memo string, rule Rule, startDate time.Time, endDate *time.Time) (*RecurringExpense, error) {
+	voAmount, err := expense.NewAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	voStoreName, err := expense.NewStoreName(storeName)
+	if err != nil {
+		return nil, err
+	}
+	voCategory, err := expense.NewCategory(category)
+	if err != nil {
+		return nil, err
+	}
+	voMemo, err := expense.NewMemo(memo)
+	if err != nil {
+		return nil, err
+	}
+	if endDate != nil && endDate.Before(startDate) {
+		return nil, fmt.Errorf("終了日は開始日以降の日付を指定してください")
+	}
+
+	return &RecurringExpense{
+		HouseholdID: householdID,
+		UserID:      userID,
+		PayerID:     payerID,
+		Amount:      voAmount,
+		StoreName:   voStoreName,
+		Category:    voCategory,
+		Memo:        voMemo,
+		Rule:        rule,
+		StartDate:   truncateToDate(startDate),
+		EndDate:     endDate,
+		Active:      true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// DueDates は asOf 時点までに生成すべき未生成の発生日を返します。
+func (r *RecurringExpense) DueDates(asOf time.Time) []time.Time {
+	if !r.Active {
+		return nil
+	}
+	from := r.StartDate
+	if r.LastGeneratedDate != nil && !r.LastGeneratedDate.Before(from) {
+		from = r.LastGeneratedDate.AddDate(0, 0, 1)
+	}
+	to := truncateToDate(asOf)
+	if r.EndDate != nil && r.EndDate.Before(to) {
+		to = truncateToDate(*r.EndDate)
+	}
+	return r.Rule.Occurrences(from, to)
+}
+
+// Materialize は指定日の支出エンティティを生成します。
+func (r *RecurringExpense) Materialize(date time.Time) (*expense.Expense, error) {
+	e, err := expense.NewExpense(
+		r.HouseholdID,
+		r.Amount.Value(),
+		r.StoreName.Value(),
+		date,
+		r.Category.Value(),
+		r.Memo.Value(),
+		r.UserID,
+		r.PayerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	id := r.ID.Value()
+	e.RecurringExpenseID = &id
+	return e, nil
+}
+
+// MarkGenerated は指定日まで生成済みであることを記録します。
+func (r *RecurringExpense) MarkGenerated(date time.Time) {
+	r.LastGeneratedDate = &date
+	r.UpdatedAt = time.Now()
+}
@@ -0,0 +1,17 @@
+package recurring
+
+import "context"
+
+// RecurringExpenseRepository defines the interface for recurring expense data operations.
+type RecurringExpenseRepository interface {
+	Create(ctx context.Context, recurringExpense *RecurringExpense) error
+	FindByID(ctx context.Context, id uint) (*RecurringExpense, error)
+	FindByHouseholdID(ctx context.Context, householdID uint) ([]*RecurringExpense, error)
+	FindActive(ctx context.Context) ([]*RecurringExpense, error)
+	Update(ctx context.Context, recurringExpense *RecurringExpense) error
+	Delete(ctx context.Context, id RecurringExpenseID) error
+	// RenameCategory は世帯の定期支出のカテゴリ名を変更します。
+	RenameCategory(ctx context.Context, householdID uint, oldName string, newName string) error
+	// ReassignMember は世帯の定期支出の登録者・支払者を fromUserID から toUserID に置き換えます。
+	ReassignMember(ctx context.Context, householdID uint, fromUserID uint, toUserID uint) error
+}
@@ -0,0 +1,109 @@
+package recurring
+
+import (
+	"fmt"
+	"time"
+)
+
+// Frequency は繰り返しの頻度を示す値オブジェクト
+type Frequency string
+
+const (
+	// FrequencyMonthly は毎月指定日に発生します。
+	FrequencyMonthly Frequency = "monthly"
+	// FrequencyWeekly は毎週指定曜日に発生します。
+	FrequencyWeekly Frequency = "weekly"
+	// FrequencyYearly は毎年指定月日に発生します。
+	FrequencyYearly Frequency = "yearly"
+	// FrequencyLastBusinessDay は毎月最終営業日（土日を除く）に発生します。
+	FrequencyLastBusinessDay Frequency = "last_business_day"
+)
+
+// Rule は繰り返しルールを示す値オブジェクト
+type Rule struct {
+	Frequency Frequency
+	// Day は月次・年次で使用する日付です。月末より大きい場合は月末日として扱います。
+	Day int
+	// Weekday は週次で使用する曜日です。
+	Weekday time.Weekday
+	// Month は年次で使用する月です。
+	Month time.Month
+}
+
+// NewRule は繰り返しルールを生成します。
+func NewRule(frequency string, day int, weekday int, month int) (Rule, error) {
+	switch Frequency(frequency) {
+	case FrequencyMonthly:
+		if day < 1 || day > 31 {
+			return Rule{}, fmt.Errorf("日付は1から31の間で指定してください")
+		}
+		return Rule{Frequency: FrequencyMonthly, Day: day}, nil
+	case FrequencyWeekly:
+		if weekday < 0 || weekday > 6 {
+			return Rule{}, fmt.Errorf("曜日は0(日曜)から6(土曜)の間で指定してください")
+		}
+		return Rule{Frequency: FrequencyWeekly, Weekday: time.Weekday(weekday)}, nil
+	case FrequencyYearly:
+		if month < 1 || month > 12 {
+			return Rule{}, fmt.Errorf("月は1から12の間で指定してください")
+		}
+		if day < 1 || day > 31 {
+			return Rule{}, fmt.Errorf("日付は1から31の間で指定してください")
+		}
+		return Rule{Frequency: FrequencyYearly, Day: day, Month: time.Month(month)}, nil
+	case FrequencyLastBusinessDay:
+		return Rule{Frequency: FrequencyLastBusinessDay}, nil
+	default:
+		return Rule{}, fmt.Errorf("繰り返しの頻度が不正です: %s", frequency)
+	}
+}
+
+// Matches は指定日がルールに該当するかを判定します。
+func (r Rule) Matches(date time.Time) bool {
+	switch r.Frequency {
+	case FrequencyMonthly:
+		return date.Day() == clampDay(date.Year(), date.Month(), r.Day)
+	case FrequencyWeekly:
+		return date.Weekday() == r.Weekday
+	case FrequencyYearly:
+		return date.Month() == r.Month && date.Day() == clampDay(date.Year(), date.Month(), r.Day)
+	case FrequencyLastBusinessDay:
+		return date.Day() == lastBusinessDay(date.Year(), date.Month())
+	default:
+		return false
+	}
+}
+
+// Occurrences は from から to まで（両端を含む）のルールに該当する日付を返します。
+func (r Rule) Occurrences(from, to time.Time) []time.Time {
+	var dates []time.Time
+	for d := truncateToDate(from); !d.After(to); d = d.AddDate(0, 0, 1) {
+		if r.Matches(d) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func clampDay(year int, month time.Month, day int) int {
+	return min(day, daysIn(year, month))
+}
+
+// lastBusinessDay は月の最終営業日を返します。祝日は考慮しません。
+func lastBusinessDay(year int, month time.Month) int {
+	d := time.Date(year, month, daysIn(year, month), 0, 0, 0, 0, time.UTC)
+	for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d.Day()
+}
+
+// truncateToDate は時刻のカレンダー上の日付をUTCの0時として返します。
+// フロントエンドは日付をUTCの0時として送信するため、生成される支出もこれに合わせます。
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,8 @@
+package recurring
+
+// RecurringExpenseID は定期支出のIDを示す値オブジェクト
+type RecurringExpenseID uint
+
+func (id RecurringExpenseID) Value() uint {
+	return uint(id)
+}
@@ -0,0 +1,21 @@
+package session
+
+import "context"
+
+// SessionRepository defines the interface for session data operations.
+type SessionRepository interface {
+	Create(ctx context.Context, s *Session) error
+	// FindByID はセッションを取得します。存在しない場合は nil を返します。
+	FindByID(ctx context.Context, id uint) (*Session, error)
+	// FindByRefreshTokenHash は現在または直前のリフレッシュトークンのハッシュが一致するセッションを返します。
+	FindByRefreshTokenHash(ctx context.Context, hash string) (*Session, error)
+	// FindActiveByUserID はユーザーの有効なセッションを最終利用日時の新しい順に返します。
+	FindActiveByUserID(ctx context.Context, userID uint) ([]*Session, error)
+	Update(ctx context.Context, s *Session) error
+	// Rotate はリフレッシュトークンが currentHash のままの場合のみセッションを更新し、更新したかどうかを返します。
+	Rotate(ctx context.Context, s *Session, currentHash string) (bool, error)
+	// RevokeByUserID はユーザーの有効なセッションをすべて失効させます。
+	RevokeByUserID(ctx context.Context, userID uint) error
+	// RevokeOthersByUserID は exceptID 以外のユーザーの有効なセッションをすべて失効させます。
+	RevokeOthersByUserID(ctx context.Context, userID uint, exceptID uint) error
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 端末情報の最大長
+const (
+	maxDeviceNameLength = 100
+	maxUserAgentLength  = 512
+)
+
+// ClientInfo はセッションを利用する端末の情報です。
+type ClientInfo struct {
+	DeviceName string
+	IPAddress  string
+	UserAgent  string
+}
+
+// Session はリフレッシュトークンで継続するログインセッションを表すドメインエンティティです。
+// リフレッシュトークンはハッシュ値のみを保持します。
+type Session struct {
+	ID                       uint
+	UserID                   uint
+	RefreshTokenHash         string
+	PreviousRefreshTokenHash string
+	DeviceName               string
+	IPAddress                string
+	UserAgent                string
+	CreatedAt                time.Time
+	LastUsedAt               time.Time
+	RotatedAt                time.Time
+	ExpiresAt                time.Time
+	RevokedAt                *time.Time
+}
+
+// NewSession は新しいSessionドメインエンティティを生成します。
+func NewSession(userID uint, refreshTokenHash string, client ClientInfo, now time.Time, ttl time.Duration) (*Session, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user id is required")
+	}
+	if refreshTokenHash == "" {
+		return nil, fmt.Errorf("refresh token hash is required")
+	}
+
+	return &Session{
+		UserID:           userID,
+		RefreshTokenHash: refreshTokenHash,
+		DeviceName:       truncate(strings.TrimSpace(client.DeviceName), maxDeviceNameLength),
+		IPAddress:        client.IPAddress,
+		UserAgent:        truncate(client.UserAgent, maxUserAgentLength),
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		RotatedAt:        now,
+		ExpiresAt:        now.Add(ttl),
+	}, nil
+}
+
+// IsActive はセッションが失効しておらず有効期限内かを返します。
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Rotate はリフレッシュトークンを新しいものに置き換え、有効期限を延長します。
+func (s *Session) Rotate(refreshTokenHash string, client ClientInfo, now time.Time, ttl time.Duration) {
+	s.PreviousRefreshTokenHash = s.RefreshTokenHash
+	s.RefreshTokenHash = refreshTokenHash
+	s.Touch(client, now)
+	s.RotatedAt = now
+	s.ExpiresAt = now.Add(ttl)
+}
+
+// Touch は最終利用日時と接続元を更新します。
+func (s *Session) Touch(client ClientInfo, now time.Time) {
+	s.LastUsedAt = now
+	if client.IPAddress != "" {
+		s.IPAddress = client.IPAddress
+	}
+	if client.UserAgent != "" {
+		s.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+	}
+}
+
+// Revoke はセッションを失効させます。
+func (s *Session) Revoke(now time.Time) {
+	if s.RevokedAt == nil {
+		s.RevokedAt = &now
+	}
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return s
+}
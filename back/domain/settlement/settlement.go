@@ -0,0 +1,124 @@
+package settlement
+
+import (
+	"sort"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+)
+
+// Balance はメンバーごとの支払額と負担額を示します。
+type Balance struct {
+	UserID uint
+	Paid   int
+	Owed   int
+}
+
+// Net は精算時に受け取る（正）または支払う（負）金額を返します。
+func (b Balance) Net() int {
+	return b.Paid - b.Owed
+}
+
+// Transfer は精算のための送金を示します。
+type Transfer struct {
+	FromUserID uint
+	ToUserID   uint
+	Amount     int
+}
+
+// Calculate は支出一覧から各メンバーの支払額と負担額を集計します。
+// 支出ごとの負担額が設定されていればそれを、なければ世帯の分担ルールを使用します。
+// 匿名化された元メンバーの負担分は回収できないため支払者が負担し、他のメンバーの負担分はそのまま精算します。
+// 元メンバーが支払った支出は元メンバーへの債務となるため、世帯の精算には含めません。
+func Calculate(memberIDs []uint, rule household.SplitRule, expenses []*expense.Expense) []Balance {
+	balances := make(map[uint]*Balance)
+	get := func(id uint) *Balance {
+		if b, ok := balances[id]; ok {
+			return b
+		}
+		b := &Balance{UserID: id}
+		balances[id] = b
+		return b
+	}
+	for _, id := range memberIDs {
+		get(id)
+	}
+
+	for _, e := range expenses {
+		if e.PayerID == expense.FormerMemberID {
+			continue
+		}
+		payer := get(uint(e.PayerID))
+		payer.Paid += e.Amount.Value()
+
+		if e.HasCustomShares() {
+			for _, share := range e.Shares {
+				if share.UserID == expense.FormerMemberID {
+					payer.Owed += share.Amount.Value()
+					continue
+				}
+				get(uint(share.UserID)).Owed += share.Amount.Value()
+			}
+			continue
+		}
+		for id, amount := range rule.Allocate(e.Amount.Value(), memberIDs) {
+			get(id).Owed += amount
+		}
+	}
+
+	result := make([]Balance, 0, len(balances))
+	for _, b := range balances {
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+	return result
+}
+
+// MinimizeTransfers は残高を解消するための送金を求めます。
+// 最も多く支払うべきメンバーから最も多く受け取るべきメンバーへ順に送金することで、
+// 送金回数をメンバー数-1回以下に抑えます。
+func MinimizeTransfers(balances []Balance) []Transfer {
+	type entry struct {
+		userID uint
+		amount int
+	}
+	var debtors, creditors []entry
+	for _, b := range balances {
+		switch net := b.Net(); {
+		case net < 0:
+			debtors = append(debtors, entry{b.UserID, -net})
+		case net > 0:
+			creditors = append(creditors, entry{b.UserID, net})
+		}
+	}
+	byAmount := func(entries []entry) {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].amount != entries[j].amount {
+				return entries[i].amount > entries[j].amount
+			}
+			return entries[i].userID < entries[j].userID
+		})
+	}
+	byAmount(debtors)
+	byAmount(creditors)
+
+	transfers := []Transfer{}
+	i, j := 0, 0
+	for i < len(debtors) && j < len(creditors) {
+		amount := min(debtors[i].amount, creditors[j].amount)
+		transfers = append(transfers, Transfer{
+			FromUserID: debtors[i].userID,
+			ToUserID:   creditors[j].userID,
+			Amount:     amount,
+		})
+		debtors[i].amount -= amount
+		creditors[j].amount -= amount
+		if debtors[i].amount == 0 {
+			i++
+		}
+		if creditors[j].amount == 0 {
+			j++
+		}
+	}
+	return transfers
+}
@@ -0,0 +1,72 @@
+package settlement
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+)
+
+func newTestExpense(t *testing.T, amount int, payerID uint, shares map[uint]int) *expense.Expense {
+	t.Helper()
+	e, err := expense.NewExpense(1, amount, "store", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), "食費", "", payerID, payerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var domainShares []expense.Share
+	for userID, amount := range shares {
+		share, err := expense.NewShare(userID, amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		domainShares = append(domainShares, share)
+	}
+	if err := e.SetShares(domainShares); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestCalculateWithFormerMember(t *testing.T) {
+	equal := household.SplitRule{Method: household.SplitMethodEqual}
+	tests := []struct {
+		name     string
+		expenses func(t *testing.T) []*expense.Expense
+		want     []Balance
+	}{
+		{
+			name: "former member's share falls on the payer",
+			expenses: func(t *testing.T) []*expense.Expense {
+				return []*expense.Expense{newTestExpense(t, 3000, 1, map[uint]int{1: 1000, 2: 1000, expense.FormerMemberID: 1000})}
+			},
+			want: []Balance{{UserID: 1, Paid: 3000, Owed: 2000}, {UserID: 2, Owed: 1000}},
+		},
+		{
+			name: "expense paid by the former member is left out",
+			expenses: func(t *testing.T) []*expense.Expense {
+				return []*expense.Expense{
+					newTestExpense(t, 2000, expense.FormerMemberID, map[uint]int{1: 1000, 2: 1000}),
+					newTestExpense(t, 1000, 2, nil),
+				}
+			},
+			want: []Balance{{UserID: 1, Owed: 500}, {UserID: 2, Paid: 1000, Owed: 500}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Calculate([]uint{1, 2}, equal, tt.expenses(t))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Calculate() = %+v, want %+v", got, tt.want)
+			}
+			total := 0
+			for _, b := range got {
+				total += b.Net()
+			}
+			if total != 0 {
+				t.Errorf("sum of net balances = %d, want 0", total)
+			}
+		})
+	}
+}
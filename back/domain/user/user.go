@@ -3,16 +3,16 @@ package user
 import "time"
 
 type User struct {
-	ID          UserID
-	Email       *Email
-	LineUserID  *LineUserID
-	Password    Password
-	Name        Name
-	Image       string
-	Admin       bool
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	HouseholdID uint
+	ID              UserID
+	Email           *Email
+	EmailVerifiedAt *time.Time
+	Password        Password
+	Name            Name
+	Image           string
+	Admin           bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	HouseholdID     uint
 }
 
 // NewUser は新しいUserドメインエンティティを生成します。
@@ -60,3 +60,27 @@ func (u *User) ChangePassword(newPassword Password) {
 	u.Password = newPassword
 	u.UpdatedAt = time.Now()
 }
+
+// HasPassword はメールアドレスとパスワードでログインできるかを返します。
+// 外部IDプロバイダのアカウントから作成したユーザーはパスワードを持ちません。
+func (u *User) HasPassword() bool {
+	return u.Email != nil && u.Password.Value() != ""
+}
+
+// IsEmailVerified はメールアドレスの所有が確認済みかを返します。
+func (u *User) IsEmailVerified() bool {
+	return u.Email != nil && u.EmailVerifiedAt != nil
+}
+
+// VerifyEmail はメールアドレスを確認済みにします。
+func (u *User) VerifyEmail(now time.Time) {
+	u.EmailVerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+// ChangeEmail はメールアドレスを変更し、確認済みの状態を解除します。
+func (u *User) ChangeEmail(newEmail *Email) {
+	u.Email = newEmail
+	u.EmailVerifiedAt = nil
+	u.UpdatedAt = time.Now()
+}
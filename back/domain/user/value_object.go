@@ -48,6 +48,17 @@ func (p Password) Value() string {
 	return string(p)
 }
 
+// MinPasswordLength は平文パスワードの最小文字数です。
+const MinPasswordLength = 8
+
+// ValidatePlainPassword はハッシュ化する前のパスワードを検証します。
+func ValidatePlainPassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return fmt.Errorf("パスワードは%d文字以上で入力してください", MinPasswordLength)
+	}
+	return nil
+}
+
 // Name はユーザー名を示す値オブジェクト
 type Name string
 
@@ -66,22 +77,3 @@ func NewName(name string) (Name, error) {
 func (n Name) Value() string {
 	return string(n)
 }
-
-// LineUserID はLINEのユーザーIDを示す値オブジェクト
-type LineUserID string
-
-func NewLineUserID(id string) (*LineUserID, error) {
-	if id == "" {
-		return nil, nil
-	}
-	// LINE User IDは必須ではないため、バリデーションは行わない
-	lineID := LineUserID(id)
-	return &lineID, nil
-}
-
-func (l *LineUserID) Value() string {
-	if l == nil {
-		return ""
-	}
-	return string(*l)
-}
@@ -25,24 +25,213 @@ type ServerInterface interface {
 	// Initiate LINE login flow
 	// (GET /api/v1/auth/line/login)
 	GetApiV1AuthLineLogin(w http.ResponseWriter, r *http.Request)
+	// OpenID Connect login callback
+	// (GET /api/v1/auth/oidc/{provider}/callback)
+	GetApiV1AuthOidcProviderCallback(w http.ResponseWriter, r *http.Request, provider string, params GetApiV1AuthOidcProviderCallbackParams)
+	// Create new user from an identity provider account
+	// (POST /api/v1/auth/oidc/{provider}/create)
+	PostApiV1AuthOidcProviderCreate(w http.ResponseWriter, r *http.Request, provider string)
+	// Link an identity provider account to existing user
+	// (POST /api/v1/auth/oidc/{provider}/link)
+	PostApiV1AuthOidcProviderLink(w http.ResponseWriter, r *http.Request, provider string)
+	// Initiate OpenID Connect login flow
+	// (GET /api/v1/auth/oidc/{provider}/login)
+	GetApiV1AuthOidcProviderLogin(w http.ResponseWriter, r *http.Request, provider string)
+	// Refresh the access token
+	// (POST /auth/refresh)
+	PostAuthRefresh(w http.ResponseWriter, r *http.Request)
+	// Get budgets
+	// (GET /budgets)
+	GetBudgets(w http.ResponseWriter, r *http.Request, params GetBudgetsParams)
+	// Create a new budget
+	// (POST /budgets)
+	PostBudgets(w http.ResponseWriter, r *http.Request)
+	// Get budget versus actual status
+	// (GET /budgets/status)
+	GetBudgetsStatus(w http.ResponseWriter, r *http.Request, params GetBudgetsStatusParams)
+	// Delete a budget
+	// (DELETE /budgets/{id})
+	DeleteBudgetsId(w http.ResponseWriter, r *http.Request, id int)
+	// Update a budget
+	// (PUT /budgets/{id})
+	PutBudgetsId(w http.ResponseWriter, r *http.Request, id int)
+	// Get monthly cash flow
+	// (GET /cash-flow)
+	GetCashFlow(w http.ResponseWriter, r *http.Request, params GetCashFlowParams)
+	// Get categories of the household
+	// (GET /categories)
+	GetCategories(w http.ResponseWriter, r *http.Request, params GetCategoriesParams)
+	// Create a new category
+	// (POST /categories)
+	PostCategories(w http.ResponseWriter, r *http.Request)
+	// Delete a category
+	// (DELETE /categories/{id})
+	DeleteCategoriesId(w http.ResponseWriter, r *http.Request, id int)
+	// Update a category
+	// (PUT /categories/{id})
+	PutCategoriesId(w http.ResponseWriter, r *http.Request, id int)
+	// Verify the email address
+	// (POST /email/verify)
+	PostEmailVerify(w http.ResponseWriter, r *http.Request)
 	// Get expenses
 	// (GET /expenses)
 	GetExpenses(w http.ResponseWriter, r *http.Request, params GetExpensesParams)
 	// Create a new expense
 	// (POST /expenses)
 	PostExpenses(w http.ResponseWriter, r *http.Request)
+	// Export expenses
+	// (GET /expenses/export)
+	GetExpensesExport(w http.ResponseWriter, r *http.Request, params GetExpensesExportParams)
+	// Import expenses from a CSV file
+	// (POST /expenses/import)
+	PostExpensesImport(w http.ResponseWriter, r *http.Request)
+	// Get expense totals grouped by a dimension
+	// (GET /expenses/summary)
+	GetExpensesSummary(w http.ResponseWriter, r *http.Request, params GetExpensesSummaryParams)
 	// Delete an expense
 	// (DELETE /expenses/{id})
 	DeleteExpensesId(w http.ResponseWriter, r *http.Request, id int)
 	// Update an expense
 	// (PUT /expenses/{id})
 	PutExpensesId(w http.ResponseWriter, r *http.Request, id int)
+	// List invitations of the household
+	// (GET /household/invitations)
+	GetHouseholdInvitations(w http.ResponseWriter, r *http.Request)
+	// Create an invitation to the household
+	// (POST /household/invitations)
+	PostHouseholdInvitations(w http.ResponseWriter, r *http.Request)
+	// Revoke an invitation
+	// (DELETE /household/invitations/{id})
+	DeleteHouseholdInvitationsId(w http.ResponseWriter, r *http.Request, id int)
+	// Leave the household
+	// (POST /household/leave)
+	PostHouseholdLeave(w http.ResponseWriter, r *http.Request)
+	// List household members and their roles
+	// (GET /household/members)
+	GetHouseholdMembers(w http.ResponseWriter, r *http.Request)
+	// Remove a member from the household
+	// (DELETE /household/members/{userId})
+	DeleteHouseholdMembersUserId(w http.ResponseWriter, r *http.Request, userId int, params DeleteHouseholdMembersUserIdParams)
+	// Change the role of a household member
+	// (PUT /household/members/{userId}/role)
+	PutHouseholdMembersUserIdRole(w http.ResponseWriter, r *http.Request, userId int)
+	// Transfer ownership of the household to another member
+	// (PUT /household/owner)
+	PutHouseholdOwner(w http.ResponseWriter, r *http.Request)
+	// Get household settings
+	// (GET /household/settings)
+	GetHouseholdSettings(w http.ResponseWriter, r *http.Request)
+	// Update household settings
+	// (PUT /household/settings)
+	PutHouseholdSettings(w http.ResponseWriter, r *http.Request)
+	// Get monthly settlement
+	// (GET /household/settlement)
+	GetHouseholdSettlement(w http.ResponseWriter, r *http.Request, params GetHouseholdSettlementParams)
+	// Get household split rule
+	// (GET /household/split)
+	GetHouseholdSplit(w http.ResponseWriter, r *http.Request)
+	// Update household split rule
+	// (PUT /household/split)
+	PutHouseholdSplit(w http.ResponseWriter, r *http.Request)
+	// Get incomes
+	// (GET /incomes)
+	GetIncomes(w http.ResponseWriter, r *http.Request, params GetIncomesParams)
+	// Create a new income
+	// (POST /incomes)
+	PostIncomes(w http.ResponseWriter, r *http.Request)
+	// Delete an income
+	// (DELETE /incomes/{id})
+	DeleteIncomesId(w http.ResponseWriter, r *http.Request, id int)
+	// Update an income
+	// (PUT /incomes/{id})
+	PutIncomesId(w http.ResponseWriter, r *http.Request, id int)
+	// Log in with email and password
+	// (POST /login)
+	PostLogin(w http.ResponseWriter, r *http.Request)
+	// Complete the login with a two-factor code
+	// (POST /login/2fa)
+	PostLogin2fa(w http.ResponseWriter, r *http.Request)
+	// Start a passkey login
+	// (POST /passkeys/login/begin)
+	PostPasskeysLoginBegin(w http.ResponseWriter, r *http.Request)
+	// Complete a passkey login
+	// (POST /passkeys/login/finish)
+	PostPasskeysLoginFinish(w http.ResponseWriter, r *http.Request)
+	// Request a password reset
+	// (POST /password/forgot)
+	PostPasswordForgot(w http.ResponseWriter, r *http.Request)
+	// Reset the password with a reset token
+	// (POST /password/reset)
+	PostPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Get recurring expenses
+	// (GET /recurring-expenses)
+	GetRecurringExpenses(w http.ResponseWriter, r *http.Request)
+	// Create a new recurring expense
+	// (POST /recurring-expenses)
+	PostRecurringExpenses(w http.ResponseWriter, r *http.Request)
+	// Delete a recurring expense
+	// (DELETE /recurring-expenses/{id})
+	DeleteRecurringExpensesId(w http.ResponseWriter, r *http.Request, id int)
+	// Update a recurring expense
+	// (PUT /recurring-expenses/{id})
+	PutRecurringExpensesId(w http.ResponseWriter, r *http.Request, id int)
+	// Revoke all sessions
+	// (DELETE /sessions)
+	DeleteSessions(w http.ResponseWriter, r *http.Request)
+	// List active sessions
+	// (GET /sessions)
+	GetSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke a session
+	// (DELETE /sessions/{id})
+	DeleteSessionsId(w http.ResponseWriter, r *http.Request, id int)
 	// User registration
 	// (POST /signup)
 	PostSignup(w http.ResponseWriter, r *http.Request)
 	// Update user information
 	// (PUT /user)
 	PutUser(w http.ResponseWriter, r *http.Request)
+	// Get the two-factor authentication status
+	// (GET /user/2fa)
+	GetUser2fa(w http.ResponseWriter, r *http.Request)
+	// Regenerate recovery codes
+	// (POST /user/2fa/recovery-codes)
+	PostUser2faRecoveryCodes(w http.ResponseWriter, r *http.Request)
+	// Confirm TOTP enrollment
+	// (POST /user/2fa/totp/confirm)
+	PostUser2faTotpConfirm(w http.ResponseWriter, r *http.Request)
+	// Disable TOTP
+	// (POST /user/2fa/totp/disable)
+	PostUser2faTotpDisable(w http.ResponseWriter, r *http.Request)
+	// Start TOTP enrollment
+	// (POST /user/2fa/totp/setup)
+	PostUser2faTotpSetup(w http.ResponseWriter, r *http.Request)
+	// Resend the verification email
+	// (POST /user/email/verification)
+	PostUserEmailVerification(w http.ResponseWriter, r *http.Request)
+	// List identity providers linked to the current user
+	// (GET /user/identities)
+	GetUserIdentities(w http.ResponseWriter, r *http.Request)
+	// Unlink an identity provider from the current user
+	// (DELETE /user/identities/{id})
+	DeleteUserIdentitiesId(w http.ResponseWriter, r *http.Request, id int)
+	// List registered passkeys
+	// (GET /user/passkeys)
+	GetUserPasskeys(w http.ResponseWriter, r *http.Request)
+	// Start a passkey registration
+	// (POST /user/passkeys/register/begin)
+	PostUserPasskeysRegisterBegin(w http.ResponseWriter, r *http.Request)
+	// Complete a passkey registration
+	// (POST /user/passkeys/register/finish)
+	PostUserPasskeysRegisterFinish(w http.ResponseWriter, r *http.Request)
+	// Delete a passkey
+	// (DELETE /user/passkeys/{id})
+	DeleteUserPasskeysId(w http.ResponseWriter, r *http.Request, id int)
+	// Set an email address and password for a user registered through an identity provider
+	// (POST /user/password)
+	PostUserPassword(w http.ResponseWriter, r *http.Request)
+	// Change the password
+	// (PUT /user/password)
+	PutUserPassword(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -73,6 +262,102 @@ func (_ Unimplemented) GetApiV1AuthLineLogin(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// OpenID Connect login callback
+// (GET /api/v1/auth/oidc/{provider}/callback)
+func (_ Unimplemented) GetApiV1AuthOidcProviderCallback(w http.ResponseWriter, r *http.Request, provider string, params GetApiV1AuthOidcProviderCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create new user from an identity provider account
+// (POST /api/v1/auth/oidc/{provider}/create)
+func (_ Unimplemented) PostApiV1AuthOidcProviderCreate(w http.ResponseWriter, r *http.Request, provider string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Link an identity provider account to existing user
+// (POST /api/v1/auth/oidc/{provider}/link)
+func (_ Unimplemented) PostApiV1AuthOidcProviderLink(w http.ResponseWriter, r *http.Request, provider string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Initiate OpenID Connect login flow
+// (GET /api/v1/auth/oidc/{provider}/login)
+func (_ Unimplemented) GetApiV1AuthOidcProviderLogin(w http.ResponseWriter, r *http.Request, provider string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refresh the access token
+// (POST /auth/refresh)
+func (_ Unimplemented) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get budgets
+// (GET /budgets)
+func (_ Unimplemented) GetBudgets(w http.ResponseWriter, r *http.Request, params GetBudgetsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new budget
+// (POST /budgets)
+func (_ Unimplemented) PostBudgets(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get budget versus actual status
+// (GET /budgets/status)
+func (_ Unimplemented) GetBudgetsStatus(w http.ResponseWriter, r *http.Request, params GetBudgetsStatusParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a budget
+// (DELETE /budgets/{id})
+func (_ Unimplemented) DeleteBudgetsId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a budget
+// (PUT /budgets/{id})
+func (_ Unimplemented) PutBudgetsId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get monthly cash flow
+// (GET /cash-flow)
+func (_ Unimplemented) GetCashFlow(w http.ResponseWriter, r *http.Request, params GetCashFlowParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get categories of the household
+// (GET /categories)
+func (_ Unimplemented) GetCategories(w http.ResponseWriter, r *http.Request, params GetCategoriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new category
+// (POST /categories)
+func (_ Unimplemented) PostCategories(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a category
+// (DELETE /categories/{id})
+func (_ Unimplemented) DeleteCategoriesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a category
+// (PUT /categories/{id})
+func (_ Unimplemented) PutCategoriesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Verify the email address
+// (POST /email/verify)
+func (_ Unimplemented) PostEmailVerify(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get expenses
 // (GET /expenses)
 func (_ Unimplemented) GetExpenses(w http.ResponseWriter, r *http.Request, params GetExpensesParams) {
@@ -85,6 +370,24 @@ func (_ Unimplemented) PostExpenses(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Export expenses
+// (GET /expenses/export)
+func (_ Unimplemented) GetExpensesExport(w http.ResponseWriter, r *http.Request, params GetExpensesExportParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Import expenses from a CSV file
+// (POST /expenses/import)
+func (_ Unimplemented) PostExpensesImport(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get expense totals grouped by a dimension
+// (GET /expenses/summary)
+func (_ Unimplemented) GetExpensesSummary(w http.ResponseWriter, r *http.Request, params GetExpensesSummaryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete an expense
 // (DELETE /expenses/{id})
 func (_ Unimplemented) DeleteExpensesId(w http.ResponseWriter, r *http.Request, id int) {
@@ -97,6 +400,186 @@ func (_ Unimplemented) PutExpensesId(w http.ResponseWriter, r *http.Request, id
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List invitations of the household
+// (GET /household/invitations)
+func (_ Unimplemented) GetHouseholdInvitations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an invitation to the household
+// (POST /household/invitations)
+func (_ Unimplemented) PostHouseholdInvitations(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an invitation
+// (DELETE /household/invitations/{id})
+func (_ Unimplemented) DeleteHouseholdInvitationsId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Leave the household
+// (POST /household/leave)
+func (_ Unimplemented) PostHouseholdLeave(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List household members and their roles
+// (GET /household/members)
+func (_ Unimplemented) GetHouseholdMembers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a member from the household
+// (DELETE /household/members/{userId})
+func (_ Unimplemented) DeleteHouseholdMembersUserId(w http.ResponseWriter, r *http.Request, userId int, params DeleteHouseholdMembersUserIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the role of a household member
+// (PUT /household/members/{userId}/role)
+func (_ Unimplemented) PutHouseholdMembersUserIdRole(w http.ResponseWriter, r *http.Request, userId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Transfer ownership of the household to another member
+// (PUT /household/owner)
+func (_ Unimplemented) PutHouseholdOwner(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get household settings
+// (GET /household/settings)
+func (_ Unimplemented) GetHouseholdSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update household settings
+// (PUT /household/settings)
+func (_ Unimplemented) PutHouseholdSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get monthly settlement
+// (GET /household/settlement)
+func (_ Unimplemented) GetHouseholdSettlement(w http.ResponseWriter, r *http.Request, params GetHouseholdSettlementParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get household split rule
+// (GET /household/split)
+func (_ Unimplemented) GetHouseholdSplit(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update household split rule
+// (PUT /household/split)
+func (_ Unimplemented) PutHouseholdSplit(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get incomes
+// (GET /incomes)
+func (_ Unimplemented) GetIncomes(w http.ResponseWriter, r *http.Request, params GetIncomesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new income
+// (POST /incomes)
+func (_ Unimplemented) PostIncomes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete an income
+// (DELETE /incomes/{id})
+func (_ Unimplemented) DeleteIncomesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update an income
+// (PUT /incomes/{id})
+func (_ Unimplemented) PutIncomesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Log in with email and password
+// (POST /login)
+func (_ Unimplemented) PostLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete the login with a two-factor code
+// (POST /login/2fa)
+func (_ Unimplemented) PostLogin2fa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start a passkey login
+// (POST /passkeys/login/begin)
+func (_ Unimplemented) PostPasskeysLoginBegin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete a passkey login
+// (POST /passkeys/login/finish)
+func (_ Unimplemented) PostPasskeysLoginFinish(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Request a password reset
+// (POST /password/forgot)
+func (_ Unimplemented) PostPasswordForgot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset the password with a reset token
+// (POST /password/reset)
+func (_ Unimplemented) PostPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get recurring expenses
+// (GET /recurring-expenses)
+func (_ Unimplemented) GetRecurringExpenses(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new recurring expense
+// (POST /recurring-expenses)
+func (_ Unimplemented) PostRecurringExpenses(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a recurring expense
+// (DELETE /recurring-expenses/{id})
+func (_ Unimplemented) DeleteRecurringExpensesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a recurring expense
+// (PUT /recurring-expenses/{id})
+func (_ Unimplemented) PutRecurringExpensesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke all sessions
+// (DELETE /sessions)
+func (_ Unimplemented) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List active sessions
+// (GET /sessions)
+func (_ Unimplemented) GetSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke a session
+// (DELETE /sessions/{id})
+func (_ Unimplemented) DeleteSessionsId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // User registration
 // (POST /signup)
 func (_ Unimplemented) PostSignup(w http.ResponseWriter, r *http.Request) {
@@ -109,6 +592,90 @@ func (_ Unimplemented) PutUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get the two-factor authentication status
+// (GET /user/2fa)
+func (_ Unimplemented) GetUser2fa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Regenerate recovery codes
+// (POST /user/2fa/recovery-codes)
+func (_ Unimplemented) PostUser2faRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm TOTP enrollment
+// (POST /user/2fa/totp/confirm)
+func (_ Unimplemented) PostUser2faTotpConfirm(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Disable TOTP
+// (POST /user/2fa/totp/disable)
+func (_ Unimplemented) PostUser2faTotpDisable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start TOTP enrollment
+// (POST /user/2fa/totp/setup)
+func (_ Unimplemented) PostUser2faTotpSetup(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Resend the verification email
+// (POST /user/email/verification)
+func (_ Unimplemented) PostUserEmailVerification(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List identity providers linked to the current user
+// (GET /user/identities)
+func (_ Unimplemented) GetUserIdentities(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unlink an identity provider from the current user
+// (DELETE /user/identities/{id})
+func (_ Unimplemented) DeleteUserIdentitiesId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List registered passkeys
+// (GET /user/passkeys)
+func (_ Unimplemented) GetUserPasskeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start a passkey registration
+// (POST /user/passkeys/register/begin)
+func (_ Unimplemented) PostUserPasskeysRegisterBegin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete a passkey registration
+// (POST /user/passkeys/register/finish)
+func (_ Unimplemented) PostUserPasskeysRegisterFinish(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a passkey
+// (DELETE /user/passkeys/{id})
+func (_ Unimplemented) DeleteUserPasskeysId(w http.ResponseWriter, r *http.Request, id int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set an email address and password for a user registered through an identity provider
+// (POST /user/password)
+func (_ Unimplemented) PostUserPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the password
+// (PUT /user/password)
+func (_ Unimplemented) PutUserPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -150,14 +717,1512 @@ func (siw *ServerInterfaceWrapper) GetApiV1AuthLineCallback(w http.ResponseWrite
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "state", r.URL.Query(), &params.State)
+	err = runtime.BindQueryParameter("form", true, true, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1AuthLineCallback(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiV1AuthLineCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AuthLineCreate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiV1AuthLineCreate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiV1AuthLineLink operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AuthLineLink(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiV1AuthLineLink(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiV1AuthLineLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AuthLineLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1AuthLineLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiV1AuthOidcProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AuthOidcProviderCallback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetApiV1AuthOidcProviderCallbackParams
+
+	// ------------- Required query parameter "code" -------------
+
+	if paramValue := r.URL.Query().Get("code"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "code"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := r.URL.Query().Get("state"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "state"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1AuthOidcProviderCallback(w, r, provider, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiV1AuthOidcProviderCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AuthOidcProviderCreate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiV1AuthOidcProviderCreate(w, r, provider)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostApiV1AuthOidcProviderLink operation middleware
+func (siw *ServerInterfaceWrapper) PostApiV1AuthOidcProviderLink(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostApiV1AuthOidcProviderLink(w, r, provider)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetApiV1AuthOidcProviderLogin operation middleware
+func (siw *ServerInterfaceWrapper) GetApiV1AuthOidcProviderLogin(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", chi.URLParam(r, "provider"), &provider, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetApiV1AuthOidcProviderLogin(w, r, provider)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRefresh(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBudgets operation middleware
+func (siw *ServerInterfaceWrapper) GetBudgets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBudgetsParams
+
+	// ------------- Required query parameter "year" -------------
+
+	if paramValue := r.URL.Query().Get("year"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "year"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "month" -------------
+
+	if paramValue := r.URL.Query().Get("month"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "month"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBudgets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostBudgets operation middleware
+func (siw *ServerInterfaceWrapper) PostBudgets(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostBudgets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBudgetsStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetBudgetsStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBudgetsStatusParams
+
+	// ------------- Required query parameter "year" -------------
+
+	if paramValue := r.URL.Query().Get("year"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "year"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "month" -------------
+
+	if paramValue := r.URL.Query().Get("month"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "month"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBudgetsStatus(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBudgetsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBudgetsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBudgetsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutBudgetsId operation middleware
+func (siw *ServerInterfaceWrapper) PutBudgetsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutBudgetsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCashFlow operation middleware
+func (siw *ServerInterfaceWrapper) GetCashFlow(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCashFlowParams
+
+	// ------------- Required query parameter "year" -------------
+
+	if paramValue := r.URL.Query().Get("year"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "year"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCashFlow(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCategories operation middleware
+func (siw *ServerInterfaceWrapper) GetCategories(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCategoriesParams
+
+	// ------------- Optional query parameter "include_archived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_archived", r.URL.Query(), &params.IncludeArchived)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_archived", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCategories(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostCategories operation middleware
+func (siw *ServerInterfaceWrapper) PostCategories(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostCategories(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategoriesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCategoriesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutCategoriesId operation middleware
+func (siw *ServerInterfaceWrapper) PutCategoriesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutCategoriesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEmailVerify operation middleware
+func (siw *ServerInterfaceWrapper) PostEmailVerify(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEmailVerify(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExpenses operation middleware
+func (siw *ServerInterfaceWrapper) GetExpenses(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExpensesParams
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "month" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category_id", r.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "payer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "payer_id", r.URL.Query(), &params.PayerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "payer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_min", r.URL.Query(), &params.AmountMin)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_min", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_max", r.URL.Query(), &params.AmountMax)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_max", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExpenses(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostExpenses operation middleware
+func (siw *ServerInterfaceWrapper) PostExpenses(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostExpenses(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExpensesExport operation middleware
+func (siw *ServerInterfaceWrapper) GetExpensesExport(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExpensesExportParams
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "encoding" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "encoding", r.URL.Query(), &params.Encoding)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "encoding", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "year" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "month" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category_id", r.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "payer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "payer_id", r.URL.Query(), &params.PayerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "payer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_min" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_min", r.URL.Query(), &params.AmountMin)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_min", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_max" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amount_max", r.URL.Query(), &params.AmountMax)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_max", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExpensesExport(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostExpensesImport operation middleware
+func (siw *ServerInterfaceWrapper) PostExpensesImport(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostExpensesImport(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExpensesSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetExpensesSummary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExpensesSummaryParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "group_by" -------------
+
+	if paramValue := r.URL.Query().Get("group_by"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "group_by"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "group_by", r.URL.Query(), &params.GroupBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_by", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExpensesSummary(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteExpensesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteExpensesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteExpensesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutExpensesId operation middleware
+func (siw *ServerInterfaceWrapper) PutExpensesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutExpensesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseholdInvitations operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseholdInvitations(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseholdInvitations(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostHouseholdInvitations operation middleware
+func (siw *ServerInterfaceWrapper) PostHouseholdInvitations(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHouseholdInvitations(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteHouseholdInvitationsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHouseholdInvitationsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteHouseholdInvitationsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostHouseholdLeave operation middleware
+func (siw *ServerInterfaceWrapper) PostHouseholdLeave(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostHouseholdLeave(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseholdMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseholdMembers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseholdMembers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteHouseholdMembersUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHouseholdMembersUserId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteHouseholdMembersUserIdParams
+
+	// ------------- Optional query parameter "expenses" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "expenses", r.URL.Query(), &params.Expenses)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expenses", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteHouseholdMembersUserId(w, r, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutHouseholdMembersUserIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PutHouseholdMembersUserIdRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutHouseholdMembersUserIdRole(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutHouseholdOwner operation middleware
+func (siw *ServerInterfaceWrapper) PutHouseholdOwner(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutHouseholdOwner(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseholdSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseholdSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseholdSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutHouseholdSettings operation middleware
+func (siw *ServerInterfaceWrapper) PutHouseholdSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutHouseholdSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseholdSettlement operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseholdSettlement(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHouseholdSettlementParams
+
+	// ------------- Required query parameter "year" -------------
+
+	if paramValue := r.URL.Query().Get("year"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "year"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "month" -------------
+
+	if paramValue := r.URL.Query().Get("month"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "month"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseholdSettlement(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHouseholdSplit operation middleware
+func (siw *ServerInterfaceWrapper) GetHouseholdSplit(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHouseholdSplit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutHouseholdSplit operation middleware
+func (siw *ServerInterfaceWrapper) PutHouseholdSplit(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutHouseholdSplit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetIncomes operation middleware
+func (siw *ServerInterfaceWrapper) GetIncomes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIncomesParams
+
+	// ------------- Required query parameter "year" -------------
+
+	if paramValue := r.URL.Query().Get("year"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "year"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "year", r.URL.Query(), &params.Year)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "month" -------------
+
+	if paramValue := r.URL.Query().Get("month"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "month"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIncomes(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostIncomes operation middleware
+func (siw *ServerInterfaceWrapper) PostIncomes(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostIncomes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteIncomesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteIncomesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteIncomesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutIncomesId operation middleware
+func (siw *ServerInterfaceWrapper) PutIncomesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutIncomesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostLogin2fa operation middleware
+func (siw *ServerInterfaceWrapper) PostLogin2fa(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostLogin2fa(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPasskeysLoginBegin operation middleware
+func (siw *ServerInterfaceWrapper) PostPasskeysLoginBegin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPasskeysLoginBegin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPasskeysLoginFinish operation middleware
+func (siw *ServerInterfaceWrapper) PostPasskeysLoginFinish(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPasskeysLoginFinish(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPasswordForgot operation middleware
+func (siw *ServerInterfaceWrapper) PostPasswordForgot(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPasswordForgot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRecurringExpenses operation middleware
+func (siw *ServerInterfaceWrapper) GetRecurringExpenses(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRecurringExpenses(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostRecurringExpenses operation middleware
+func (siw *ServerInterfaceWrapper) PostRecurringExpenses(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostRecurringExpenses(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteRecurringExpensesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRecurringExpensesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRecurringExpensesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutRecurringExpensesId operation middleware
+func (siw *ServerInterfaceWrapper) PutRecurringExpensesId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutRecurringExpensesId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSessionsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetApiV1AuthLineCallback(w, r, params)
+		siw.Handler.DeleteSessionsId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -167,11 +2232,11 @@ func (siw *ServerInterfaceWrapper) GetApiV1AuthLineCallback(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// PostApiV1AuthLineCreate operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1AuthLineCreate(w http.ResponseWriter, r *http.Request) {
+// PostSignup operation middleware
+func (siw *ServerInterfaceWrapper) PostSignup(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostApiV1AuthLineCreate(w, r)
+		siw.Handler.PostSignup(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -181,11 +2246,11 @@ func (siw *ServerInterfaceWrapper) PostApiV1AuthLineCreate(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// PostApiV1AuthLineLink operation middleware
-func (siw *ServerInterfaceWrapper) PostApiV1AuthLineLink(w http.ResponseWriter, r *http.Request) {
+// PutUser operation middleware
+func (siw *ServerInterfaceWrapper) PutUser(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostApiV1AuthLineLink(w, r)
+		siw.Handler.PutUser(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -195,11 +2260,11 @@ func (siw *ServerInterfaceWrapper) PostApiV1AuthLineLink(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetApiV1AuthLineLogin operation middleware
-func (siw *ServerInterfaceWrapper) GetApiV1AuthLineLogin(w http.ResponseWriter, r *http.Request) {
+// GetUser2fa operation middleware
+func (siw *ServerInterfaceWrapper) GetUser2fa(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetApiV1AuthLineLogin(w, r)
+		siw.Handler.GetUser2fa(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -209,54 +2274,67 @@ func (siw *ServerInterfaceWrapper) GetApiV1AuthLineLogin(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetExpenses operation middleware
-func (siw *ServerInterfaceWrapper) GetExpenses(w http.ResponseWriter, r *http.Request) {
+// PostUser2faRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) PostUser2faRecoveryCodes(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUser2faRecoveryCodes(w, r)
+	}))
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetExpensesParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// ------------- Required query parameter "year" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	if paramValue := r.URL.Query().Get("year"); paramValue != "" {
+// PostUser2faTotpConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostUser2faTotpConfirm(w http.ResponseWriter, r *http.Request) {
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "year"})
-		return
-	}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUser2faTotpConfirm(w, r)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, true, "year", r.URL.Query(), &params.Year)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "year", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Required query parameter "month" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	if paramValue := r.URL.Query().Get("month"); paramValue != "" {
+// PostUser2faTotpDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostUser2faTotpDisable(w http.ResponseWriter, r *http.Request) {
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "month"})
-		return
-	}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUser2faTotpDisable(w, r)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, true, "month", r.URL.Query(), &params.Month)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "category" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "category", r.URL.Query(), &params.Category)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "category", Err: err})
-		return
+// PostUser2faTotpSetup operation middleware
+func (siw *ServerInterfaceWrapper) PostUser2faTotpSetup(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUser2faTotpSetup(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// PostUserEmailVerification operation middleware
+func (siw *ServerInterfaceWrapper) PostUserEmailVerification(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExpenses(w, r, params)
+		siw.Handler.PostUserEmailVerification(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -266,11 +2344,11 @@ func (siw *ServerInterfaceWrapper) GetExpenses(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// PostExpenses operation middleware
-func (siw *ServerInterfaceWrapper) PostExpenses(w http.ResponseWriter, r *http.Request) {
+// GetUserIdentities operation middleware
+func (siw *ServerInterfaceWrapper) GetUserIdentities(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostExpenses(w, r)
+		siw.Handler.GetUserIdentities(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -280,8 +2358,8 @@ func (siw *ServerInterfaceWrapper) PostExpenses(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteExpensesId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteExpensesId(w http.ResponseWriter, r *http.Request) {
+// DeleteUserIdentitiesId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUserIdentitiesId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -295,7 +2373,7 @@ func (siw *ServerInterfaceWrapper) DeleteExpensesId(w http.ResponseWriter, r *ht
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteExpensesId(w, r, id)
+		siw.Handler.DeleteUserIdentitiesId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -305,8 +2383,50 @@ func (siw *ServerInterfaceWrapper) DeleteExpensesId(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// PutExpensesId operation middleware
-func (siw *ServerInterfaceWrapper) PutExpensesId(w http.ResponseWriter, r *http.Request) {
+// GetUserPasskeys operation middleware
+func (siw *ServerInterfaceWrapper) GetUserPasskeys(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserPasskeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUserPasskeysRegisterBegin operation middleware
+func (siw *ServerInterfaceWrapper) PostUserPasskeysRegisterBegin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUserPasskeysRegisterBegin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostUserPasskeysRegisterFinish operation middleware
+func (siw *ServerInterfaceWrapper) PostUserPasskeysRegisterFinish(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostUserPasskeysRegisterFinish(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUserPasskeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUserPasskeysId(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -320,7 +2440,7 @@ func (siw *ServerInterfaceWrapper) PutExpensesId(w http.ResponseWriter, r *http.
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutExpensesId(w, r, id)
+		siw.Handler.DeleteUserPasskeysId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -330,11 +2450,11 @@ func (siw *ServerInterfaceWrapper) PutExpensesId(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// PostSignup operation middleware
-func (siw *ServerInterfaceWrapper) PostSignup(w http.ResponseWriter, r *http.Request) {
+// PostUserPassword operation middleware
+func (siw *ServerInterfaceWrapper) PostUserPassword(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostSignup(w, r)
+		siw.Handler.PostUserPassword(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -344,11 +2464,11 @@ func (siw *ServerInterfaceWrapper) PostSignup(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// PutUser operation middleware
-func (siw *ServerInterfaceWrapper) PutUser(w http.ResponseWriter, r *http.Request) {
+// PutUserPassword operation middleware
+func (siw *ServerInterfaceWrapper) PutUserPassword(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutUser(w, r)
+		siw.Handler.PutUserPassword(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -483,18 +2603,165 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/auth/line/login", wrapper.GetApiV1AuthLineLogin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/auth/oidc/{provider}/callback", wrapper.GetApiV1AuthOidcProviderCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/auth/oidc/{provider}/create", wrapper.PostApiV1AuthOidcProviderCreate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/auth/oidc/{provider}/link", wrapper.PostApiV1AuthOidcProviderLink)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/auth/oidc/{provider}/login", wrapper.GetApiV1AuthOidcProviderLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/refresh", wrapper.PostAuthRefresh)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/budgets", wrapper.GetBudgets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/budgets", wrapper.PostBudgets)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/budgets/status", wrapper.GetBudgetsStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/budgets/{id}", wrapper.DeleteBudgetsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/budgets/{id}", wrapper.PutBudgetsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cash-flow", wrapper.GetCashFlow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/categories", wrapper.GetCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/categories", wrapper.PostCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/categories/{id}", wrapper.DeleteCategoriesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/categories/{id}", wrapper.PutCategoriesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/email/verify", wrapper.PostEmailVerify)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/expenses", wrapper.GetExpenses)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/expenses", wrapper.PostExpenses)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/expenses/export", wrapper.GetExpensesExport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/expenses/import", wrapper.PostExpensesImport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/expenses/summary", wrapper.GetExpensesSummary)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/expenses/{id}", wrapper.DeleteExpensesId)
 	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/expenses/{id}", wrapper.PutExpensesId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/household/invitations", wrapper.GetHouseholdInvitations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/household/invitations", wrapper.PostHouseholdInvitations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/household/invitations/{id}", wrapper.DeleteHouseholdInvitationsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/household/leave", wrapper.PostHouseholdLeave)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/household/members", wrapper.GetHouseholdMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/household/members/{userId}", wrapper.DeleteHouseholdMembersUserId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/household/members/{userId}/role", wrapper.PutHouseholdMembersUserIdRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/household/owner", wrapper.PutHouseholdOwner)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/household/settings", wrapper.GetHouseholdSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/household/settings", wrapper.PutHouseholdSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/household/settlement", wrapper.GetHouseholdSettlement)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/household/split", wrapper.GetHouseholdSplit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/household/split", wrapper.PutHouseholdSplit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/incomes", wrapper.GetIncomes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/incomes", wrapper.PostIncomes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/incomes/{id}", wrapper.DeleteIncomesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/incomes/{id}", wrapper.PutIncomesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/login", wrapper.PostLogin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/login/2fa", wrapper.PostLogin2fa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/passkeys/login/begin", wrapper.PostPasskeysLoginBegin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/passkeys/login/finish", wrapper.PostPasskeysLoginFinish)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/password/forgot", wrapper.PostPasswordForgot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/password/reset", wrapper.PostPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/recurring-expenses", wrapper.GetRecurringExpenses)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/recurring-expenses", wrapper.PostRecurringExpenses)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/recurring-expenses/{id}", wrapper.DeleteRecurringExpensesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/recurring-expenses/{id}", wrapper.PutRecurringExpensesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/sessions", wrapper.DeleteSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions", wrapper.GetSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/sessions/{id}", wrapper.DeleteSessionsId)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/signup", wrapper.PostSignup)
 	})
@@ -502,5 +2769,47 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		r.Put(options.BaseURL+"/user", wrapper.PutUser)
 	})
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/user/2fa", wrapper.GetUser2fa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/2fa/recovery-codes", wrapper.PostUser2faRecoveryCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/2fa/totp/confirm", wrapper.PostUser2faTotpConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/2fa/totp/disable", wrapper.PostUser2faTotpDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/2fa/totp/setup", wrapper.PostUser2faTotpSetup)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/email/verification", wrapper.PostUserEmailVerification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/user/identities", wrapper.GetUserIdentities)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/user/identities/{id}", wrapper.DeleteUserIdentitiesId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/user/passkeys", wrapper.GetUserPasskeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/passkeys/register/begin", wrapper.PostUserPasskeysRegisterBegin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/passkeys/register/finish", wrapper.PostUserPasskeysRegisterFinish)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/user/passkeys/{id}", wrapper.DeleteUserPasskeysId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/user/password", wrapper.PostUserPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/user/password", wrapper.PutUserPassword)
+	})
 	return r
 }
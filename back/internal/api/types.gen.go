@@ -9,35 +9,712 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for ExpenseSummaryGroupBy.
+const (
+	ExpenseSummaryGroupByCategory ExpenseSummaryGroupBy = "category"
+	ExpenseSummaryGroupByDay      ExpenseSummaryGroupBy = "day"
+	ExpenseSummaryGroupByMonth    ExpenseSummaryGroupBy = "month"
+	ExpenseSummaryGroupByPayer    ExpenseSummaryGroupBy = "payer"
+	ExpenseSummaryGroupByStore    ExpenseSummaryGroupBy = "store"
+	ExpenseSummaryGroupByWeek     ExpenseSummaryGroupBy = "week"
+)
+
+// Defines values for GetExpensesExportParamsEncoding.
+const (
+	GetExpensesExportParamsEncodingShiftJis GetExpensesExportParamsEncoding = "shift_jis"
+	GetExpensesExportParamsEncodingUtf8     GetExpensesExportParamsEncoding = "utf-8"
+)
+
+// Defines values for GetExpensesExportParamsFormat.
+const (
+	GetExpensesExportParamsFormatCsv   GetExpensesExportParamsFormat = "csv"
+	GetExpensesExportParamsFormatJsonl GetExpensesExportParamsFormat = "jsonl"
+	GetExpensesExportParamsFormatXlsx  GetExpensesExportParamsFormat = "xlsx"
+)
+
+// Defines values for GetExpensesExportParamsOrder.
+const (
+	GetExpensesExportParamsOrderAsc  GetExpensesExportParamsOrder = "asc"
+	GetExpensesExportParamsOrderDesc GetExpensesExportParamsOrder = "desc"
+)
+
+// Defines values for GetExpensesExportParamsSort.
+const (
+	GetExpensesExportParamsSortAmount    GetExpensesExportParamsSort = "amount"
+	GetExpensesExportParamsSortCreatedAt GetExpensesExportParamsSort = "created_at"
+	GetExpensesExportParamsSortDate      GetExpensesExportParamsSort = "date"
+)
+
+// Defines values for GetExpensesParamsOrder.
+const (
+	GetExpensesParamsOrderAsc  GetExpensesParamsOrder = "asc"
+	GetExpensesParamsOrderDesc GetExpensesParamsOrder = "desc"
+)
+
+// Defines values for GetExpensesParamsSort.
+const (
+	GetExpensesParamsSortAmount    GetExpensesParamsSort = "amount"
+	GetExpensesParamsSortCreatedAt GetExpensesParamsSort = "created_at"
+	GetExpensesParamsSortDate      GetExpensesParamsSort = "date"
+)
+
+// Defines values for HouseholdDataHandoff.
+const (
+	HouseholdDataHandoffAnonymize HouseholdDataHandoff = "anonymize"
+	HouseholdDataHandoffMove      HouseholdDataHandoff = "move"
+	HouseholdDataHandoffStay      HouseholdDataHandoff = "stay"
+)
+
+// Defines values for HouseholdRole.
+const (
+	HouseholdRoleAdmin  HouseholdRole = "admin"
+	HouseholdRoleMember HouseholdRole = "member"
+	HouseholdRoleOwner  HouseholdRole = "owner"
+	HouseholdRoleViewer HouseholdRole = "viewer"
+)
+
+// Defines values for HouseholdSettingsWeekStart.
+const (
+	Friday    HouseholdSettingsWeekStart = "friday"
+	Monday    HouseholdSettingsWeekStart = "monday"
+	Saturday  HouseholdSettingsWeekStart = "saturday"
+	Sunday    HouseholdSettingsWeekStart = "sunday"
+	Thursday  HouseholdSettingsWeekStart = "thursday"
+	Tuesday   HouseholdSettingsWeekStart = "tuesday"
+	Wednesday HouseholdSettingsWeekStart = "wednesday"
+)
+
+// Defines values for InvitationResponseStatus.
+const (
+	InvitationResponseStatusActive  InvitationResponseStatus = "active"
+	InvitationResponseStatusExpired InvitationResponseStatus = "expired"
+	InvitationResponseStatusRevoked InvitationResponseStatus = "revoked"
+	InvitationResponseStatusUsedUp  InvitationResponseStatus = "used_up"
+)
+
+// Defines values for PostExpensesImportMultipartBodyEncoding.
+const (
+	PostExpensesImportMultipartBodyEncodingAuto     PostExpensesImportMultipartBodyEncoding = "auto"
+	PostExpensesImportMultipartBodyEncodingShiftJis PostExpensesImportMultipartBodyEncoding = "shift_jis"
+	PostExpensesImportMultipartBodyEncodingUtf8     PostExpensesImportMultipartBodyEncoding = "utf-8"
+)
+
+// Defines values for RecurringFrequency.
+const (
+	RecurringFrequencyLastBusinessDay RecurringFrequency = "last_business_day"
+	RecurringFrequencyMonthly         RecurringFrequency = "monthly"
+	RecurringFrequencyWeekly          RecurringFrequency = "weekly"
+	RecurringFrequencyYearly          RecurringFrequency = "yearly"
+)
+
+// Defines values for SplitRuleMethod.
+const (
+	SplitRuleMethodEqual SplitRuleMethod = "equal"
+	SplitRuleMethodFixed SplitRuleMethod = "fixed"
+)
+
+// BudgetRequest defines model for BudgetRequest.
+type BudgetRequest struct {
+	Category    string `json:"category"`
+	LimitAmount int    `json:"limit_amount"`
+	Month       int    `json:"month"`
+	Year        int    `json:"year"`
+}
+
+// BudgetResponse defines model for BudgetResponse.
+type BudgetResponse struct {
+	Category    string    `json:"category"`
+	CreatedAt   time.Time `json:"created_at"`
+	Id          int       `json:"id"`
+	LimitAmount int       `json:"limit_amount"`
+	Month       int       `json:"month"`
+	Year        int       `json:"year"`
+}
+
+// BudgetStatusItem defines model for BudgetStatusItem.
+type BudgetStatusItem struct {
+	BudgetId    int     `json:"budget_id"`
+	Category    string  `json:"category"`
+	LimitAmount int     `json:"limit_amount"`
+	PercentUsed float64 `json:"percent_used"`
+	Remaining   int     `json:"remaining"`
+	Spent       int     `json:"spent"`
+}
+
+// BudgetStatusResponse defines model for BudgetStatusResponse.
+type BudgetStatusResponse struct {
+	// Currency ISO 4217 code of the household's display currency.
+	Currency string `json:"currency"`
+
+	// From First day of the month, following the household's month start day.
+	From  openapi_types.Date `json:"from"`
+	Items []BudgetStatusItem `json:"items"`
+	Month int                `json:"month"`
+
+	// To Last day of the month, following the household's month start day.
+	To             openapi_types.Date `json:"to"`
+	TotalLimit     int                `json:"total_limit"`
+	TotalRemaining int                `json:"total_remaining"`
+	TotalSpent     int                `json:"total_spent"`
+	Year           int                `json:"year"`
+}
+
+// CashFlowMonth defines model for CashFlowMonth.
+type CashFlowMonth struct {
+	Balance int `json:"balance"`
+	Expense int `json:"expense"`
+
+	// From First day of the month, following the household's month start day.
+	From   openapi_types.Date `json:"from"`
+	Income int                `json:"income"`
+	Month  int                `json:"month"`
+
+	// To Last day of the month, following the household's month start day.
+	To openapi_types.Date `json:"to"`
+}
+
+// CashFlowResponse defines model for CashFlowResponse.
+type CashFlowResponse struct {
+	Balance int `json:"balance"`
+
+	// Currency ISO 4217 code of the household's display currency.
+	Currency     string          `json:"currency"`
+	Months       []CashFlowMonth `json:"months"`
+	TotalExpense int             `json:"total_expense"`
+	TotalIncome  int             `json:"total_income"`
+	Year         int             `json:"year"`
+}
+
+// CategoryRequest defines model for CategoryRequest.
+type CategoryRequest struct {
+	Archived *bool `json:"archived,omitempty"`
+
+	// Color Display color in '#RRGGBB' format.
+	Color *string `json:"color,omitempty"`
+	Icon  *string `json:"icon,omitempty"`
+	Name  string  `json:"name"`
+
+	// ParentId Parent category for sub-categories.
+	ParentId  *int `json:"parent_id"`
+	SortOrder *int `json:"sort_order,omitempty"`
+}
+
+// CategoryResponse defines model for CategoryResponse.
+type CategoryResponse struct {
+	Archived  bool      `json:"archived"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	Icon      string    `json:"icon"`
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	ParentId  *int      `json:"parent_id"`
+	SortOrder int       `json:"sort_order"`
+}
+
+// EmailVerificationRequest defines model for EmailVerificationRequest.
+type EmailVerificationRequest struct {
+	Token string `json:"token"`
+}
+
+// ExpenseImportMapping Columns of the CSV for each expense field, given as a header name or a 1-based column number.
+type ExpenseImportMapping struct {
+	Amount    string  `json:"amount"`
+	Category  *string `json:"category,omitempty"`
+	Date      string  `json:"date"`
+	Memo      *string `json:"memo,omitempty"`
+	StoreName string  `json:"store_name"`
+}
+
+// ExpenseImportResult defines model for ExpenseImportResult.
+type ExpenseImportResult struct {
+	AcceptedCount int  `json:"accepted_count"`
+	DryRun        bool `json:"dry_run"`
+
+	// ImportedCount Number of expenses actually created. Always 0 on dry run.
+	ImportedCount int                `json:"imported_count"`
+	RejectedCount int                `json:"rejected_count"`
+	Rows          []ExpenseImportRow `json:"rows"`
+	TotalRows     int                `json:"total_rows"`
+}
+
+// ExpenseImportRow defines model for ExpenseImportRow.
+type ExpenseImportRow struct {
+	Accepted bool                `json:"accepted"`
+	Amount   *int                `json:"amount,omitempty"`
+	Category string              `json:"category"`
+	Date     *openapi_types.Date `json:"date,omitempty"`
+
+	// Errors Reasons why the row was rejected.
+	Errors []string `json:"errors"`
+
+	// Line Line number in the CSV file.
+	Line      int    `json:"line"`
+	Memo      string `json:"memo"`
+	StoreName string `json:"store_name"`
+}
+
 // ExpenseRequest defines model for ExpenseRequest.
 type ExpenseRequest struct {
-	Amount    int       `json:"amount"`
-	Category  string    `json:"category"`
-	Date      time.Time `json:"date"`
-	Memo      *string   `json:"memo,omitempty"`
-	StoreName string    `json:"store_name"`
-	UserId    int       `json:"user_id"`
+	Amount int `json:"amount"`
+
+	// Category Name of an existing, non-archived category of the household. Used when category_id is absent.
+	Category string `json:"category"`
+
+	// CategoryId Category of the household. Takes precedence over category.
+	CategoryId *int `json:"category_id,omitempty"`
+
+	// Date Calendar date in YYYY-MM-DD format.
+	Date openapi_types.Date `json:"date"`
+	Memo *string            `json:"memo,omitempty"`
+
+	// PayerId Member who paid. Defaults to user_id.
+	PayerId *int `json:"payer_id,omitempty"`
+
+	// Shares Custom per-member shares overriding the household split rule.
+	Shares    *[]ExpenseShare `json:"shares,omitempty"`
+	StoreName string          `json:"store_name"`
+	UserId    int             `json:"user_id"`
 }
 
 // ExpenseResponse defines model for ExpenseResponse.
 type ExpenseResponse struct {
-	Amount    int       `json:"amount"`
-	Category  string    `json:"category"`
+	Amount     int             `json:"amount"`
+	Category   string          `json:"category"`
+	CategoryId *int            `json:"category_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Date       time.Time       `json:"date"`
+	Id         int             `json:"id"`
+	Memo       *string         `json:"memo,omitempty"`
+	PayerId    *int            `json:"payer_id,omitempty"`
+	PayerName  *string         `json:"payer_name,omitempty"`
+	Shares     *[]ExpenseShare `json:"shares,omitempty"`
+	StoreName  string          `json:"store_name"`
+
+	// UserId User who recorded the expense. 0 when the user has been deleted.
+	UserId int `json:"user_id"`
+}
+
+// ExpenseShare defines model for ExpenseShare.
+type ExpenseShare struct {
+	Amount int `json:"amount"`
+	UserId int `json:"user_id"`
+}
+
+// ExpenseSummaryGroupBy defines model for ExpenseSummaryGroupBy.
+type ExpenseSummaryGroupBy string
+
+// ExpenseSummaryItem defines model for ExpenseSummaryItem.
+type ExpenseSummaryItem struct {
+	Count int `json:"count"`
+
+	// Key Category name, payer ID, store name, or period start (YYYY-MM-DD / YYYY-MM).
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Total int    `json:"total"`
+}
+
+// ExpenseSummaryResponse defines model for ExpenseSummaryResponse.
+type ExpenseSummaryResponse struct {
+	Count int `json:"count"`
+
+	// Currency ISO 4217 code of the household's display currency.
+	Currency string                `json:"currency"`
+	From     openapi_types.Date    `json:"from"`
+	GroupBy  ExpenseSummaryGroupBy `json:"group_by"`
+	Items    []ExpenseSummaryItem  `json:"items"`
+	To       openapi_types.Date    `json:"to"`
+	Total    int                   `json:"total"`
+}
+
+// HouseholdDataHandoff What happens to the member's past records. stay keeps them in the household with the member still recorded as creator, payer and sharer of expenses, move takes them to the member's new household, anonymize keeps them in the household but clears the member as creator, payer, sharer and recipient, and settles without the former member, so their shares fall on the payer and expenses they paid are left out.
+type HouseholdDataHandoff string
+
+// HouseholdLeaveRequest defines model for HouseholdLeaveRequest.
+type HouseholdLeaveRequest struct {
+	// Expenses What happens to the member's past records. stay keeps them in the household with the member still recorded as creator, payer and sharer of expenses, move takes them to the member's new household, anonymize keeps them in the household but clears the member as creator, payer, sharer and recipient, and settles without the former member, so their shares fall on the payer and expenses they paid are left out.
+	Expenses *HouseholdDataHandoff `json:"expenses,omitempty"`
+}
+
+// HouseholdMemberResponse defines model for HouseholdMemberResponse.
+type HouseholdMemberResponse struct {
+	Image    *string       `json:"image,omitempty"`
+	JoinedAt time.Time     `json:"joined_at"`
+	Name     string        `json:"name"`
+	Role     HouseholdRole `json:"role"`
+	UserId   int           `json:"user_id"`
+}
+
+// HouseholdOwnerRequest defines model for HouseholdOwnerRequest.
+type HouseholdOwnerRequest struct {
+	// UserId Member who becomes the new owner.
+	UserId int `json:"user_id"`
+}
+
+// HouseholdRole Role of a household member. Viewers can only read household data.
+type HouseholdRole string
+
+// HouseholdRoleRequest defines model for HouseholdRoleRequest.
+type HouseholdRoleRequest struct {
+	// Role Role of a household member. Viewers can only read household data.
+	Role HouseholdRole `json:"role"`
+}
+
+// HouseholdSettings defines model for HouseholdSettings.
+type HouseholdSettings struct {
+	// Currency ISO 4217 code of the household's display currency.
+	Currency string `json:"currency"`
+
+	// MonthStartDay Day of the month on which a month starts. With 25, January runs from January 25 to February 24.
+	MonthStartDay int `json:"month_start_day"`
+
+	// Timezone IANA timezone used to decide today's date and to convert times to dates.
+	Timezone string `json:"timezone"`
+
+	// WeekStart First day of the week in weekly summaries.
+	WeekStart HouseholdSettingsWeekStart `json:"week_start"`
+}
+
+// HouseholdSettingsWeekStart First day of the week in weekly summaries.
+type HouseholdSettingsWeekStart string
+
+// IdentityResponse defines model for IdentityResponse.
+type IdentityResponse struct {
 	CreatedAt time.Time `json:"created_at"`
-	Date      time.Time `json:"date"`
 	Id        int       `json:"id"`
-	Memo      *string   `json:"memo,omitempty"`
-	PayerName *string   `json:"payer_name,omitempty"`
-	StoreName string    `json:"store_name"`
-	UserId    int       `json:"user_id"`
+	Provider  string    `json:"provider"`
+}
+
+// IncomeRequest defines model for IncomeRequest.
+type IncomeRequest struct {
+	Amount   int    `json:"amount"`
+	Category string `json:"category"`
+
+	// Date Calendar date in YYYY-MM-DD format.
+	Date openapi_types.Date `json:"date"`
+	Memo *string            `json:"memo,omitempty"`
+
+	// RecipientId Member who received the income. Defaults to the current user.
+	RecipientId *int    `json:"recipient_id,omitempty"`
+	Source      *string `json:"source,omitempty"`
+}
+
+// IncomeResponse defines model for IncomeResponse.
+type IncomeResponse struct {
+	Amount        int       `json:"amount"`
+	Category      string    `json:"category"`
+	CreatedAt     time.Time `json:"created_at"`
+	Date          time.Time `json:"date"`
+	Id            int       `json:"id"`
+	Memo          *string   `json:"memo,omitempty"`
+	RecipientId   int       `json:"recipient_id"`
+	RecipientName *string   `json:"recipient_name,omitempty"`
+	Source        string    `json:"source"`
+	UserId        int       `json:"user_id"`
+}
+
+// InvitationRedemption defines model for InvitationRedemption.
+type InvitationRedemption struct {
+	JoinedAt time.Time `json:"joined_at"`
+	Name     *string   `json:"name,omitempty"`
+
+	// UserId Member who joined. Empty when the user has been deleted.
+	UserId *int `json:"user_id,omitempty"`
 }
 
+// InvitationRequest defines model for InvitationRequest.
+type InvitationRequest struct {
+	// Email Only a user who has verified this email address can join.
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// ExpiresInHours Defaults to 168 hours (7 days). Up to 720 hours (30 days).
+	ExpiresInHours *int `json:"expires_in_hours,omitempty"`
+
+	// MaxUses Number of users who can join. 0 or omitted means unlimited.
+	MaxUses *int `json:"max_uses,omitempty"`
+
+	// Provider Identity provider of the invited user, such as line. Must be given together with subject.
+	Provider *string `json:"provider,omitempty"`
+
+	// Role Role of a household member. Viewers can only read household data.
+	Role *HouseholdRole `json:"role,omitempty"`
+
+	// Subject User ID issued by the identity provider, such as the LINE user ID.
+	Subject *string `json:"subject,omitempty"`
+}
+
+// InvitationResponse defines model for InvitationResponse.
+type InvitationResponse struct {
+	Code      string               `json:"code"`
+	CreatedAt time.Time            `json:"created_at"`
+	CreatedBy *int                 `json:"created_by,omitempty"`
+	Email     *openapi_types.Email `json:"email,omitempty"`
+	ExpiresAt time.Time            `json:"expires_at"`
+	Id        int                  `json:"id"`
+
+	// MaxUses 0 means unlimited.
+	MaxUses     int                    `json:"max_uses"`
+	Provider    *string                `json:"provider,omitempty"`
+	Redemptions []InvitationRedemption `json:"redemptions"`
+	RevokedAt   *time.Time             `json:"revoked_at,omitempty"`
+
+	// Role Role of a household member. Viewers can only read household data.
+	Role    HouseholdRole            `json:"role"`
+	Status  InvitationResponseStatus `json:"status"`
+	Subject *string                  `json:"subject,omitempty"`
+	Uses    int                      `json:"uses"`
+}
+
+// InvitationResponseStatus defines model for InvitationResponse.Status.
+type InvitationResponseStatus string
+
 // LinkAccountRequest defines model for LinkAccountRequest.
 type LinkAccountRequest struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
 }
 
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	// MfaRequired Whether the second step of the login is required. Session cookies are set only when false.
+	MfaRequired bool `json:"mfa_required"`
+
+	// MfaToken Short-lived token for POST /login/2fa. Returned only when mfa_required is true.
+	MfaToken *string `json:"mfa_token,omitempty"`
+}
+
+// PasskeyAssertionRequest PublicKeyCredential returned by navigator.credentials.get, encoded as in PublicKeyCredential.toJSON().
+type PasskeyAssertionRequest struct {
+	// Id Base64URL encoded credential ID
+	Id       string                   `json:"id"`
+	RawId    string                   `json:"rawId"`
+	Response PasskeyAssertionResponse `json:"response"`
+	Type     string                   `json:"type"`
+}
+
+// PasskeyAssertionResponse defines model for PasskeyAssertionResponse.
+type PasskeyAssertionResponse struct {
+	AuthenticatorData string  `json:"authenticatorData"`
+	ClientDataJSON    string  `json:"clientDataJSON"`
+	Signature         string  `json:"signature"`
+	UserHandle        *string `json:"userHandle,omitempty"`
+}
+
+// PasskeyAttestationResponse defines model for PasskeyAttestationResponse.
+type PasskeyAttestationResponse struct {
+	AttestationObject string    `json:"attestationObject"`
+	ClientDataJSON    string    `json:"clientDataJSON"`
+	Transports        *[]string `json:"transports,omitempty"`
+}
+
+// PasskeyAuthenticatorSelection defines model for PasskeyAuthenticatorSelection.
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyCreationOptions Options for navigator.credentials.create, encoded as in PublicKeyCredentialCreationOptionsJSON.
+type PasskeyCreationOptions struct {
+	Attestation            string                        `json:"attestation"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+
+	// Challenge Base64URL encoded challenge
+	Challenge          string                        `json:"challenge"`
+	ExcludeCredentials []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	PubKeyCredParams   []PasskeyCredentialParameter  `json:"pubKeyCredParams"`
+	Rp                 PasskeyRelyingParty           `json:"rp"`
+
+	// Timeout Timeout in milliseconds
+	Timeout int               `json:"timeout"`
+	User    PasskeyUserEntity `json:"user"`
+}
+
+// PasskeyCredentialDescriptor defines model for PasskeyCredentialDescriptor.
+type PasskeyCredentialDescriptor struct {
+	Id         string    `json:"id"`
+	Transports *[]string `json:"transports,omitempty"`
+	Type       string    `json:"type"`
+}
+
+// PasskeyCredentialParameter defines model for PasskeyCredentialParameter.
+type PasskeyCredentialParameter struct {
+	// Alg COSE algorithm identifier
+	Alg  int    `json:"alg"`
+	Type string `json:"type"`
+}
+
+// PasskeyRegistrationRequest PublicKeyCredential returned by navigator.credentials.create, encoded as in PublicKeyCredential.toJSON().
+type PasskeyRegistrationRequest struct {
+	Id string `json:"id"`
+
+	// Name Display name of the passkey such as the device name
+	Name     *string                    `json:"name,omitempty"`
+	RawId    string                     `json:"rawId"`
+	Response PasskeyAttestationResponse `json:"response"`
+	Type     string                     `json:"type"`
+}
+
+// PasskeyRelyingParty defines model for PasskeyRelyingParty.
+type PasskeyRelyingParty struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PasskeyRequestOptions Options for navigator.credentials.get, encoded as in PublicKeyCredentialRequestOptionsJSON.
+type PasskeyRequestOptions struct {
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"`
+
+	// Challenge Base64URL encoded challenge
+	Challenge string `json:"challenge"`
+	RpId      string `json:"rpId"`
+
+	// Timeout Timeout in milliseconds
+	Timeout          int    `json:"timeout"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyResponse defines model for PasskeyResponse.
+type PasskeyResponse struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	Id         int        `json:"id"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+}
+
+// PasskeyUserEntity defines model for PasskeyUserEntity.
+type PasskeyUserEntity struct {
+	DisplayName string `json:"displayName"`
+
+	// Id Base64URL encoded user handle
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PasswordChangeRequest defines model for PasswordChangeRequest.
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// PasswordForgotRequest defines model for PasswordForgotRequest.
+type PasswordForgotRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	NewPassword string `json:"new_password"`
+	Token       string `json:"token"`
+}
+
+// PasswordSetupRequest defines model for PasswordSetupRequest.
+type PasswordSetupRequest struct {
+	// Email Required when the user has no email address yet.
+	Email    *openapi_types.Email `json:"email,omitempty"`
+	Password string               `json:"password"`
+}
+
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	// RecoveryCodes Recovery codes shown only once. Each code can be used once instead of a TOTP code.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RecurringExpenseRequest defines model for RecurringExpenseRequest.
+type RecurringExpenseRequest struct {
+	Active   *bool  `json:"active,omitempty"`
+	Amount   int    `json:"amount"`
+	Category string `json:"category"`
+
+	// Day Day of month for monthly and yearly rules. Clamped to the last day of the month.
+	Day       *int               `json:"day,omitempty"`
+	EndDate   *time.Time         `json:"end_date"`
+	Frequency RecurringFrequency `json:"frequency"`
+	Memo      *string            `json:"memo,omitempty"`
+
+	// Month Month for yearly rules.
+	Month *int `json:"month,omitempty"`
+
+	// PayerId Member who pays. Defaults to the current user.
+	PayerId   *int      `json:"payer_id,omitempty"`
+	StartDate time.Time `json:"start_date"`
+	StoreName string    `json:"store_name"`
+
+	// Weekday Day of week for weekly rules (0 = Sunday).
+	Weekday *int `json:"weekday,omitempty"`
+}
+
+// RecurringExpenseResponse defines model for RecurringExpenseResponse.
+type RecurringExpenseResponse struct {
+	Active            bool               `json:"active"`
+	Amount            int                `json:"amount"`
+	Category          string             `json:"category"`
+	CreatedAt         time.Time          `json:"created_at"`
+	Day               *int               `json:"day,omitempty"`
+	EndDate           *time.Time         `json:"end_date"`
+	Frequency         RecurringFrequency `json:"frequency"`
+	Id                int                `json:"id"`
+	LastGeneratedDate *time.Time         `json:"last_generated_date"`
+	Memo              *string            `json:"memo,omitempty"`
+	Month             *int               `json:"month,omitempty"`
+	PayerId           int                `json:"payer_id"`
+	StartDate         time.Time          `json:"start_date"`
+	StoreName         string             `json:"store_name"`
+	Weekday           *int               `json:"weekday,omitempty"`
+}
+
+// RecurringFrequency defines model for RecurringFrequency.
+type RecurringFrequency string
+
+// SessionResponse defines model for SessionResponse.
+type SessionResponse struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Current Whether this is the session of the request
+	Current    bool      `json:"current"`
+	DeviceName string    `json:"device_name"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Id         int       `json:"id"`
+	IpAddress  string    `json:"ip_address"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	UserAgent  string    `json:"user_agent"`
+}
+
+// SettlementMember defines model for SettlementMember.
+type SettlementMember struct {
+	Balance int    `json:"balance"`
+	Name    string `json:"name"`
+	Owed    int    `json:"owed"`
+	Paid    int    `json:"paid"`
+	UserId  int    `json:"user_id"`
+}
+
+// SettlementResponse defines model for SettlementResponse.
+type SettlementResponse struct {
+	// Currency ISO 4217 code of the household's display currency.
+	Currency string `json:"currency"`
+
+	// From First day of the month, following the household's month start day.
+	From    openapi_types.Date `json:"from"`
+	Members []SettlementMember `json:"members"`
+	Month   int                `json:"month"`
+
+	// To Last day of the month, following the household's month start day.
+	To        openapi_types.Date   `json:"to"`
+	Total     int                  `json:"total"`
+	Transfers []SettlementTransfer `json:"transfers"`
+	Year      int                  `json:"year"`
+}
+
+// SettlementTransfer defines model for SettlementTransfer.
+type SettlementTransfer struct {
+	Amount     int    `json:"amount"`
+	FromName   string `json:"from_name"`
+	FromUserId int    `json:"from_user_id"`
+	ToName     string `json:"to_name"`
+	ToUserId   int    `json:"to_user_id"`
+}
+
 // SignUpRequest defines model for SignUpRequest.
 type SignUpRequest struct {
 	Email    openapi_types.Email `json:"email"`
@@ -46,20 +723,79 @@ type SignUpRequest struct {
 	Password string              `json:"password"`
 }
 
+// SplitRule defines model for SplitRule.
+type SplitRule struct {
+	Method SplitRuleMethod `json:"method"`
+	Shares *[]SplitShare   `json:"shares,omitempty"`
+}
+
+// SplitRuleMethod defines model for SplitRule.Method.
+type SplitRuleMethod string
+
+// SplitShare defines model for SplitShare.
+type SplitShare struct {
+	Percent int `json:"percent"`
+	UserId  int `json:"user_id"`
+}
+
+// TOTPCodeRequest defines model for TOTPCodeRequest.
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// TOTPDisableRequest defines model for TOTPDisableRequest.
+type TOTPDisableRequest struct {
+	// Code TOTP code or recovery code
+	Code string `json:"code"`
+
+	// Password Required when the user has a password. Users without a password, who sign in only with an identity provider such as LINE, can disable TOTP with a code alone.
+	Password *string `json:"password,omitempty"`
+}
+
+// TOTPSetupResponse defines model for TOTPSetupResponse.
+type TOTPSetupResponse struct {
+	// OtpauthUri otpauth URI to be shown as a QR code
+	OtpauthUri string `json:"otpauth_uri"`
+
+	// Secret Base32 encoded shared secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// TwoFactorLoginRequest defines model for TwoFactorLoginRequest.
+type TwoFactorLoginRequest struct {
+	// Code TOTP code or recovery code
+	Code     string `json:"code"`
+	MfaToken string `json:"mfa_token"`
+}
+
+// TwoFactorStatusResponse defines model for TwoFactorStatusResponse.
+type TwoFactorStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
 // UserResponse defines model for UserResponse.
 type UserResponse struct {
-	Admin     bool                 `json:"admin"`
-	CreatedAt time.Time            `json:"created_at"`
-	Email     *openapi_types.Email `json:"email"`
-	Id        int                  `json:"id"`
-	Image     *string              `json:"image,omitempty"`
-	Name      string               `json:"name"`
+	Admin         bool                 `json:"admin"`
+	CreatedAt     time.Time            `json:"created_at"`
+	Email         *openapi_types.Email `json:"email"`
+	EmailVerified bool                 `json:"email_verified"`
+	Id            int                  `json:"id"`
+	Image         *string              `json:"image,omitempty"`
+	Name          string               `json:"name"`
 }
 
 // UserUpdate defines model for UserUpdate.
 type UserUpdate struct {
-	Image *string `json:"image,omitempty"`
-	Name  *string `json:"name,omitempty"`
+	Email *openapi_types.Email `json:"email,omitempty"`
+	Image *string              `json:"image,omitempty"`
+	Name  *string              `json:"name,omitempty"`
+}
+
+// DeleteHouseholdMembersUserIdParams defines parameters for DeleteHouseholdMembersUserId.
+type DeleteHouseholdMembersUserIdParams struct {
+	// Expenses What happens to the removed member's past records
+	Expenses *HouseholdDataHandoff `form:"expenses,omitempty" json:"expenses,omitempty"`
 }
 
 // GetApiV1AuthLineCallbackParams defines parameters for GetApiV1AuthLineCallback.
@@ -71,29 +807,304 @@ type GetApiV1AuthLineCallbackParams struct {
 	State string `form:"state" json:"state"`
 }
 
+// GetApiV1AuthOidcProviderCallbackParams defines parameters for GetApiV1AuthOidcProviderCallback.
+type GetApiV1AuthOidcProviderCallbackParams struct {
+	// Code Authorization code from the identity provider
+	Code string `form:"code" json:"code"`
+
+	// State State parameter for CSRF protection
+	State string `form:"state" json:"state"`
+}
+
+// GetBudgetsParams defines parameters for GetBudgets.
+type GetBudgetsParams struct {
+	// Year Year to filter budgets
+	Year int `form:"year" json:"year"`
+
+	// Month Month to filter budgets
+	Month int `form:"month" json:"month"`
+}
+
+// GetBudgetsStatusParams defines parameters for GetBudgetsStatus.
+type GetBudgetsStatusParams struct {
+	// Year Year of the budgets
+	Year int `form:"year" json:"year"`
+
+	// Month Month of the budgets
+	Month int `form:"month" json:"month"`
+}
+
+// GetCashFlowParams defines parameters for GetCashFlow.
+type GetCashFlowParams struct {
+	// Year Year of the cash flow
+	Year int `form:"year" json:"year"`
+}
+
+// GetCategoriesParams defines parameters for GetCategories.
+type GetCategoriesParams struct {
+	// IncludeArchived Include archived categories
+	IncludeArchived *bool `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
 // GetExpensesParams defines parameters for GetExpenses.
 type GetExpensesParams struct {
-	// Year Year to filter expenses
+	// Year Year to filter expenses. Must be given with month.
+	Year *int `form:"year,omitempty" json:"year,omitempty"`
+
+	// Month Month to filter expenses. Must be given with year.
+	Month *int `form:"month,omitempty" json:"month,omitempty"`
+
+	// From First day of the period (inclusive)
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period (inclusive)
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Category Category names to filter expenses
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+
+	// CategoryId Category IDs to filter expenses
+	CategoryId *[]int `form:"category_id,omitempty" json:"category_id,omitempty"`
+
+	// PayerId Member who paid
+	PayerId *int `form:"payer_id,omitempty" json:"payer_id,omitempty"`
+
+	// AmountMin Minimum amount (inclusive)
+	AmountMin *int `form:"amount_min,omitempty" json:"amount_min,omitempty"`
+
+	// AmountMax Maximum amount (inclusive)
+	AmountMax *int `form:"amount_max,omitempty" json:"amount_max,omitempty"`
+
+	// Q Text to search in store name and memo
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort Field to sort by
+	Sort *GetExpensesParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Sort direction
+	Order *GetExpensesParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Cursor Cursor returned in X-Next-Cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum number of expenses per page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetExpensesParamsSort defines parameters for GetExpenses.
+type GetExpensesParamsSort string
+
+// GetExpensesParamsOrder defines parameters for GetExpenses.
+type GetExpensesParamsOrder string
+
+// GetExpensesExportParams defines parameters for GetExpensesExport.
+type GetExpensesExportParams struct {
+	// Format Output format
+	Format GetExpensesExportParamsFormat `form:"format" json:"format"`
+
+	// Encoding Character encoding of the CSV. UTF-8 is written with a BOM.
+	Encoding *GetExpensesExportParamsEncoding `form:"encoding,omitempty" json:"encoding,omitempty"`
+
+	// Year Year to filter expenses. Must be given with month.
+	Year *int `form:"year,omitempty" json:"year,omitempty"`
+
+	// Month Month to filter expenses. Must be given with year.
+	Month *int `form:"month,omitempty" json:"month,omitempty"`
+
+	// From First day of the period (inclusive)
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day of the period (inclusive)
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+
+	// Category Category names to filter expenses
+	Category *[]string `form:"category,omitempty" json:"category,omitempty"`
+
+	// CategoryId Category IDs to filter expenses
+	CategoryId *[]int `form:"category_id,omitempty" json:"category_id,omitempty"`
+
+	// PayerId Member who paid
+	PayerId *int `form:"payer_id,omitempty" json:"payer_id,omitempty"`
+
+	// AmountMin Minimum amount (inclusive)
+	AmountMin *int `form:"amount_min,omitempty" json:"amount_min,omitempty"`
+
+	// AmountMax Maximum amount (inclusive)
+	AmountMax *int `form:"amount_max,omitempty" json:"amount_max,omitempty"`
+
+	// Q Text to search in store name and memo
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Sort Field to sort by
+	Sort *GetExpensesExportParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Sort direction
+	Order *GetExpensesExportParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+}
+
+// GetExpensesExportParamsFormat defines parameters for GetExpensesExport.
+type GetExpensesExportParamsFormat string
+
+// GetExpensesExportParamsEncoding defines parameters for GetExpensesExport.
+type GetExpensesExportParamsEncoding string
+
+// GetExpensesExportParamsSort defines parameters for GetExpensesExport.
+type GetExpensesExportParamsSort string
+
+// GetExpensesExportParamsOrder defines parameters for GetExpensesExport.
+type GetExpensesExportParamsOrder string
+
+// PostExpensesImportMultipartBody defines parameters for PostExpensesImport.
+type PostExpensesImportMultipartBody struct {
+	// DefaultCategory Category used when the category column is empty or not mapped.
+	DefaultCategory *string `json:"default_category,omitempty"`
+
+	// DryRun Only validate the rows without creating expenses.
+	DryRun   *bool                                    `json:"dry_run,omitempty"`
+	Encoding *PostExpensesImportMultipartBodyEncoding `json:"encoding,omitempty"`
+
+	// File CSV file to import.
+	File      openapi_types.File `json:"file"`
+	HasHeader *bool              `json:"has_header,omitempty"`
+
+	// Mapping JSON encoded ExpenseImportMapping.
+	Mapping string `json:"mapping"`
+
+	// PayerId Member who paid all imported expenses. Defaults to the current user.
+	PayerId *int `json:"payer_id,omitempty"`
+}
+
+// PostExpensesImportMultipartBodyEncoding defines parameters for PostExpensesImport.
+type PostExpensesImportMultipartBodyEncoding string
+
+// GetExpensesSummaryParams defines parameters for GetExpensesSummary.
+type GetExpensesSummaryParams struct {
+	// From First day of the period
+	From openapi_types.Date `form:"from" json:"from"`
+
+	// To Last day of the period
+	To openapi_types.Date `form:"to" json:"to"`
+
+	// GroupBy Dimension to group by
+	GroupBy ExpenseSummaryGroupBy `form:"group_by" json:"group_by"`
+}
+
+// GetHouseholdSettlementParams defines parameters for GetHouseholdSettlement.
+type GetHouseholdSettlementParams struct {
+	// Year Year to settle
+	Year int `form:"year" json:"year"`
+
+	// Month Month to settle
+	Month int `form:"month" json:"month"`
+}
+
+// GetIncomesParams defines parameters for GetIncomes.
+type GetIncomesParams struct {
+	// Year Year to filter incomes
 	Year int `form:"year" json:"year"`
 
-	// Month Month to filter expenses
+	// Month Month to filter incomes
 	Month int `form:"month" json:"month"`
 
-	// Category Category to filter expenses
+	// Category Category to filter incomes
 	Category *string `form:"category,omitempty" json:"category,omitempty"`
 }
 
 // PostApiV1AuthLineLinkJSONRequestBody defines body for PostApiV1AuthLineLink for application/json ContentType.
 type PostApiV1AuthLineLinkJSONRequestBody = LinkAccountRequest
 
+// PostApiV1AuthOidcProviderLinkJSONRequestBody defines body for PostApiV1AuthOidcProviderLink for application/json ContentType.
+type PostApiV1AuthOidcProviderLinkJSONRequestBody = LinkAccountRequest
+
+// PostBudgetsJSONRequestBody defines body for PostBudgets for application/json ContentType.
+type PostBudgetsJSONRequestBody = BudgetRequest
+
+// PutBudgetsIdJSONRequestBody defines body for PutBudgetsId for application/json ContentType.
+type PutBudgetsIdJSONRequestBody = BudgetRequest
+
+// PostCategoriesJSONRequestBody defines body for PostCategories for application/json ContentType.
+type PostCategoriesJSONRequestBody = CategoryRequest
+
+// PutCategoriesIdJSONRequestBody defines body for PutCategoriesId for application/json ContentType.
+type PutCategoriesIdJSONRequestBody = CategoryRequest
+
+// PostEmailVerifyJSONRequestBody defines body for PostEmailVerify for application/json ContentType.
+type PostEmailVerifyJSONRequestBody = EmailVerificationRequest
+
 // PostExpensesJSONRequestBody defines body for PostExpenses for application/json ContentType.
 type PostExpensesJSONRequestBody = ExpenseRequest
 
+// PostExpensesImportMultipartRequestBody defines body for PostExpensesImport for multipart/form-data ContentType.
+type PostExpensesImportMultipartRequestBody PostExpensesImportMultipartBody
+
 // PutExpensesIdJSONRequestBody defines body for PutExpensesId for application/json ContentType.
 type PutExpensesIdJSONRequestBody = ExpenseRequest
 
+// PostHouseholdInvitationsJSONRequestBody defines body for PostHouseholdInvitations for application/json ContentType.
+type PostHouseholdInvitationsJSONRequestBody = InvitationRequest
+
+// PostHouseholdLeaveJSONRequestBody defines body for PostHouseholdLeave for application/json ContentType.
+type PostHouseholdLeaveJSONRequestBody = HouseholdLeaveRequest
+
+// PutHouseholdMembersUserIdRoleJSONRequestBody defines body for PutHouseholdMembersUserIdRole for application/json ContentType.
+type PutHouseholdMembersUserIdRoleJSONRequestBody = HouseholdRoleRequest
+
+// PutHouseholdOwnerJSONRequestBody defines body for PutHouseholdOwner for application/json ContentType.
+type PutHouseholdOwnerJSONRequestBody = HouseholdOwnerRequest
+
+// PutHouseholdSettingsJSONRequestBody defines body for PutHouseholdSettings for application/json ContentType.
+type PutHouseholdSettingsJSONRequestBody = HouseholdSettings
+
+// PutHouseholdSplitJSONRequestBody defines body for PutHouseholdSplit for application/json ContentType.
+type PutHouseholdSplitJSONRequestBody = SplitRule
+
+// PostIncomesJSONRequestBody defines body for PostIncomes for application/json ContentType.
+type PostIncomesJSONRequestBody = IncomeRequest
+
+// PutIncomesIdJSONRequestBody defines body for PutIncomesId for application/json ContentType.
+type PutIncomesIdJSONRequestBody = IncomeRequest
+
+// PostLoginJSONRequestBody defines body for PostLogin for application/json ContentType.
+type PostLoginJSONRequestBody = SignUpRequest
+
+// PostLogin2faJSONRequestBody defines body for PostLogin2fa for application/json ContentType.
+type PostLogin2faJSONRequestBody = TwoFactorLoginRequest
+
+// PostPasskeysLoginFinishJSONRequestBody defines body for PostPasskeysLoginFinish for application/json ContentType.
+type PostPasskeysLoginFinishJSONRequestBody = PasskeyAssertionRequest
+
+// PostPasswordForgotJSONRequestBody defines body for PostPasswordForgot for application/json ContentType.
+type PostPasswordForgotJSONRequestBody = PasswordForgotRequest
+
+// PostPasswordResetJSONRequestBody defines body for PostPasswordReset for application/json ContentType.
+type PostPasswordResetJSONRequestBody = PasswordResetRequest
+
+// PostRecurringExpensesJSONRequestBody defines body for PostRecurringExpenses for application/json ContentType.
+type PostRecurringExpensesJSONRequestBody = RecurringExpenseRequest
+
+// PutRecurringExpensesIdJSONRequestBody defines body for PutRecurringExpensesId for application/json ContentType.
+type PutRecurringExpensesIdJSONRequestBody = RecurringExpenseRequest
+
 // PostSignupJSONRequestBody defines body for PostSignup for application/json ContentType.
 type PostSignupJSONRequestBody = SignUpRequest
 
 // PutUserJSONRequestBody defines body for PutUser for application/json ContentType.
 type PutUserJSONRequestBody = UserUpdate
+
+// PostUser2faRecoveryCodesJSONRequestBody defines body for PostUser2faRecoveryCodes for application/json ContentType.
+type PostUser2faRecoveryCodesJSONRequestBody = TOTPCodeRequest
+
+// PostUser2faTotpConfirmJSONRequestBody defines body for PostUser2faTotpConfirm for application/json ContentType.
+type PostUser2faTotpConfirmJSONRequestBody = TOTPCodeRequest
+
+// PostUser2faTotpDisableJSONRequestBody defines body for PostUser2faTotpDisable for application/json ContentType.
+type PostUser2faTotpDisableJSONRequestBody = TOTPDisableRequest
+
+// PostUserPasskeysRegisterFinishJSONRequestBody defines body for PostUserPasskeysRegisterFinish for application/json ContentType.
+type PostUserPasskeysRegisterFinishJSONRequestBody = PasskeyRegistrationRequest
+
+// PostUserPasswordJSONRequestBody defines body for PostUserPassword for application/json ContentType.
+type PostUserPasswordJSONRequestBody = PasswordSetupRequest
+
+// PutUserPasswordJSONRequestBody defines body for PutUserPassword for application/json ContentType.
+type PutUserPasswordJSONRequestBody = PasswordChangeRequest
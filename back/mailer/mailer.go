@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message は送信するメールです。
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer はメール送信の抽象です。SMTPやSESなどの送信手段はこのインターフェースを実装します。
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LocalMailer はメールを送信せずにファイルまたはログへ書き出すMailerです。
+// SMTPサーバーの無い開発環境での動作確認に使用します。
+type LocalMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLogMailer はメールを標準のログへ書き出すMailerを生成します。
+func NewLogMailer() *LocalMailer {
+	return &LocalMailer{w: log.Writer()}
+}
+
+// NewFileMailer はメールを指定したファイルへ追記するMailerを生成します。
+func NewFileMailer(path string) (*LocalMailer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mail file: %w", err)
+	}
+	return &LocalMailer{w: f}, nil
+}
+
+func (m *LocalMailer) Send(ctx context.Context, msg Message) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "----- mail %s -----\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "To: %s\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\n\n", msg.Subject)
+	b.WriteString(msg.Body)
+	if !strings.HasSuffix(msg.Body, "\n") {
+		b.WriteString("\n")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, err := io.WriteString(m.w, b.String()); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+	return nil
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -13,42 +19,237 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/yanatoritakuma/budget/back/controller"
 	"github.com/yanatoritakuma/budget/back/db"
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/domain/passkey"
+	"github.com/yanatoritakuma/budget/back/mailer"
+	"github.com/yanatoritakuma/budget/back/model"
+	"github.com/yanatoritakuma/budget/back/oidc"
 	"github.com/yanatoritakuma/budget/back/repository"
 	"github.com/yanatoritakuma/budget/back/router"
 	"github.com/yanatoritakuma/budget/back/usecase"
+	"gorm.io/gorm"
 )
 
 var ginLambda *ginadapter.GinLambdaV2
+var recurringExpenseUsecase usecase.RecurringExpenseUsecase
 
-// setupRouter initializes the database, repositories, usecases, controllers and router.
-func setupRouter() *gin.Engine {
-	dbInstance := db.NewDB()
-
+// setupRouter initializes the repositories, usecases, controllers and router.
+func setupRouter(dbInstance *gorm.DB) *gin.Engine {
 	// Repositories
 	userRepoImpl := repository.NewUserRepositoryImpl(dbInstance)
 	householdRepoImpl := repository.NewHouseholdRepositoryImpl(dbInstance)
+	householdMemberRepository := repository.NewHouseholdMemberRepositoryImpl(dbInstance)
+	householdInvitationRepository := repository.NewHouseholdInvitationRepositoryImpl(dbInstance)
 	expenseRepository := repository.NewExpenseRepositoryImpl(dbInstance)
+	budgetRepository := repository.NewBudgetRepositoryImpl(dbInstance)
+	recurringExpenseRepository := repository.NewRecurringExpenseRepositoryImpl(dbInstance)
+	incomeRepository := repository.NewIncomeRepositoryImpl(dbInstance)
+	categoryRepository := repository.NewCategoryRepositoryImpl(dbInstance)
+	sessionRepository := repository.NewSessionRepositoryImpl(dbInstance)
+	passwordResetTokenRepository := repository.NewPasswordResetTokenRepositoryImpl(dbInstance)
+	mfaRepository := repository.NewMFARepositoryImpl(dbInstance, []byte(os.Getenv("SECRET")))
+	passkeyRepository := repository.NewPasskeyRepositoryImpl(dbInstance)
+	identityRepository := repository.NewIdentityRepositoryImpl(dbInstance)
 	uow := repository.NewUnitOfWork(dbInstance)
+	tokenStore, err := newTokenStore(dbInstance)
+	if err != nil {
+		log.Fatalf("failed to create token store: %v", err)
+	}
+	attemptStore, err := newAttemptStore(dbInstance)
+	if err != nil {
+		log.Fatalf("failed to create attempt store: %v", err)
+	}
+	mail, err := newMailer()
+	if err != nil {
+		log.Fatalf("failed to create mailer: %v", err)
+	}
+	// EMAIL_VERIFICATION_REQUIRED_FOR はメールアドレスの確認を必須にする機能のカンマ区切りです（none で制限なし）
+	restrictedFeatures, err := usecase.ParseRestrictedFeatures(os.Getenv("EMAIL_VERIFICATION_REQUIRED_FOR"))
+	if err != nil {
+		log.Fatalf("failed to parse EMAIL_VERIFICATION_REQUIRED_FOR: %v", err)
+	}
 
 	// Usecases
-	expenseUsecase := usecase.NewExpenseUsecase(expenseRepository, userRepoImpl)
-	userUsecase := usecase.NewUserUsecase(userRepoImpl, householdRepoImpl, uow)
+	expenseUsecase := usecase.NewExpenseUsecase(expenseRepository, userRepoImpl, categoryRepository, householdMemberRepository, householdRepoImpl)
+	sessionUsecase := usecase.NewSessionUsecase(sessionRepository)
+	emailVerificationUsecase := usecase.NewEmailVerificationUsecase(userRepoImpl, mail, restrictedFeatures)
+	loginThrottle := usecase.NewLoginThrottle(attemptStore)
+	userUsecase := usecase.NewUserUsecase(userRepoImpl, householdRepoImpl, uow, tokenStore, emailVerificationUsecase, loginThrottle)
+	budgetUsecase := usecase.NewBudgetUsecase(budgetRepository, expenseRepository, userRepoImpl, householdRepoImpl, householdMemberRepository)
+	recurringExpenseUsecase = usecase.NewRecurringExpenseUsecase(recurringExpenseRepository, userRepoImpl, householdRepoImpl, householdMemberRepository, uow)
+	incomeUsecase := usecase.NewIncomeUsecase(incomeRepository, expenseRepository, userRepoImpl, householdRepoImpl, householdMemberRepository)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepository, userRepoImpl, householdMemberRepository, uow)
+	expenseImportUsecase := usecase.NewExpenseImportUsecase(userRepoImpl, categoryRepository, householdMemberRepository, uow)
+	expenseExportUsecase := usecase.NewExpenseExportUsecase(expenseRepository, userRepoImpl, householdRepoImpl)
+	mfaUsecase := usecase.NewMFAUsecase(userRepoImpl, mfaRepository, attemptStore)
+	passkeyUsecase := usecase.NewPasskeyUsecase(userRepoImpl, passkeyRepository, identityRepository, tokenStore, newRelyingParty())
+	oidcLoginUsecase := usecase.NewOIDCLoginUsecase(newOIDCRegistry(), identityRepository, userRepoImpl, userUsecase)
+	passwordUsecase := usecase.NewPasswordUsecase(userRepoImpl, sessionRepository, passwordResetTokenRepository, mail, emailVerificationUsecase)
+	identityUsecase := usecase.NewIdentityUsecase(userRepoImpl, identityRepository, passkeyRepository)
 
 	// Controllers
 	expenseController := controller.NewExpenseController(expenseUsecase)
+	expenseImportController := controller.NewExpenseImportController(expenseImportUsecase)
+	expenseExportController := controller.NewExpenseExportController(expenseExportUsecase)
+	budgetController := controller.NewBudgetController(budgetUsecase)
+	recurringExpenseController := controller.NewRecurringExpenseController(recurringExpenseUsecase)
+	incomeController := controller.NewIncomeController(incomeUsecase)
+	categoryController := controller.NewCategoryController(categoryUsecase)
+	passwordController := controller.NewPasswordController(passwordUsecase)
+	emailVerificationController := controller.NewEmailVerificationController(emailVerificationUsecase)
+	mfaController := controller.NewMFAController(mfaUsecase, sessionUsecase)
+	passkeyController := controller.NewPasskeyController(passkeyUsecase, sessionUsecase)
+	oidcLoginController := controller.NewOIDCLoginController(oidcLoginUsecase, sessionUsecase, mfaUsecase, tokenStore)
+	identityController := controller.NewIdentityController(identityUsecase)
 
 	// New router signature
-	return router.NewRouter(dbInstance, expenseController, userRepoImpl, householdRepoImpl, uow, userUsecase)
+	return router.NewRouter(dbInstance, expenseController, expenseImportController, expenseExportController, budgetController, recurringExpenseController, incomeController, categoryController, passwordController, emailVerificationController, mfaController, passkeyController, oidcLoginController, identityController, userRepoImpl, householdRepoImpl, householdMemberRepository, householdInvitationRepository, expenseRepository, uow, userUsecase, sessionUsecase, tokenStore, emailVerificationUsecase, mfaUsecase, attemptStore)
+}
+
+// newTokenStore は TOKEN_STORE 環境変数で指定されたCSRFトークン・OAuth stateのストアを生成します。
+// postgres（既定）はDBに保存し、signed は署名付きトークンで状態を持たず、memory はプロセス内に保存します。
+func newTokenStore(dbInstance *gorm.DB) (model.TokenStore, error) {
+	switch os.Getenv("TOKEN_STORE") {
+	case "", "postgres":
+		return repository.NewPostgresTokenStore(dbInstance), nil
+	case "signed":
+		return model.NewSignedTokenStore([]byte(os.Getenv("SECRET")))
+	case "memory":
+		return model.NewMemoryTokenStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported TOKEN_STORE: %s", os.Getenv("TOKEN_STORE"))
+	}
+}
+
+// newAttemptStore は RATE_LIMIT_STORE 環境変数で指定されたログイン試行・リクエスト数のストアを生成します。
+// postgres（既定）はDBに保存してインスタンス間で共有し、memory はプロセス内に保存します。
+func newAttemptStore(dbInstance *gorm.DB) (model.AttemptStore, error) {
+	switch os.Getenv("RATE_LIMIT_STORE") {
+	case "", "postgres":
+		return repository.NewPostgresAttemptStore(dbInstance), nil
+	case "memory":
+		return model.NewMemoryAttemptStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported RATE_LIMIT_STORE: %s", os.Getenv("RATE_LIMIT_STORE"))
+	}
+}
+
+// newMailer は MAILER 環境変数で指定されたメール送信手段を生成します。
+// log（既定）はログへ、file は MAIL_FILE_PATH のファイルへメールを書き出します。
+func newMailer() (mailer.Mailer, error) {
+	switch os.Getenv("MAILER") {
+	case "", "log":
+		return mailer.NewLogMailer(), nil
+	case "file":
+		return mailer.NewFileMailer(os.Getenv("MAIL_FILE_PATH"))
+	default:
+		return nil, fmt.Errorf("unsupported MAILER: %s", os.Getenv("MAILER"))
+	}
+}
+
+// newRelyingParty はWebAuthnのRP（このサービス）の設定を環境変数から生成します。
+// RP ID はフロントエンドのホスト名、許可するオリジンは FE_URL を既定とします。
+func newRelyingParty() passkey.RelyingParty {
+	rp := passkey.RelyingParty{
+		ID:   os.Getenv("WEBAUTHN_RP_ID"),
+		Name: os.Getenv("WEBAUTHN_RP_NAME"),
+	}
+	if rp.ID == "" {
+		if feURL, err := url.Parse(os.Getenv("FE_URL")); err == nil {
+			rp.ID = feURL.Hostname()
+		}
+	}
+	if rp.Name == "" {
+		rp.Name = "Budget"
+	}
+	origins := os.Getenv("WEBAUTHN_ORIGINS")
+	if origins == "" {
+		origins = os.Getenv("FE_URL")
+	}
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			rp.Origins = append(rp.Origins, origin)
+		}
+	}
+	return rp
 }
 
-func Handler(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+// newOIDCRegistry は OIDC_PROVIDERS 環境変数で指定されたIDプロバイダ（既定は line）を生成します。
+// 各プロバイダは OIDC_<NAME>_ISSUER・_CLIENT_ID・_CLIENT_SECRET・_REDIRECT_URI・_SCOPES で設定し、
+// LINEは従来の LINE_CHANNEL_ID・LINE_CHANNEL_SECRET・LINE_REDIRECT_URI も使用できます。
+func newOIDCRegistry() *oidc.Registry {
+	names := os.Getenv("OIDC_PROVIDERS")
+	if names == "" {
+		names = identity.ProviderLINE
+	}
+
+	// IDプロバイダの応答が遅い場合にリクエストを待たせ続けないようにする
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+
+	var providers []*oidc.Provider
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URI"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+			HTTPClient:   httpClient,
+		}
+		if config.Issuer == "" {
+			config.Issuer = oidc.WellKnownIssuers[name]
+		}
+		if name == identity.ProviderLINE {
+			config.ClientID = cmp.Or(config.ClientID, os.Getenv("LINE_CHANNEL_ID"))
+			config.ClientSecret = cmp.Or(config.ClientSecret, os.Getenv("LINE_CHANNEL_SECRET"))
+			config.RedirectURL = cmp.Or(config.RedirectURL, os.Getenv("LINE_REDIRECT_URI"))
+		}
+		if config.Issuer == "" {
+			log.Fatalf("%sISSUER is required", prefix)
+		}
+		providers = append(providers, oidc.NewProvider(config))
+	}
+	return oidc.NewRegistry(providers...)
+}
+
+// Handler はAPI GatewayからのリクエストとEventBridgeのスケジュールイベントを振り分けます。
+func Handler(ctx context.Context, payload json.RawMessage) (any, error) {
+	var scheduledEvent events.EventBridgeEvent
+	if err := json.Unmarshal(payload, &scheduledEvent); err == nil && scheduledEvent.Source == "aws.events" {
+		return nil, generateRecurringExpenses(ctx, scheduledEvent.Time)
+	}
+
+	var req events.APIGatewayV2HTTPRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, err
+	}
 	req.RawPath = strings.TrimPrefix(req.RawPath, "/prod")
 
 	return ginLambda.ProxyWithContext(ctx, req)
 }
 
+// generateRecurringExpenses は定期支出から期日を迎えた支出を生成します。
+func generateRecurringExpenses(ctx context.Context, asOf time.Time) error {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	// 日付の判定は世帯ごとのタイムゾーンで行う
+	created, err := recurringExpenseUsecase.GenerateDueExpenses(ctx, asOf)
+	if err != nil {
+		return err
+	}
+	log.Printf("generated %d recurring expenses as of %s", created, asOf.Format(time.RFC3339))
+	return nil
+}
+
 func main() {
-	r := setupRouter()
+	r := setupRouter(db.NewDB())
 
 	if _, ok := os.LookupEnv("LAMBDA_TASK_ROOT"); ok {
 		ginLambda = ginadapter.NewV2(r)
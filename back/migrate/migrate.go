@@ -1,19 +1,211 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/yanatoritakuma/budget/back/db"
+	"github.com/yanatoritakuma/budget/back/domain/category"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/identity"
 	"github.com/yanatoritakuma/budget/back/model"
+	"github.com/yanatoritakuma/budget/back/repository"
+	"gorm.io/gorm"
 )
 
 func main() {
 	dbConn := db.NewDB()
 	defer fmt.Println("Successfully Migrated")
 	defer db.CloseDB(dbConn)
+	// メールアドレス確認の導入前に登録されたユーザーは確認済みとして扱う
+	backfillEmailVerified := !dbConn.Migrator().HasColumn(&model.User{}, "EmailVerifiedAt")
+	if err := backfillExpenseHouseholds(dbConn); err != nil {
+		log.Fatalf("failed to backfill expense households: %v", err)
+	}
 	dbConn.AutoMigrate(
 		&model.Household{},
+		&model.HouseholdSplitShare{},
 		&model.User{},
 		&model.Expense{},
+		&model.ExpenseShare{},
+		&model.Budget{},
+		&model.RecurringExpense{},
+		&model.Income{},
+		&model.Category{},
+		&model.TokenRecord{},
+		&model.Session{},
+		&model.PasswordResetToken{},
+		&model.TOTPCredential{},
+		&model.RecoveryCode{},
+		&model.PasskeyCredential{},
+		&model.UserIdentity{},
+		&model.AttemptCounter{},
+		&model.HouseholdMember{},
+		&model.HouseholdInvitation{},
+		&model.HouseholdInvitationRedemption{},
 	)
+
+	if err := backfillCategories(dbConn); err != nil {
+		log.Fatalf("failed to backfill categories: %v", err)
+	}
+
+	if err := migrateLineUserIDs(dbConn); err != nil {
+		log.Fatalf("failed to migrate LINE user IDs: %v", err)
+	}
+
+	if err := clearIdentityUserPasswords(dbConn); err != nil {
+		log.Fatalf("failed to clear passwords of identity users: %v", err)
+	}
+
+	if err := backfillHouseholdMembers(dbConn); err != nil {
+		log.Fatalf("failed to backfill household members: %v", err)
+	}
+
+	if err := migrateInviteCodes(dbConn); err != nil {
+		log.Fatalf("failed to migrate invite codes: %v", err)
+	}
+
+	if backfillEmailVerified {
+		if err := dbConn.Exec(`UPDATE "user" SET email_verified_at = created_at WHERE email IS NOT NULL`).Error; err != nil {
+			log.Fatalf("failed to backfill email verification: %v", err)
+		}
+	}
+}
+
+// backfillExpenseHouseholds は登録者の世帯から求めていた支出の世帯を支出に保存します。
+// 列に NOT NULL 制約を付ける前に値を埋め、ユーザーの削除で支出が削除されないよう外部キーを作り直します。
+func backfillExpenseHouseholds(dbConn *gorm.DB) error {
+	migrator := dbConn.Migrator()
+	if !migrator.HasTable(&model.Expense{}) || migrator.HasColumn(&model.Expense{}, "household_id") {
+		return nil
+	}
+	return dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`ALTER TABLE expenses ADD COLUMN household_id bigint`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE expenses e SET household_id = u.household_id
+			FROM "user" u
+			WHERE u.id = e.user_id`).Error; err != nil {
+			return err
+		}
+		if tx.Migrator().HasConstraint(&model.Expense{}, "User") {
+			return tx.Migrator().DropConstraint(&model.Expense{}, "User")
+		}
+		return nil
+	})
+}
+
+// migrateLineUserIDs はユーザーに保存していたLINEのユーザーIDを外部IDプロバイダの紐付けに移し、列を削除します。
+func migrateLineUserIDs(dbConn *gorm.DB) error {
+	if !dbConn.Migrator().HasColumn(&model.User{}, "line_user_id") {
+		return nil
+	}
+	return dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO user_identities (user_id, provider, subject, created_at)
+			SELECT id, ?, line_user_id, NOW()
+			FROM "user"
+			WHERE line_user_id IS NOT NULL AND line_user_id <> ''
+			ON CONFLICT (provider, subject) DO NOTHING`, identity.ProviderLINE).Error; err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&model.User{}, "line_user_id")
+	})
+}
+
+// clearIdentityUserPasswords は外部IDプロバイダから作成したユーザーに設定していた仮のパスワードを削除します。
+// メールアドレスの無いユーザーはパスワードでログインできないため、パスワードを持たないものとして扱います。
+func clearIdentityUserPasswords(dbConn *gorm.DB) error {
+	return dbConn.Exec(`
+		UPDATE "user" SET password = ''
+		WHERE email IS NULL AND password <> ''
+			AND EXISTS (SELECT 1 FROM user_identities i WHERE i.user_id = "user".id)`).Error
+}
+
+// backfillHouseholdMembers は役割の導入前から世帯に所属するユーザーを世帯のメンバーとして登録します。
+// 所有者のいない世帯では最初に登録したユーザーを所有者とし、それ以外のユーザーは一般メンバーとします。
+func backfillHouseholdMembers(dbConn *gorm.DB) error {
+	return dbConn.Exec(`
+		INSERT INTO household_members (household_id, user_id, role, joined_at)
+		SELECT u.household_id, u.id,
+			CASE WHEN NOT EXISTS (
+					SELECT 1 FROM household_members m WHERE m.household_id = u.household_id AND m.role = ?
+				) AND ROW_NUMBER() OVER (PARTITION BY u.household_id ORDER BY u.created_at, u.id) = 1
+				THEN ? ELSE ? END,
+			u.created_at
+		FROM "user" u
+		WHERE NOT EXISTS (SELECT 1 FROM household_members m WHERE m.user_id = u.id)`,
+		household.RoleOwner.Value(), household.RoleOwner.Value(), household.RoleMember.Value()).Error
+}
+
+// migrateInviteCodes は世帯に保存していた招待コードを招待に移し、列を削除します。
+// 期限の無かった招待コードは、移行時から既定の有効期間だけ利用できるようにします。
+func migrateInviteCodes(dbConn *gorm.DB) error {
+	if !dbConn.Migrator().HasColumn(&model.Household{}, "invite_code") {
+		return nil
+	}
+	return dbConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO household_invitations (household_id, code, created_by_id, role, max_uses, uses, expires_at, created_at)
+			SELECT h.id, h.invite_code,
+				(SELECT m.user_id FROM household_members m WHERE m.household_id = h.id AND m.role = ?),
+				?, 0, 0, ?, h.updated_at
+			FROM households h
+			WHERE h.invite_code IS NOT NULL AND h.invite_code <> ''
+			ON CONFLICT (code) DO NOTHING`,
+			household.RoleOwner.Value(), household.RoleMember.Value(), time.Now().Add(household.DefaultInvitationTTL)).Error; err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&model.Household{}, "invite_code")
+	})
+}
+
+// backfillCategories は既存の世帯に初期カテゴリを登録し、
+// 支出に自由入力されていたカテゴリ名をカテゴリに変換して紐付けます。
+func backfillCategories(dbConn *gorm.DB) error {
+	return dbConn.Transaction(func(tx *gorm.DB) error {
+		var householdIDs []uint
+		if err := tx.Model(&model.Household{}).
+			Where("NOT EXISTS (SELECT 1 FROM categories WHERE categories.household_id = households.id)").
+			Pluck("id", &householdIDs).Error; err != nil {
+			return err
+		}
+
+		categoryRepository := repository.NewCategoryRepositoryImpl(tx)
+		for _, householdID := range householdIDs {
+			defaults, err := category.NewDefaultCategories(householdID)
+			if err != nil {
+				return err
+			}
+			for _, c := range defaults {
+				if err := categoryRepository.Create(context.Background(), c); err != nil {
+					return err
+				}
+			}
+		}
+
+		// 前後の空白（全角空白を含む）による表記揺れは同じカテゴリとして扱う
+		if err := tx.Exec(`
+			INSERT INTO categories (household_id, name, color, icon, sort_order, archived, created_at, updated_at)
+			SELECT e.household_id, BTRIM(e.category, ' 　'), '', '',
+				100 + ROW_NUMBER() OVER (PARTITION BY e.household_id ORDER BY BTRIM(e.category, ' 　')),
+				false, NOW(), NOW()
+			FROM expenses e
+			WHERE e.category_id IS NULL AND BTRIM(e.category, ' 　') <> ''
+			GROUP BY e.household_id, BTRIM(e.category, ' 　')
+			ON CONFLICT (household_id, name) DO NOTHING`).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			UPDATE expenses e
+			SET category_id = c.id, category = c.name
+			FROM categories c
+			WHERE c.household_id = e.household_id
+				AND c.name = BTRIM(e.category, ' 　')
+				AND e.category_id IS NULL`).Error
+	})
 }
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// AttemptCounter はPostgreSQLに保存するログイン試行・リクエスト数の記録です。
+type AttemptCounter struct {
+	Key          string     `json:"key" gorm:"primaryKey"`
+	Count        int        `json:"count" gorm:"not null"`
+	WindowEndsAt time.Time  `json:"window_ends_at" gorm:"not null;index"`
+	LockedUntil  *time.Time `json:"locked_until"`
+}
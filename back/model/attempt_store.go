@@ -0,0 +1,102 @@
+package model
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AttemptRecord はキーごとの試行回数とロックの状態です。
+type AttemptRecord struct {
+	Count        int
+	WindowEndsAt time.Time
+	LockedUntil  time.Time
+}
+
+// IsLocked は指定時刻にロック中かどうかを返します。
+func (r AttemptRecord) IsLocked(now time.Time) bool {
+	return now.Before(r.LockedUntil)
+}
+
+// AttemptStore はログイン試行やリクエスト数をキーごとに数えるストアです。
+// 回数は固定ウィンドウで数え、ウィンドウが終了すると1から数え直します。
+type AttemptStore interface {
+	// Get はキーの記録を返します。ウィンドウとロックがどちらも終了した記録は空の記録を返します。
+	Get(ctx context.Context, key string) (AttemptRecord, error)
+	// Increment はキーの回数を1増やし、増加後の記録を返します。
+	Increment(ctx context.Context, key string, window time.Duration) (AttemptRecord, error)
+	// Lock はキーを指定時刻までロックします。ウィンドウはロックの終了まで延長されます。
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset はキーの記録を削除します。
+	Reset(ctx context.Context, key string) error
+}
+
+// MemoryAttemptStore はプロセス内のメモリに試行回数を保存するストアです。
+// インスタンス間で共有されないため、テストや単一プロセスでの実行に使用します。
+type MemoryAttemptStore struct {
+	records map[string]AttemptRecord
+	mutex   sync.Mutex
+}
+
+var _ AttemptStore = (*MemoryAttemptStore)(nil)
+
+// NewMemoryAttemptStore は新しいMemoryAttemptStoreを作成します
+func NewMemoryAttemptStore() *MemoryAttemptStore {
+	return &MemoryAttemptStore{
+		records: make(map[string]AttemptRecord),
+	}
+}
+
+func (s *MemoryAttemptStore) Get(ctx context.Context, key string) (AttemptRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.current(key, time.Now()), nil
+}
+
+func (s *MemoryAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (AttemptRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	record := s.current(key, now)
+	if !now.Before(record.WindowEndsAt) {
+		record.Count = 0
+		record.WindowEndsAt = now.Add(window)
+	}
+	record.Count++
+	s.records[key] = record
+	return record, nil
+}
+
+func (s *MemoryAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record := s.current(key, time.Now())
+	record.LockedUntil = until
+	if record.WindowEndsAt.Before(until) {
+		record.WindowEndsAt = until
+	}
+	s.records[key] = record
+	return nil
+}
+
+func (s *MemoryAttemptStore) Reset(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// current は有効な記録を返し、終了した記録は削除します。呼び出し側でロックを取得してください。
+func (s *MemoryAttemptStore) current(key string, now time.Time) AttemptRecord {
+	record, exists := s.records[key]
+	if !exists {
+		return AttemptRecord{}
+	}
+	if !now.Before(record.WindowEndsAt) && !record.IsLocked(now) {
+		delete(s.records, key)
+		return AttemptRecord{}
+	}
+	return record
+}
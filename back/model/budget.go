@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type Budget struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	HouseholdID uint      `json:"household_id" gorm:"not null;uniqueIndex:idx_budget_household_category_month"`
+	Household   Household `json:"household" gorm:"foreignKey:HouseholdID;references:ID;constraint:OnDelete:CASCADE"`
+	Category    string    `json:"category" gorm:"not null;uniqueIndex:idx_budget_household_category_month"`
+	Year        int       `json:"year" gorm:"not null;uniqueIndex:idx_budget_household_category_month"`
+	Month       int       `json:"month" gorm:"not null;uniqueIndex:idx_budget_household_category_month"`
+	LimitAmount int       `json:"limit_amount" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
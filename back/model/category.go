@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type Category struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	HouseholdID uint       `json:"household_id" gorm:"not null;uniqueIndex:idx_category_household_name"`
+	Household   Household  `json:"household" gorm:"foreignKey:HouseholdID;references:ID;constraint:OnDelete:CASCADE"`
+	Name        string     `json:"name" gorm:"not null;uniqueIndex:idx_category_household_name"`
+	Color       string     `json:"color"`
+	Icon        string     `json:"icon"`
+	SortOrder   int        `json:"sort_order" gorm:"not null;default:0"`
+	Archived    bool       `json:"archived" gorm:"not null;default:false"`
+	ParentID    *uint      `json:"parent_id" gorm:"index"`
+	Children    []Category `json:"children" gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL"`
+	// カテゴリが削除されても支出は残し、参照のみ外す
+	Expenses  []Expense `json:"expenses" gorm:"foreignKey:CategoryID;constraint:OnDelete:SET NULL"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
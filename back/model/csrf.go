@@ -1,81 +1,99 @@
 package model
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"sync"
 	"time"
 )
 
+// TokenStore はCSRFトークンやOAuthのstateをセッションIDごとに発行・検証するストアです。
+type TokenStore interface {
+	// IssueToken はセッションIDに紐づく新しいトークンを発行します。
+	IssueToken(ctx context.Context, sessionID string, ttl time.Duration) (string, error)
+	// GetToken は発行済みで有効なトークンを返します。保持しないストアは常に false を返します。
+	GetToken(ctx context.Context, sessionID string) (string, bool, error)
+	// ValidateToken はトークンがセッションIDに対して発行された有効なものか検証します。
+	ValidateToken(ctx context.Context, sessionID, token string) (bool, error)
+	// DeleteToken はセッションIDのトークンを無効にします。
+	DeleteToken(ctx context.Context, sessionID string) error
+}
+
 // CSRFToken はトークンと有効期限を管理する構造体
 type CSRFToken struct {
 	Token     string
 	ExpiresAt time.Time
 }
 
-// TokenStore はCSRFトークンを保存するためのストア
-type TokenStore struct {
+// MemoryTokenStore はプロセス内のメモリにトークンを保存するストアです。
+// インスタンス間で共有されないため、テストや単一プロセスでの実行に使用します。
+type MemoryTokenStore struct {
 	tokens map[string]CSRFToken
 	mutex  sync.RWMutex
 }
 
-// NewTokenStore は新しいTokenStoreを作成します
-func NewTokenStore() *TokenStore {
-	return &TokenStore{
+var _ TokenStore = (*MemoryTokenStore)(nil)
+
+// NewMemoryTokenStore は新しいMemoryTokenStoreを作成します
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
 		tokens: make(map[string]CSRFToken),
 	}
 }
 
-// SaveToken はトークンを保存します
-func (s *TokenStore) SaveToken(sessionID string, token CSRFToken) {
+// IssueToken はトークンを生成して保存します
+func (s *MemoryTokenStore) IssueToken(ctx context.Context, sessionID string, ttl time.Duration) (string, error) {
+	token, err := GenerateTokenValue()
+	if err != nil {
+		return "", err
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.tokens[sessionID] = token
+	s.tokens[sessionID] = CSRFToken{Token: token, ExpiresAt: time.Now().Add(ttl)}
+	return token, nil
 }
 
 // ValidateToken はトークンを検証します
-func (s *TokenStore) ValidateToken(sessionID, token string) bool {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
-	storedToken, exists := s.tokens[sessionID]
-	if !exists {
-		return false
+func (s *MemoryTokenStore) ValidateToken(ctx context.Context, sessionID, token string) (bool, error) {
+	storedToken, exists, err := s.GetToken(ctx, sessionID)
+	if err != nil || !exists {
+		return false, err
 	}
-
-	// 有効期限チェック
-	if time.Now().After(storedToken.ExpiresAt) {
-		delete(s.tokens, sessionID)
-		return false
-	}
-
-	return storedToken.Token == token
+	return subtle.ConstantTimeCompare([]byte(storedToken), []byte(token)) == 1, nil
 }
 
 // GetToken は保存されているトークンを取得します
-func (s *TokenStore) GetToken(sessionID string) (string, bool) {
+func (s *MemoryTokenStore) GetToken(ctx context.Context, sessionID string) (string, bool, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	storedToken, exists := s.tokens[sessionID]
 	if !exists {
-		return "", false
+		return "", false, nil
 	}
 
 	// 有効期限チェック
 	if time.Now().After(storedToken.ExpiresAt) {
 		delete(s.tokens, sessionID)
-		return "", false
+		return "", false, nil
 	}
 
-	return storedToken.Token, true
+	return storedToken.Token, true, nil
 }
 
 // DeleteToken は指定されたセッションIDのトークンを削除します
-func (s *TokenStore) DeleteToken(sessionID string) {
+func (s *MemoryTokenStore) DeleteToken(ctx context.Context, sessionID string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	delete(s.tokens, sessionID)
-} // CleanupExpiredTokens は期限切れのトークンを削除します
-func (s *TokenStore) CleanupExpiredTokens() {
+	return nil
+}
+
+// CleanupExpiredTokens は期限切れのトークンを削除します
+func (s *MemoryTokenStore) CleanupExpiredTokens() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -86,3 +104,12 @@ func (s *TokenStore) CleanupExpiredTokens() {
 		}
 	}
 }
+
+// GenerateTokenValue は推測できないランダムなトークン文字列を生成します。
+func GenerateTokenValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
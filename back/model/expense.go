@@ -3,15 +3,31 @@ package model
 import "time"
 
 type Expense struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Amount    int       `json:"amount" gorm:"not null"`
-	StoreName string    `json:"store_name" gorm:"not null"`
-	Date      time.Time `json:"date" gorm:"not null"`
-	Category  string    `json:"category" gorm:"not null"`
-	Memo      string    `json:"memo"`
-	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-	UserID    uint      `json:"user_id" gorm:"not null"`
-	User      User      `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
-	PayerID   uint      `json:"payer_id" gorm:"not null"`
+	ID uint `json:"id" gorm:"primaryKey"`
+	// HouseholdID は支出が属する世帯です。登録者が世帯を移っても変わりません。
+	HouseholdID uint      `json:"household_id" gorm:"not null;index"`
+	Household   Household `json:"household" gorm:"foreignKey:HouseholdID;references:ID;constraint:OnDelete:CASCADE"`
+	Amount      int       `json:"amount" gorm:"not null"`
+	StoreName   string    `json:"store_name" gorm:"not null"`
+	Date        time.Time `json:"date" gorm:"not null;index;uniqueIndex:idx_expense_recurring_date,priority:2"`
+	Category    string    `json:"category" gorm:"not null"`
+	// CategoryID は世帯のカテゴリへの参照です。Category はカテゴリ名の非正規化コピーです。
+	CategoryID *uint     `json:"category_id" gorm:"index"`
+	Memo       string    `json:"memo"`
+	CreatedAt  time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	// UserID は登録者です。登録者が退会しても世帯の記録として残すため、参照のみ外します。
+	UserID  *uint          `json:"user_id"`
+	User    *User          `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL"`
+	PayerID uint           `json:"payer_id" gorm:"not null"`
+	Shares  []ExpenseShare `json:"shares" gorm:"foreignKey:ExpenseID;constraint:OnDelete:CASCADE"`
+	// 同じ定期支出から同じ日付の支出が重複して生成されないよう一意制約を設定
+	RecurringExpenseID *uint `json:"recurring_expense_id" gorm:"uniqueIndex:idx_expense_recurring_date,priority:1"`
+}
+
+type ExpenseShare struct {
+	ID        uint `json:"id" gorm:"primaryKey"`
+	ExpenseID uint `json:"expense_id" gorm:"not null;index"`
+	UserID    uint `json:"user_id" gorm:"not null"`
+	Amount    int  `json:"amount" gorm:"not null"`
 }
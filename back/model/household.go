@@ -5,10 +5,23 @@ import (
 )
 
 type Household struct {
-	ID         uint      `json:"id" gorm:"primaryKey"`
-	Name       string    `json:"name" gorm:"not null"`
-	InviteCode string    `json:"invite_code" gorm:"unique"`
-	Users      []User    `json:"users"` // A household has many users
-	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
-}
\ No newline at end of file
+	ID          uint                  `json:"id" gorm:"primaryKey"`
+	Name        string                `json:"name" gorm:"not null"`
+	SplitMethod string                `json:"split_method" gorm:"not null;default:equal"`
+	SplitShares []HouseholdSplitShare `json:"split_shares" gorm:"foreignKey:HouseholdID;constraint:OnDelete:CASCADE"`
+	Currency    string                `json:"currency" gorm:"not null;default:JPY"`
+	Timezone    string                `json:"timezone" gorm:"not null;default:Asia/Tokyo"`
+	// MonthStartDay は集計の月の始まりの日、WeekStart は週の始まりの曜日(0が日曜日)です。
+	MonthStartDay int       `json:"month_start_day" gorm:"not null;default:1"`
+	WeekStart     int       `json:"week_start" gorm:"not null;default:1"`
+	Users         []User    `json:"users"` // A household has many users
+	CreatedAt     time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+}
+
+type HouseholdSplitShare struct {
+	ID          uint `json:"id" gorm:"primaryKey"`
+	HouseholdID uint `json:"household_id" gorm:"not null;uniqueIndex:idx_split_share_household_user"`
+	UserID      uint `json:"user_id" gorm:"not null;uniqueIndex:idx_split_share_household_user"`
+	Percent     int  `json:"percent" gorm:"not null"`
+}
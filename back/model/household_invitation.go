@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type HouseholdInvitation struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	HouseholdID    uint       `json:"household_id" gorm:"not null;index"`
+	Household      Household  `json:"household" gorm:"foreignKey:HouseholdID;references:ID;constraint:OnDelete:CASCADE"`
+	Code           string     `json:"code" gorm:"type:varchar(32);not null;uniqueIndex"`
+	CreatedByID    *uint      `json:"created_by_id" gorm:"index"`
+	CreatedBy      *User      `json:"created_by" gorm:"foreignKey:CreatedByID;references:ID;constraint:OnDelete:SET NULL"`
+	Role           string     `json:"role" gorm:"type:varchar(16);not null;default:member"`
+	TargetEmail    string     `json:"target_email" gorm:"not null;default:''"`
+	TargetProvider string     `json:"target_provider" gorm:"type:varchar(64);not null;default:''"`
+	TargetSubject  string     `json:"target_subject" gorm:"type:varchar(255);not null;default:''"`
+	MaxUses        int        `json:"max_uses" gorm:"not null;default:0"`
+	Uses           int        `json:"uses" gorm:"not null;default:0"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+type HouseholdInvitationRedemption struct {
+	ID           uint                `json:"id" gorm:"primaryKey"`
+	InvitationID uint                `json:"invitation_id" gorm:"not null;index"`
+	Invitation   HouseholdInvitation `json:"invitation" gorm:"foreignKey:InvitationID;references:ID;constraint:OnDelete:CASCADE"`
+	UserID       *uint               `json:"user_id" gorm:"index"`
+	User         *User               `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL"`
+	JoinedAt     time.Time           `json:"joined_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type HouseholdMember struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	HouseholdID uint      `json:"household_id" gorm:"not null;index"`
+	Household   Household `json:"household" gorm:"foreignKey:HouseholdID;references:ID;constraint:OnDelete:CASCADE"`
+	UserID      uint      `json:"user_id" gorm:"not null;uniqueIndex"`
+	User        User      `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	Role        string    `json:"role" gorm:"type:varchar(16);not null;default:member"`
+	JoinedAt    time.Time `json:"joined_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Income struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Amount      int       `json:"amount" gorm:"not null"`
+	Source      string    `json:"source"`
+	Date        time.Time `json:"date" gorm:"not null;index"`
+	Category    string    `json:"category" gorm:"not null"`
+	Memo        string    `json:"memo"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	UserID      uint      `json:"user_id" gorm:"not null"`
+	User        User      `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	RecipientID uint      `json:"recipient_id" gorm:"not null"`
+}
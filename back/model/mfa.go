@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// TOTPCredential はユーザーのTOTP設定です。シークレットは暗号化して保存します。
+type TOTPCredential struct {
+	UserID          uint       `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	User            User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	SecretEncrypted string     `json:"-" gorm:"not null"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	LastUsedStep    int64      `json:"-" gorm:"not null;default:0"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type RecoveryCode struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	User      User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	CodeHash  string     `json:"-" gorm:"not null"`
+	CreatedAt time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UsedAt    *time.Time `json:"used_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type PasskeyCredential struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       uint       `json:"user_id" gorm:"not null;index"`
+	User         User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	CredentialID []byte     `json:"-" gorm:"not null;uniqueIndex"`
+	PublicKey    []byte     `json:"-" gorm:"not null"`
+	SignCount    int64      `json:"sign_count" gorm:"not null;default:0"`
+	AAGUID       []byte     `json:"-"`
+	Transports   string     `json:"transports"`
+	Name         string     `json:"name" gorm:"not null"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type RecurringExpense struct {
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	HouseholdID       uint       `json:"household_id" gorm:"not null;index"`
+	Household         Household  `json:"household" gorm:"foreignKey:HouseholdID;references:ID;constraint:OnDelete:CASCADE"`
+	UserID            uint       `json:"user_id" gorm:"not null"`
+	PayerID           uint       `json:"payer_id" gorm:"not null"`
+	Amount            int        `json:"amount" gorm:"not null"`
+	StoreName         string     `json:"store_name" gorm:"not null"`
+	Category          string     `json:"category" gorm:"not null"`
+	Memo              string     `json:"memo"`
+	Frequency         string     `json:"frequency" gorm:"not null"`
+	Day               int        `json:"day"`
+	Weekday           int        `json:"weekday"`
+	Month             int        `json:"month"`
+	StartDate         time.Time  `json:"start_date" gorm:"not null"`
+	EndDate           *time.Time `json:"end_date"`
+	LastGeneratedDate *time.Time `json:"last_generated_date"`
+	Active            bool       `json:"active" gorm:"not null;default:true"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
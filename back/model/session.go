@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type Session struct {
+	ID                       uint       `json:"id" gorm:"primaryKey"`
+	UserID                   uint       `json:"user_id" gorm:"not null;index"`
+	User                     User       `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	RefreshTokenHash         string     `json:"-" gorm:"not null;uniqueIndex"`
+	PreviousRefreshTokenHash string     `json:"-" gorm:"index"`
+	DeviceName               string     `json:"device_name"`
+	IPAddress                string     `json:"ip_address"`
+	UserAgent                string     `json:"user_agent"`
+	CreatedAt                time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	LastUsedAt               time.Time  `json:"last_used_at" gorm:"not null"`
+	RotatedAt                time.Time  `json:"rotated_at" gorm:"not null"`
+	ExpiresAt                time.Time  `json:"expires_at" gorm:"not null;index"`
+	RevokedAt                *time.Time `json:"revoked_at"`
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// SignedTokenStore はサーバー側に状態を持たない署名付きトークンのストアです。
+// トークンは有効期限と乱数をセッションIDとともにHMAC署名したもので、
+// Cookieとヘッダーの両方で送られる値（Signed Double-Submit Cookie）として検証します。
+// 状態を持たないため DeleteToken で個別に失効させることはできず、有効期限まで有効です。
+type SignedTokenStore struct {
+	secret []byte
+}
+
+var _ TokenStore = (*SignedTokenStore)(nil)
+
+// NewSignedTokenStore は署名鍵を指定してSignedTokenStoreを作成します。
+func NewSignedTokenStore(secret []byte) (*SignedTokenStore, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("token signing secret is empty")
+	}
+	return &SignedTokenStore{secret: secret}, nil
+}
+
+// IssueToken は有効期限付きの署名済みトークンを発行します
+func (s *SignedTokenStore) IssueToken(ctx context.Context, sessionID string, ttl time.Duration) (string, error) {
+	payload := make([]byte, 8+16)
+	binary.BigEndian.PutUint64(payload, uint64(time.Now().Add(ttl).Unix()))
+	if _, err := rand.Read(payload[8:]); err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.sign(sessionID, encoded), nil
+}
+
+// GetToken は状態を保持しないため常に false を返します
+func (s *SignedTokenStore) GetToken(ctx context.Context, sessionID string) (string, bool, error) {
+	return "", false, nil
+}
+
+// ValidateToken は署名と有効期限を検証します
+func (s *SignedTokenStore) ValidateToken(ctx context.Context, sessionID, token string) (bool, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false, nil
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(sessionID, encoded))) {
+		return false, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(payload) != 8+16 {
+		return false, nil
+	}
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	return time.Now().Before(expiresAt), nil
+}
+
+// DeleteToken は状態を保持しないため何もしません
+func (s *SignedTokenStore) DeleteToken(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func (s *SignedTokenStore) sign(sessionID, encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(sessionID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
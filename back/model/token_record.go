@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// TokenRecord はPostgreSQLに保存するCSRFトークン・OAuth stateです。
+type TokenRecord struct {
+	SessionID string    `json:"session_id" gorm:"primaryKey"`
+	Token     string    `json:"token" gorm:"not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
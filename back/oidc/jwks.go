@@ -0,0 +1,175 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minJWKSRefreshInterval は未知の kid による JWKS の再取得の最小間隔です。
+// 不正な kid を付けたトークンでIDプロバイダへのリクエストが増えないようにします。
+const minJWKSRefreshInterval = time.Minute
+
+// jsonWebKey はJWKSに含まれる鍵（RFC 7517）です。
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// PublicKey はJWKを *rsa.PublicKey または *ecdsa.PublicKey に変換します。
+func (k jsonWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeKeyParam(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decodeKeyParam(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decodeKeyParam(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decodeKeyParam(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, errors.New("invalid EC coordinate length")
+		}
+		// 座標を非圧縮形式の点にし、曲線上の点であることを検証する
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+func decodeKeyParam(s string) ([]byte, error) {
+	if s == "" {
+		return nil, errors.New("missing parameter")
+	}
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// keySet はJWKSから取得した公開鍵を kid ごとにキャッシュします。
+type keySet struct {
+	client *http.Client
+
+	mutex     sync.Mutex
+	url       string
+	keys      map[string]crypto.PublicKey
+	expiry    time.Time
+	lastFetch time.Time
+}
+
+func newKeySet(client *http.Client) *keySet {
+	return &keySet{client: client}
+}
+
+// get は kid に対応する公開鍵を返します。
+// キャッシュに無い kid はIDプロバイダの鍵のローテーションとみなし、JWKSを再取得します。
+func (ks *keySet) get(ctx context.Context, jwksURL, kid string) (crypto.PublicKey, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	now := time.Now()
+	if ks.url == jwksURL {
+		if key, ok := ks.keys[kid]; ok && now.Before(ks.expiry) {
+			return key, nil
+		}
+		if now.Sub(ks.lastFetch) < minJWKSRefreshInterval {
+			return nil, fmt.Errorf("public key with kid %s not found in JWKS", kid)
+		}
+	}
+
+	if err := ks.refresh(ctx, jwksURL); err != nil {
+		return nil, err
+	}
+	if key, ok := ks.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("public key with kid %s not found in JWKS", kid)
+}
+
+// refresh はJWKSを取得してキャッシュを置き換えます。呼び出し側でロックを取得してください。
+func (ks *keySet) refresh(ctx context.Context, jwksURL string) error {
+	// 取得に失敗した場合も再取得の間隔を空ける
+	ks.lastFetch = time.Now()
+	if ks.url != jwksURL {
+		ks.url = jwksURL
+		ks.keys = nil
+		ks.expiry = time.Time{}
+	}
+
+	body, err := fetch(ctx, ks.client, jwksURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &jwks); err != nil {
+		return fmt.Errorf("failed to unmarshal JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		// 暗号化用の鍵や、対応していない種類の鍵は無視する
+		if jwk.Kid == "" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	ks.keys = keys
+	ks.expiry = time.Now().Add(metadataCacheTTL)
+	return nil
+}
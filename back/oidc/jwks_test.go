@@ -0,0 +1,138 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeySetGet(t *testing.T) {
+	idp := newFakeIdP(t)
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	idp.setKeys(rsaKey.jwk, ecKey.jwk)
+	ks := newKeySet(http.DefaultClient)
+	jwksURL := idp.url + "/jwks"
+
+	for _, kid := range []string{"rsa-1", "ec-1", "rsa-1"} {
+		if _, err := ks.get(context.Background(), jwksURL, kid); err != nil {
+			t.Fatalf("get(%s) error = %v", kid, err)
+		}
+	}
+	if idp.fetches() != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", idp.fetches())
+	}
+}
+
+func TestKeySetGetIgnoresUnusableKeys(t *testing.T) {
+	idp := newFakeIdP(t)
+	encryption := newRSAKey(t, "enc-1")
+	encryption.jwk.Use = "enc"
+	noKid := newRSAKey(t, "")
+	broken := newECKey(t, "broken-1")
+	broken.jwk.Crv = "P-192"
+	idp.setKeys(encryption.jwk, noKid.jwk, broken.jwk)
+	ks := newKeySet(http.DefaultClient)
+
+	for _, kid := range []string{"enc-1", "", "broken-1"} {
+		if _, err := ks.get(context.Background(), idp.url+"/jwks", kid); err == nil {
+			t.Errorf("get(%q) error = nil, want not found", kid)
+		}
+	}
+}
+
+func TestKeySetGetRefetchesOnUnknownKid(t *testing.T) {
+	idp := newFakeIdP(t)
+	oldKey := newRSAKey(t, "old")
+	newKey := newRSAKey(t, "new")
+	idp.setKeys(oldKey.jwk)
+	ks := newKeySet(http.DefaultClient)
+	jwksURL := idp.url + "/jwks"
+
+	if _, err := ks.get(context.Background(), jwksURL, "old"); err != nil {
+		t.Fatalf("get(old) error = %v", err)
+	}
+
+	// IDプロバイダが鍵をローテーションした後、最小間隔を過ぎてから新しい kid を要求する
+	idp.setKeys(oldKey.jwk, newKey.jwk)
+	ks.lastFetch = time.Now().Add(-minJWKSRefreshInterval)
+	if _, err := ks.get(context.Background(), jwksURL, "new"); err != nil {
+		t.Fatalf("get(new) error = %v", err)
+	}
+	if idp.fetches() != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", idp.fetches())
+	}
+}
+
+func TestKeySetGetRateLimitsRefetch(t *testing.T) {
+	idp := newFakeIdP(t)
+	rsaKey := newRSAKey(t, "rsa-1")
+	idp.setKeys(rsaKey.jwk)
+	ks := newKeySet(http.DefaultClient)
+	jwksURL := idp.url + "/jwks"
+
+	if _, err := ks.get(context.Background(), jwksURL, "rsa-1"); err != nil {
+		t.Fatalf("get(rsa-1) error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := ks.get(context.Background(), jwksURL, "unknown"); err == nil || !strings.Contains(err.Error(), "not found in JWKS") {
+			t.Fatalf("get(unknown) error = %v, want not found", err)
+		}
+	}
+	if idp.fetches() != 1 {
+		t.Errorf("JWKS fetched %d times within the refresh interval, want 1", idp.fetches())
+	}
+	// 既知の kid は再取得の制限中も取得できる
+	if _, err := ks.get(context.Background(), jwksURL, "rsa-1"); err != nil {
+		t.Errorf("get(rsa-1) error = %v", err)
+	}
+
+	ks.lastFetch = time.Now().Add(-minJWKSRefreshInterval)
+	if _, err := ks.get(context.Background(), jwksURL, "unknown"); err == nil {
+		t.Fatal("get(unknown) error = nil, want not found")
+	}
+	if idp.fetches() != 2 {
+		t.Errorf("JWKS fetched %d times after the refresh interval, want 2", idp.fetches())
+	}
+}
+
+func TestKeySetGetRateLimitsRefetchAfterFailure(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.jwksStatus = http.StatusInternalServerError
+	ks := newKeySet(http.DefaultClient)
+	jwksURL := idp.url + "/jwks"
+
+	if _, err := ks.get(context.Background(), jwksURL, "rsa-1"); err == nil || !strings.Contains(err.Error(), "failed to fetch JWKS") {
+		t.Fatalf("get() error = %v, want fetch failure", err)
+	}
+	if _, err := ks.get(context.Background(), jwksURL, "rsa-1"); err == nil {
+		t.Fatal("get() error = nil, want not found")
+	}
+	if idp.fetches() != 1 {
+		t.Errorf("JWKS fetched %d times after a failure, want 1", idp.fetches())
+	}
+}
+
+func TestKeySetGetRefetchesExpiredCache(t *testing.T) {
+	idp := newFakeIdP(t)
+	rsaKey := newRSAKey(t, "rsa-1")
+	idp.setKeys(rsaKey.jwk)
+	ks := newKeySet(http.DefaultClient)
+	jwksURL := idp.url + "/jwks"
+
+	if _, err := ks.get(context.Background(), jwksURL, "rsa-1"); err != nil {
+		t.Fatalf("get(rsa-1) error = %v", err)
+	}
+	// 取り下げられた鍵はキャッシュの期限が切れた後に使えなくなる
+	idp.setKeys()
+	ks.expiry = time.Now().Add(-time.Second)
+	ks.lastFetch = time.Now().Add(-metadataCacheTTL)
+	if _, err := ks.get(context.Background(), jwksURL, "rsa-1"); err == nil {
+		t.Fatal("get(rsa-1) error = nil, want not found after the key was removed")
+	}
+	if idp.fetches() != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", idp.fetches())
+	}
+}
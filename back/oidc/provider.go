@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// metadataCacheTTL はDiscoveryドキュメントとJWKSをキャッシュする期間です。
+const metadataCacheTTL = 24 * time.Hour
+
+// WellKnownIssuers は発行者（issuer）の設定を省略できるIDプロバイダです。
+var WellKnownIssuers = map[string]string{
+	"line":   "https://access.line.me",
+	"google": "https://accounts.google.com",
+}
+
+// Config はIDプロバイダの設定です。
+type Config struct {
+	// Name はURLやユーザーとの紐付けに使うプロバイダ名です。
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// HTTPClient はDiscoveryドキュメント・JWKSの取得とトークンの交換に使います。nil の場合は http.DefaultClient を使います。
+	HTTPClient *http.Client
+}
+
+// Claims はIDトークンのクレームです。
+type Claims struct {
+	jwt.RegisteredClaims
+	Name    string `json:"name,omitempty"`
+	Picture string `json:"picture,omitempty"`
+	Email   string `json:"email,omitempty"`
+}
+
+// discoveryDocument はOIDC Discoveryで取得するプロバイダのメタデータです。
+type discoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Provider はDiscoveryドキュメントに基づいて認可コードフローを処理するIDプロバイダです。
+// メタデータは初回の利用時に取得します。
+type Provider struct {
+	config Config
+
+	discovery       *discoveryDocument
+	discoveryMutex  sync.Mutex
+	discoveryExpiry time.Time
+
+	keys *keySet
+}
+
+// NewProvider はIDプロバイダを生成します。
+func NewProvider(config Config) *Provider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "profile", "email"}
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	return &Provider{
+		config: config,
+		keys:   newKeySet(config.HTTPClient),
+	}
+}
+
+// Name はプロバイダ名を返します。
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL はIDプロバイダの認証URLを生成します。
+func (p *Provider) AuthCodeURL(ctx context.Context, state string) (string, error) {
+	oauth2Config, err := p.oauth2Config(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauth2Config.AuthCodeURL(state), nil
+}
+
+// Exchange は認可コードをトークンと交換し、署名を検証したIDトークンのクレームを返します。
+func (p *Provider) Exchange(ctx context.Context, code string) (*Claims, error) {
+	oauth2Config, err := p.oauth2Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth2Config.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.config.HTTPClient), code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange auth code for token: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("id_token not found")
+	}
+	return p.VerifyIDToken(ctx, rawIDToken)
+}
+
+// VerifyIDToken はIDトークンの署名・発行者・対象者・有効期限を検証します。
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string) (*Claims, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		// プロバイダが署名に使うと公開しているアルゴリズム以外は受け付けない
+		if len(discovery.IDTokenSigningAlgValuesSupported) > 0 && !slices.Contains(discovery.IDTokenSigningAlgValuesSupported, token.Method.Alg()) {
+			return nil, fmt.Errorf("unsupported signing algorithm: %v", token.Method.Alg())
+		}
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			// RS256・ES256 などの場合はJWKSの公開鍵を使用
+			kid, ok := token.Header["kid"].(string)
+			if !ok {
+				return nil, fmt.Errorf("kid not found in token header")
+			}
+			publicKey, err := p.keys.get(ctx, discovery.JWKSURI, kid)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s public key: %w", p.config.Name, err)
+			}
+			return publicKey, nil
+		case *jwt.SigningMethodHMAC:
+			// HMAC (HS256) の場合はクライアントシークレットを使用
+			return []byte(p.config.ClientSecret), nil
+		default:
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	},
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse or validate ID token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("sub not found in ID token")
+	}
+	return claims, nil
+}
+
+func (p *Provider) oauth2Config(ctx context.Context) (*oauth2.Config, error) {
+	if p.config.ClientID == "" || p.config.ClientSecret == "" || p.config.RedirectURL == "" {
+		return nil, fmt.Errorf("%s のクライアント設定がされていません", p.config.Name)
+	}
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+		RedirectURL: p.config.RedirectURL,
+		Scopes:      p.config.Scopes,
+	}, nil
+}
+
+// getDiscovery は発行者の /.well-known/openid-configuration からメタデータを取得します。
+func (p *Provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.discoveryMutex.Lock()
+	defer p.discoveryMutex.Unlock()
+	if p.discovery != nil && time.Now().Before(p.discoveryExpiry) {
+		return p.discovery, nil
+	}
+
+	discoveryURL := strings.TrimRight(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	body, err := fetch(ctx, p.config.HTTPClient, discoveryURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document of %s: %w", p.config.Name, err)
+	}
+
+	var discovery discoveryDocument
+	if err := json.Unmarshal(body, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discovery document: %w", err)
+	}
+	// なりすましを防ぐため、設定した発行者と一致するドキュメントのみ受け付ける
+	if discovery.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("issuer mismatch in discovery document: %s", discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("incomplete discovery document of %s", p.config.Name)
+	}
+
+	p.discovery = &discovery
+	p.discoveryExpiry = time.Now().Add(metadataCacheTTL)
+	return p.discovery, nil
+}
+
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status=%d, body=%s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
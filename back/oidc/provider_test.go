@@ -0,0 +1,307 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "client-id"
+
+// fakeIdP はDiscoveryドキュメントとJWKSを返すテスト用のIDプロバイダです。
+type fakeIdP struct {
+	url string
+
+	mutex sync.Mutex
+	// issuer はDiscoveryドキュメントで返す発行者です。空の場合はサーバーのURLを返します。
+	issuer      string
+	keys        []jsonWebKey
+	algs        []string
+	jwksStatus  int
+	jwksFetches int
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	idp := &fakeIdP{algs: []string{"RS256", "ES256"}, jwksStatus: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		idp.mutex.Lock()
+		defer idp.mutex.Unlock()
+		issuer := idp.issuer
+		if issuer == "" {
+			issuer = idp.url
+		}
+		json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:                           issuer,
+			AuthorizationEndpoint:            idp.url + "/authorize",
+			TokenEndpoint:                    idp.url + "/token",
+			JWKSURI:                          idp.url + "/jwks",
+			IDTokenSigningAlgValuesSupported: idp.algs,
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.mutex.Lock()
+		defer idp.mutex.Unlock()
+		idp.jwksFetches++
+		if idp.jwksStatus != http.StatusOK {
+			w.WriteHeader(idp.jwksStatus)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"keys": idp.keys})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	idp.url = server.URL
+	return idp
+}
+
+func (idp *fakeIdP) setKeys(keys ...jsonWebKey) {
+	idp.mutex.Lock()
+	defer idp.mutex.Unlock()
+	idp.keys = keys
+}
+
+func (idp *fakeIdP) fetches() int {
+	idp.mutex.Lock()
+	defer idp.mutex.Unlock()
+	return idp.jwksFetches
+}
+
+func (idp *fakeIdP) provider() *Provider {
+	return NewProvider(Config{
+		Name:         "test",
+		Issuer:       idp.url,
+		ClientID:     testClientID,
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://budget.example.com/callback",
+	})
+}
+
+// signingKey はJWKSで公開する鍵とIDトークンの署名に使う秘密鍵の組です。
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private any
+	jwk     jsonWebKey
+}
+
+func newRSAKey(t *testing.T, kid string) signingKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingKey{
+		kid:     kid,
+		method:  jwt.SigningMethodRS256,
+		private: private,
+		jwk: jsonWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(private.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(private.E)).Bytes()),
+		},
+	}
+}
+
+func newECKey(t *testing.T, kid string) signingKey {
+	t.Helper()
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point, err := private.PublicKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signingKey{
+		kid:     kid,
+		method:  jwt.SigningMethodES256,
+		private: private,
+		jwk: jsonWebKey{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Alg: "ES256",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(point[1:33]),
+			Y:   base64.RawURLEncoding.EncodeToString(point[33:]),
+		},
+	}
+}
+
+func (k signingKey) sign(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.kid
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func validClaims(issuer string) *Claims {
+	now := time.Now()
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   "subject-1",
+			Audience:  jwt.ClaimStrings{testClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Email: "user@example.com",
+	}
+}
+
+func TestProviderVerifyIDToken(t *testing.T) {
+	idp := newFakeIdP(t)
+	rsaKey := newRSAKey(t, "rsa-1")
+	ecKey := newECKey(t, "ec-1")
+	unpublished := newRSAKey(t, "rsa-1")
+	idp.setKeys(rsaKey.jwk, ecKey.jwk)
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr string
+	}{
+		{"valid RS256", func() string { return rsaKey.sign(t, validClaims(idp.url)) }, ""},
+		{"valid ES256", func() string { return ecKey.sign(t, validClaims(idp.url)) }, ""},
+		{"issuer mismatch", func() string {
+			claims := validClaims("https://evil.example.com")
+			return rsaKey.sign(t, claims)
+		}, "invalid issuer"},
+		{"audience mismatch", func() string {
+			claims := validClaims(idp.url)
+			claims.Audience = jwt.ClaimStrings{"other-client"}
+			return rsaKey.sign(t, claims)
+		}, "invalid audience"},
+		{"expired", func() string {
+			claims := validClaims(idp.url)
+			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+			return rsaKey.sign(t, claims)
+		}, "token is expired"},
+		{"missing exp", func() string {
+			claims := validClaims(idp.url)
+			claims.ExpiresAt = nil
+			return rsaKey.sign(t, claims)
+		}, "exp claim is required"},
+		{"missing sub", func() string {
+			claims := validClaims(idp.url)
+			claims.Subject = ""
+			return rsaKey.sign(t, claims)
+		}, "sub not found"},
+		{"signed by an unpublished key", func() string { return unpublished.sign(t, validClaims(idp.url)) }, "verification error"},
+		{"unknown kid", func() string { return newRSAKey(t, "rsa-unknown").sign(t, validClaims(idp.url)) }, "not found in JWKS"},
+		{"missing kid", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims(idp.url))
+			signed, err := token.SignedString(rsaKey.private)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signed
+		}, "kid not found"},
+		{"HS256 signed with the client secret", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims(idp.url))
+			token.Header["kid"] = rsaKey.kid
+			signed, err := token.SignedString([]byte("client-secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signed
+		}, "unsupported signing algorithm"},
+		{"alg none", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims(idp.url))
+			token.Header["kid"] = rsaKey.kid
+			signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signed
+		}, "unsupported signing algorithm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := idp.provider().VerifyIDToken(context.Background(), tt.token())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("VerifyIDToken() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyIDToken() error = %v", err)
+			}
+			if claims.Subject != "subject-1" || claims.Email != "user@example.com" {
+				t.Errorf("VerifyIDToken() = %+v, want subject-1 and user@example.com", claims)
+			}
+		})
+	}
+}
+
+func TestProviderVerifyIDTokenDisallowedAlgorithm(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.algs = []string{"RS256"}
+	ecKey := newECKey(t, "ec-1")
+	idp.setKeys(ecKey.jwk)
+
+	_, err := idp.provider().VerifyIDToken(context.Background(), ecKey.sign(t, validClaims(idp.url)))
+	if err == nil || !strings.Contains(err.Error(), "unsupported signing algorithm: ES256") {
+		t.Fatalf("VerifyIDToken() error = %v, want unsupported signing algorithm", err)
+	}
+	if idp.fetches() != 0 {
+		t.Errorf("JWKS fetched %d times, want 0 for a disallowed algorithm", idp.fetches())
+	}
+}
+
+func TestProviderVerifyIDTokenAfterKeyRotation(t *testing.T) {
+	idp := newFakeIdP(t)
+	oldKey := newRSAKey(t, "old")
+	newKey := newRSAKey(t, "new")
+	idp.setKeys(oldKey.jwk)
+	provider := idp.provider()
+
+	if _, err := provider.VerifyIDToken(context.Background(), oldKey.sign(t, validClaims(idp.url))); err != nil {
+		t.Fatalf("VerifyIDToken() with the old key error = %v", err)
+	}
+
+	idp.setKeys(newKey.jwk)
+	provider.keys.lastFetch = time.Now().Add(-minJWKSRefreshInterval)
+
+	if _, err := provider.VerifyIDToken(context.Background(), newKey.sign(t, validClaims(idp.url))); err != nil {
+		t.Fatalf("VerifyIDToken() with the rotated key error = %v", err)
+	}
+	if idp.fetches() != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", idp.fetches())
+	}
+}
+
+func TestProviderRejectsDiscoveryIssuerMismatch(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.issuer = "https://evil.example.com"
+	rsaKey := newRSAKey(t, "rsa-1")
+	idp.setKeys(rsaKey.jwk)
+
+	_, err := idp.provider().VerifyIDToken(context.Background(), rsaKey.sign(t, validClaims("https://evil.example.com")))
+	if err == nil || !strings.Contains(err.Error(), "issuer mismatch") {
+		t.Fatalf("VerifyIDToken() error = %v, want issuer mismatch", err)
+	}
+}
@@ -0,0 +1,21 @@
+package oidc
+
+// Registry は設定されたIDプロバイダをプロバイダ名で引けるようにします。
+type Registry struct {
+	providers map[string]*Provider
+}
+
+// NewRegistry はIDプロバイダの一覧からRegistryを生成します。
+func NewRegistry(providers ...*Provider) *Registry {
+	r := &Registry{providers: make(map[string]*Provider)}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+// Get はプロバイダ名に対応するIDプロバイダを返します。
+func (r *Registry) Get(name string) (*Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/db"
+	"github.com/yanatoritakuma/budget/back/repository"
+	"github.com/yanatoritakuma/budget/back/usecase"
+)
+
+// 定期支出から期日を迎えた支出をローカルで生成するコマンドです。
+// 例: GO_ENV=dev go run ./recurring -date 2025-01-31
+func main() {
+	date := flag.String("date", "", "生成の基準日 (YYYY-MM-DD)。省略時は本日")
+	flag.Parse()
+
+	asOf := time.Now()
+	if *date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+		if err != nil {
+			log.Fatalf("invalid date: %v", err)
+		}
+		asOf = parsed
+	}
+
+	dbConn := db.NewDB()
+	defer db.CloseDB(dbConn)
+
+	recurringExpenseUsecase := usecase.NewRecurringExpenseUsecase(
+		repository.NewRecurringExpenseRepositoryImpl(dbConn),
+		repository.NewUserRepositoryImpl(dbConn),
+		repository.NewHouseholdRepositoryImpl(dbConn),
+		repository.NewHouseholdMemberRepositoryImpl(dbConn),
+		repository.NewUnitOfWork(dbConn),
+	)
+
+	created, err := recurringExpenseUsecase.GenerateDueExpenses(context.Background(), asOf)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("Generated %d recurring expenses as of %s\n", created, asOf.Format("2006-01-02"))
+}
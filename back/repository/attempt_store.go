@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ model.AttemptStore = (*PostgresAttemptStore)(nil)
+
+// PostgresAttemptStore は試行回数をPostgreSQLに保存するストアです。
+// Lambdaの複数インスタンス間で回数を共有できます。
+type PostgresAttemptStore struct {
+	db        *gorm.DB
+	mutex     sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresAttemptStore(db *gorm.DB) *PostgresAttemptStore {
+	return &PostgresAttemptStore{db: db}
+}
+
+func (as *PostgresAttemptStore) Get(ctx context.Context, key string) (model.AttemptRecord, error) {
+	now := time.Now()
+	var counter model.AttemptCounter
+	err := as.db.WithContext(ctx).
+		Where("key = ? AND (window_ends_at > ? OR locked_until > ?)", key, now, now).
+		First(&counter).Error
+	if err == gorm.ErrRecordNotFound {
+		return model.AttemptRecord{}, nil
+	}
+	if err != nil {
+		return model.AttemptRecord{}, err
+	}
+	return toAttemptRecord(counter), nil
+}
+
+// Increment は複数インスタンスから同時に呼ばれても数え漏れが無いよう、1回のUPSERTで回数を更新します。
+func (as *PostgresAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (model.AttemptRecord, error) {
+	as.sweepExpired(ctx)
+
+	now := time.Now()
+	var counter model.AttemptCounter
+	err := as.db.WithContext(ctx).Raw(`
+		INSERT INTO attempt_counters (key, count, window_ends_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN attempt_counters.window_ends_at <= ? THEN 1 ELSE attempt_counters.count + 1 END,
+			window_ends_at = CASE WHEN attempt_counters.window_ends_at <= ? THEN EXCLUDED.window_ends_at ELSE attempt_counters.window_ends_at END
+		RETURNING key, count, window_ends_at, locked_until`,
+		key, now.Add(window), now, now,
+	).Scan(&counter).Error
+	if err != nil {
+		return model.AttemptRecord{}, err
+	}
+	return toAttemptRecord(counter), nil
+}
+
+func (as *PostgresAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	return as.db.WithContext(ctx).Exec(`
+		INSERT INTO attempt_counters (key, count, window_ends_at, locked_until)
+		VALUES (?, 0, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			locked_until = EXCLUDED.locked_until,
+			window_ends_at = GREATEST(attempt_counters.window_ends_at, EXCLUDED.window_ends_at)`,
+		key, until, until,
+	).Error
+}
+
+func (as *PostgresAttemptStore) Reset(ctx context.Context, key string) error {
+	return as.db.WithContext(ctx).Where("key = ?", key).Delete(&model.AttemptCounter{}).Error
+}
+
+// CleanupExpiredAttempts はウィンドウとロックが終了した記録を削除します
+func (as *PostgresAttemptStore) CleanupExpiredAttempts(ctx context.Context) error {
+	now := time.Now()
+	return as.db.WithContext(ctx).
+		Where("window_ends_at <= ? AND (locked_until IS NULL OR locked_until <= ?)", now, now).
+		Delete(&model.AttemptCounter{}).Error
+}
+
+// sweepExpired は前回の掃除から一定時間が経過していれば終了した記録を削除します。
+func (as *PostgresAttemptStore) sweepExpired(ctx context.Context) {
+	as.mutex.Lock()
+	if time.Since(as.lastSweep) < tokenSweepInterval {
+		as.mutex.Unlock()
+		return
+	}
+	as.lastSweep = time.Now()
+	as.mutex.Unlock()
+
+	// 掃除に失敗しても回数の記録は継続する
+	_ = as.CleanupExpiredAttempts(ctx)
+}
+
+func toAttemptRecord(counter model.AttemptCounter) model.AttemptRecord {
+	record := model.AttemptRecord{
+		Count:        counter.Count,
+		WindowEndsAt: counter.WindowEndsAt,
+	}
+	if counter.LockedUntil != nil {
+		record.LockedUntil = *counter.LockedUntil
+	}
+	return record
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yanatoritakuma/budget/back/domain/budget"
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ budget.BudgetRepository = (*BudgetRepositoryImpl)(nil)
+
+// BudgetRepositoryImpl implements budget.BudgetRepository using GORM.
+type BudgetRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepositoryImpl creates a new BudgetRepositoryImpl.
+func NewBudgetRepositoryImpl(db *gorm.DB) budget.BudgetRepository {
+	return &BudgetRepositoryImpl{db: db}
+}
+
+// Create creates a new budget.
+func (repo *BudgetRepositoryImpl) Create(ctx context.Context, b *budget.Budget) error {
+	budgetModel := toModelBudget(b)
+	if err := repo.db.WithContext(ctx).Create(budgetModel).Error; err != nil {
+		return err
+	}
+	b.ID = budget.BudgetID(budgetModel.ID)
+	b.CreatedAt = budgetModel.CreatedAt
+	b.UpdatedAt = budgetModel.UpdatedAt
+	return nil
+}
+
+// FindByID finds a budget by ID.
+func (repo *BudgetRepositoryImpl) FindByID(ctx context.Context, id uint) (*budget.Budget, error) {
+	var budgetModel model.Budget
+	if err := repo.db.WithContext(ctx).First(&budgetModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // Budget not found
+		}
+		return nil, err
+	}
+	return toDomainBudget(&budgetModel)
+}
+
+// FindByHouseholdAndMonth finds budgets of a household for the given month.
+func (repo *BudgetRepositoryImpl) FindByHouseholdAndMonth(ctx context.Context, householdID uint, year int, month int) ([]*budget.Budget, error) {
+	var budgetModels []model.Budget
+	if err := repo.db.WithContext(ctx).
+		Where("household_id = ? AND year = ? AND month = ?", householdID, year, month).
+		Order("category").
+		Find(&budgetModels).Error; err != nil {
+		return nil, err
+	}
+
+	var budgets []*budget.Budget
+	for i := range budgetModels {
+		domainBudget, err := toDomainBudget(&budgetModels[i])
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, domainBudget)
+	}
+	return budgets, nil
+}
+
+// Update updates an existing budget.
+func (repo *BudgetRepositoryImpl) Update(ctx context.Context, b *budget.Budget) error {
+	budgetModel := toModelBudget(b)
+	return repo.db.WithContext(ctx).Model(&model.Budget{}).Where("id = ?", b.ID.Value()).Updates(budgetModel).Error
+}
+
+// Delete deletes a budget by ID.
+func (repo *BudgetRepositoryImpl) Delete(ctx context.Context, id budget.BudgetID) error {
+	return repo.db.WithContext(ctx).Where("id = ?", id.Value()).Delete(&model.Budget{}).Error
+}
+
+// RenameCategory renames the category of the household's budgets.
+func (repo *BudgetRepositoryImpl) RenameCategory(ctx context.Context, householdID uint, oldName string, newName string) error {
+	return repo.db.WithContext(ctx).Model(&model.Budget{}).
+		Where("household_id = ? AND category = ?", householdID, oldName).
+		Update("category", newName).Error
+}
+
+func toDomainBudget(bm *model.Budget) (*budget.Budget, error) {
+	if bm == nil {
+		return nil, nil
+	}
+
+	category, err := expense.NewCategory(bm.Category)
+	if err != nil {
+		return nil, err
+	}
+	year, err := budget.NewYear(bm.Year)
+	if err != nil {
+		return nil, err
+	}
+	month, err := budget.NewMonth(bm.Month)
+	if err != nil {
+		return nil, err
+	}
+	limitAmount, err := budget.NewLimitAmount(bm.LimitAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget.Budget{
+		ID:          budget.BudgetID(bm.ID),
+		HouseholdID: bm.HouseholdID,
+		Category:    category,
+		Year:        year,
+		Month:       month,
+		LimitAmount: limitAmount,
+		CreatedAt:   bm.CreatedAt,
+		UpdatedAt:   bm.UpdatedAt,
+	}, nil
+}
+
+func toModelBudget(b *budget.Budget) *model.Budget {
+	if b == nil {
+		return nil
+	}
+	return &model.Budget{
+		ID:          b.ID.Value(),
+		HouseholdID: b.HouseholdID,
+		Category:    b.Category.Value(),
+		Year:        b.Year.Value(),
+		Month:       b.Month.Value(),
+		LimitAmount: b.LimitAmount.Value(),
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+}
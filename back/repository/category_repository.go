@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yanatoritakuma/budget/back/domain/category"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ category.CategoryRepository = (*CategoryRepositoryImpl)(nil)
+
+// CategoryRepositoryImpl implements category.CategoryRepository using GORM.
+type CategoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepositoryImpl creates a new CategoryRepositoryImpl.
+func NewCategoryRepositoryImpl(db *gorm.DB) category.CategoryRepository {
+	return &CategoryRepositoryImpl{db: db}
+}
+
+// Create creates a new category.
+func (repo *CategoryRepositoryImpl) Create(ctx context.Context, c *category.Category) error {
+	categoryModel := toModelCategory(c)
+	if err := repo.db.WithContext(ctx).Create(categoryModel).Error; err != nil {
+		return err
+	}
+	c.ID = category.CategoryID(categoryModel.ID)
+	c.CreatedAt = categoryModel.CreatedAt
+	c.UpdatedAt = categoryModel.UpdatedAt
+	return nil
+}
+
+// FindByID finds a category by ID.
+func (repo *CategoryRepositoryImpl) FindByID(ctx context.Context, id uint) (*category.Category, error) {
+	var categoryModel model.Category
+	if err := repo.db.WithContext(ctx).First(&categoryModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // Category not found
+		}
+		return nil, err
+	}
+	return toDomainCategory(&categoryModel)
+}
+
+// FindByName finds a category of a household by name.
+func (repo *CategoryRepositoryImpl) FindByName(ctx context.Context, householdID uint, name string) (*category.Category, error) {
+	var categoryModel model.Category
+	if err := repo.db.WithContext(ctx).
+		Where("household_id = ? AND name = ?", householdID, name).
+		First(&categoryModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // Category not found
+		}
+		return nil, err
+	}
+	return toDomainCategory(&categoryModel)
+}
+
+// FindByHouseholdID finds categories of a household ordered by sort order.
+func (repo *CategoryRepositoryImpl) FindByHouseholdID(ctx context.Context, householdID uint, includeArchived bool) ([]*category.Category, error) {
+	var categoryModels []model.Category
+	query := repo.db.WithContext(ctx).Where("household_id = ?", householdID)
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	if err := query.Order("sort_order, id").Find(&categoryModels).Error; err != nil {
+		return nil, err
+	}
+
+	var categories []*category.Category
+	for i := range categoryModels {
+		domainCategory, err := toDomainCategory(&categoryModels[i])
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, domainCategory)
+	}
+	return categories, nil
+}
+
+// Update updates an existing category.
+func (repo *CategoryRepositoryImpl) Update(ctx context.Context, c *category.Category) error {
+	categoryModel := toModelCategory(c)
+	// ゼロ値（アーカイブ解除や親カテゴリの解除）も反映するため Select で全項目を更新する
+	return repo.db.WithContext(ctx).Model(&model.Category{}).
+		Where("id = ?", c.ID.Value()).
+		Select("name", "color", "icon", "sort_order", "archived", "parent_id", "updated_at").
+		Updates(categoryModel).Error
+}
+
+// Delete deletes a category by ID.
+func (repo *CategoryRepositoryImpl) Delete(ctx context.Context, id category.CategoryID) error {
+	return repo.db.WithContext(ctx).Where("id = ?", id.Value()).Delete(&model.Category{}).Error
+}
+
+func toDomainCategory(cm *model.Category) (*category.Category, error) {
+	if cm == nil {
+		return nil, nil
+	}
+
+	name, err := category.NewName(cm.Name)
+	if err != nil {
+		return nil, err
+	}
+	color, err := category.NewColor(cm.Color)
+	if err != nil {
+		return nil, err
+	}
+	icon, err := category.NewIcon(cm.Icon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category.Category{
+		ID:          category.CategoryID(cm.ID),
+		HouseholdID: cm.HouseholdID,
+		Name:        name,
+		Color:       color,
+		Icon:        icon,
+		SortOrder:   cm.SortOrder,
+		Archived:    cm.Archived,
+		ParentID:    cm.ParentID,
+		CreatedAt:   cm.CreatedAt,
+		UpdatedAt:   cm.UpdatedAt,
+	}, nil
+}
+
+func toModelCategory(c *category.Category) *model.Category {
+	if c == nil {
+		return nil
+	}
+	return &model.Category{
+		ID:          c.ID.Value(),
+		HouseholdID: c.HouseholdID,
+		Name:        c.Name.Value(),
+		Color:       c.Color.Value(),
+		Icon:        c.Icon.Value(),
+		SortOrder:   c.SortOrder,
+		Archived:    c.Archived,
+		ParentID:    c.ParentID,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
@@ -2,10 +2,15 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/yanatoritakuma/budget/back/domain/expense"
 	"github.com/yanatoritakuma/budget/back/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ expense.ExpenseRepository = (*ExpenseRepositoryImpl)(nil)
@@ -29,18 +34,100 @@ func (er *ExpenseRepositoryImpl) CreateExpense(ctx context.Context, e *expense.E
 	return nil
 }
 
-func (er *ExpenseRepositoryImpl) GetExpense(ctx context.Context, householdID uint, year int, month int, category *string) ([]*expense.Expense, error) {
-	var expenseModels []model.Expense
+func (er *ExpenseRepositoryImpl) CreateExpenseIfNotExists(ctx context.Context, e *expense.Expense) (bool, error) {
+	expenseModel := toModelExpense(e)
+	result := er.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "recurring_expense_id"}, {Name: "date"}},
+		DoNothing: true,
+	}).Create(expenseModel)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	e.ID = expense.ExpenseID(expenseModel.ID)
+	e.CreatedAt = expenseModel.CreatedAt
+	e.UpdatedAt = expenseModel.UpdatedAt
+	return true, nil
+}
+
+// sortColumns は並び替え項目ごとのカラムです。
+var sortColumns = map[expense.SortField]string{
+	expense.SortFieldDate:      "expenses.date",
+	expense.SortFieldAmount:    "expenses.amount",
+	expense.SortFieldCreatedAt: "expenses.created_at",
+}
+
+func (er *ExpenseRepositoryImpl) FindByID(ctx context.Context, id expense.ExpenseID) (*expense.Expense, error) {
+	var expenseModel model.Expense
+	if err := er.db.WithContext(ctx).Preload("Shares").First(&expenseModel, id.Value()).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainExpense(&expenseModel)
+}
+
+func (er *ExpenseRepositoryImpl) Find(ctx context.Context, q expense.Query) ([]*expense.Expense, error) {
+	column, ok := sortColumns[q.SortField]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field: %s", q.SortField)
+	}
+	direction := "DESC"
+	operator := "<"
+	if q.SortOrder == expense.SortOrderAsc {
+		direction = "ASC"
+		operator = ">"
+	}
+
 	query := er.db.WithContext(ctx).Table("expenses").
-		Joins(`JOIN "user" ON "user".id = expenses.user_id`).
-		Where(`"user".household_id = ?`, householdID).
-		Where("EXTRACT(YEAR FROM date) = ? AND EXTRACT(MONTH FROM date) = ?", year, month)
+		Select("expenses.*").
+		Where("expenses.household_id = ?", q.HouseholdID)
 
-	if category != nil && *category != "" {
-		query = query.Where("category = ?", *category)
+	// date のインデックスが効くよう EXTRACT ではなく範囲条件で絞り込む
+	if q.From != nil {
+		query = query.Where("expenses.date >= ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("expenses.date < ?", q.To.AddDate(0, 0, 1))
+	}
+	if len(q.Categories) > 0 && len(q.CategoryIDs) > 0 {
+		query = query.Where("(expenses.category IN ? OR expenses.category_id IN ?)", q.Categories, q.CategoryIDs)
+	} else if len(q.Categories) > 0 {
+		query = query.Where("expenses.category IN ?", q.Categories)
+	} else if len(q.CategoryIDs) > 0 {
+		query = query.Where("expenses.category_id IN ?", q.CategoryIDs)
+	}
+	if q.PayerID != nil {
+		query = query.Where("expenses.payer_id = ?", *q.PayerID)
+	}
+	if q.AmountMin != nil {
+		query = query.Where("expenses.amount >= ?", *q.AmountMin)
+	}
+	if q.AmountMax != nil {
+		query = query.Where("expenses.amount <= ?", *q.AmountMax)
+	}
+	if q.Text != "" {
+		pattern := "%" + escapeLike(q.Text) + "%"
+		query = query.Where("(expenses.store_name ILIKE ? OR expenses.memo ILIKE ?)", pattern, pattern)
+	}
+	if q.Cursor != nil {
+		value, err := cursorValue(q.SortField, q.Cursor.Value)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(fmt.Sprintf("(%s, expenses.id) %s (?, ?)", column, operator), value, q.Cursor.ID)
 	}
 
-	if err := query.Find(&expenseModels).Error; err != nil {
+	query = query.Order(fmt.Sprintf("%s %s, expenses.id %s", column, direction, direction))
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	var expenseModels []model.Expense
+	if err := query.Preload("Shares").Find(&expenseModels).Error; err != nil {
 		return nil, err
 	}
 
@@ -56,15 +143,224 @@ func (er *ExpenseRepositoryImpl) GetExpense(ctx context.Context, householdID uin
 	return expenses, nil
 }
 
+// cursorValue はカーソルに保持した値を並び替え項目の型に変換します。
+func cursorValue(field expense.SortField, value string) (any, error) {
+	if field == expense.SortFieldAmount {
+		amount, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", expense.ErrInvalidCursor, err)
+		}
+		return amount, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", expense.ErrInvalidCursor, err)
+	}
+	return t, nil
+}
+
+// escapeLike は LIKE 検索のワイルドカード文字をエスケープします。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (er *ExpenseRepositoryImpl) UpdateExpense(ctx context.Context, e *expense.Expense) error {
 	expenseModel := toModelExpense(e)
-	return er.db.WithContext(ctx).Model(&model.Expense{}).Where("id = ?", e.ID.Value()).Updates(expenseModel).Error
+	return er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Expense{}).Omit("Shares").Where("id = ?", e.ID.Value()).Updates(expenseModel).Error; err != nil {
+			return err
+		}
+		// 負担額は全件置き換える
+		if err := tx.Where("expense_id = ?", e.ID.Value()).Delete(&model.ExpenseShare{}).Error; err != nil {
+			return err
+		}
+		if len(expenseModel.Shares) == 0 {
+			return nil
+		}
+		return tx.Create(&expenseModel.Shares).Error
+	})
 }
 
 func (er *ExpenseRepositoryImpl) DeleteExpense(ctx context.Context, expenseId expense.ExpenseID) error {
 	return er.db.WithContext(ctx).Where("id = ?", expenseId.Value()).Delete(&model.Expense{}).Error
 }
 
+func (er *ExpenseRepositoryImpl) GetExpense(ctx context.Context, householdID uint, from time.Time, to time.Time, category *string) ([]*expense.Expense, error) {
+	q := expense.Query{
+		HouseholdID: householdID,
+		From:        &from,
+		To:          &to,
+		SortField:   expense.SortFieldDate,
+		SortOrder:   expense.SortOrderAsc,
+	}
+	if category != nil && *category != "" {
+		q.Categories = []string{*category}
+	}
+	return er.Find(ctx, q)
+}
+
+func (er *ExpenseRepositoryImpl) GetCategoryTotals(ctx context.Context, householdID uint, from time.Time, to time.Time) (map[string]int, error) {
+	var rows []struct {
+		Category string
+		Total    int
+	}
+	err := er.db.WithContext(ctx).Table("expenses").
+		Select("expenses.category AS category, SUM(expenses.amount) AS total").
+		Where("expenses.household_id = ?", householdID).
+		Where("expenses.date >= ? AND expenses.date < ?", from, to.AddDate(0, 0, 1)).
+		Group("expenses.category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int, len(rows))
+	for _, row := range rows {
+		totals[row.Category] = row.Total
+	}
+	return totals, nil
+}
+
+func (er *ExpenseRepositoryImpl) GetMonthlyTotals(ctx context.Context, householdID uint, from time.Time, to time.Time, monthStartDay int) (map[int]int, error) {
+	var rows []struct {
+		Month int
+		Total int
+	}
+	err := er.db.WithContext(ctx).Table("expenses").
+		Select("EXTRACT(MONTH FROM ?)::int AS month, SUM(expenses.amount) AS total", fiscalMonthExpr("expenses.date", monthStartDay)).
+		Where("expenses.household_id = ?", householdID).
+		Where("expenses.date >= ? AND expenses.date < ?", from, to.AddDate(0, 0, 1)).
+		Group("month").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[int]int, len(rows))
+	for _, row := range rows {
+		totals[row.Month] = row.Total
+	}
+	return totals, nil
+}
+
+// localDateExpr は日付を保存しているUTCの0時をカレンダー上の日付として取り出すSQL式です。
+// データベースのセッションのタイムゾーンに左右されないよう、UTCとして解釈します。
+func localDateExpr(column string) clause.Expr {
+	return gorm.Expr(fmt.Sprintf("(%s AT TIME ZONE 'UTC')", column))
+}
+
+// fiscalMonthExpr は日付を月の始まりの日だけ前にずらし、その日付の年月が月を示すようにするSQL式です。
+func fiscalMonthExpr(column string, monthStartDay int) clause.Expr {
+	return gorm.Expr("? - make_interval(days => ?)", localDateExpr(column), max(monthStartDay, 1)-1)
+}
+
+// weekStartExpr は日付を週の始まりの日に切り捨てるSQL式です。
+// date_trunc は月曜日を週の始まりとするため、ずらして切り捨ててから戻します。
+func weekStartExpr(column string, calendar expense.Calendar) clause.Expr {
+	offset := (8 - int(calendar.WeekStart)) % 7
+	return gorm.Expr("date_trunc('week', ? + make_interval(days => ?)) - make_interval(days => ?)", localDateExpr(column), offset, offset)
+}
+
+// summaryColumns は集計単位ごとのキーと表示名のSQL式を返します。
+func summaryColumns(groupBy expense.SummaryGroupBy, calendar expense.Calendar) (clause.Expr, clause.Expr, bool) {
+	switch groupBy {
+	case expense.SummaryGroupByCategory:
+		return gorm.Expr("expenses.category"), gorm.Expr("expenses.category"), true
+	case expense.SummaryGroupByPayer:
+		return gorm.Expr("expenses.payer_id::text"), gorm.Expr("COALESCE(payer.name, '不明')"), true
+	case expense.SummaryGroupByStore:
+		return gorm.Expr("expenses.store_name"), gorm.Expr("expenses.store_name"), true
+	case expense.SummaryGroupByDay:
+		day := gorm.Expr("to_char(?, 'YYYY-MM-DD')", localDateExpr("expenses.date"))
+		return day, day, true
+	case expense.SummaryGroupByWeek:
+		week := gorm.Expr("to_char(?, 'YYYY-MM-DD')", weekStartExpr("expenses.date", calendar))
+		return week, week, true
+	case expense.SummaryGroupByMonth:
+		month := gorm.Expr("to_char(?, 'YYYY-MM')", fiscalMonthExpr("expenses.date", calendar.MonthStartDay))
+		return month, month, true
+	default:
+		return clause.Expr{}, clause.Expr{}, false
+	}
+}
+
+func (er *ExpenseRepositoryImpl) GetSummary(ctx context.Context, householdID uint, period expense.SummaryPeriod, groupBy expense.SummaryGroupBy, calendar expense.Calendar) ([]expense.SummaryRow, error) {
+	key, label, ok := summaryColumns(groupBy, calendar)
+	if !ok {
+		return nil, fmt.Errorf("unsupported group by: %s", groupBy)
+	}
+
+	query := er.db.WithContext(ctx).Table("expenses").
+		Select("? AS key, ? AS label, SUM(expenses.amount) AS total, COUNT(*) AS count", key, label).
+		Where("expenses.household_id = ?", householdID).
+		// date のインデックスが効くよう範囲条件で絞り込む
+		Where("expenses.date >= ? AND expenses.date < ?", period.From, period.To.AddDate(0, 0, 1))
+	if groupBy == expense.SummaryGroupByPayer {
+		// 退会したユーザーが支払った支出も集計に含める
+		query = query.Joins(`LEFT JOIN "user" AS payer ON payer.id = expenses.payer_id`)
+	}
+
+	// 式にパラメータを含むため、出力列の別名で集約する
+	query = query.Group("key").Group("label")
+	if groupBy.IsPeriod() {
+		query = query.Order("key")
+	} else {
+		query = query.Order("total DESC, key")
+	}
+
+	var rows []expense.SummaryRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (er *ExpenseRepositoryImpl) RenameCategory(ctx context.Context, categoryID uint, name string) error {
+	return er.db.WithContext(ctx).Model(&model.Expense{}).
+		Where("category_id = ?", categoryID).
+		Update("category", name).Error
+}
+
+func (er *ExpenseRepositoryImpl) Anonymize(ctx context.Context, householdID uint, userID uint) error {
+	return er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		householdExpenses := tx.Model(&model.Expense{}).Select("id").Where("household_id = ?", householdID)
+
+		if err := tx.Model(&model.Expense{}).
+			Where("household_id = ? AND user_id = ?", householdID, userID).
+			Update("user_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Expense{}).
+			Where("household_id = ? AND payer_id = ?", householdID, userID).
+			Update("payer_id", expense.FormerMemberID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.ExpenseShare{}).
+			Where("user_id = ? AND expense_id IN (?)", userID, householdExpenses).
+			Update("user_id", expense.FormerMemberID).Error
+	})
+}
+
+func (er *ExpenseRepositoryImpl) MoveToHousehold(ctx context.Context, fromHouseholdID uint, userID uint, toHouseholdID uint, categoryIDs map[uint]uint) error {
+	return er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userExpenses := tx.Model(&model.Expense{}).Select("id").Where("household_id = ? AND user_id = ?", fromHouseholdID, userID)
+		if err := tx.Where("expense_id IN (?)", userExpenses).Delete(&model.ExpenseShare{}).Error; err != nil {
+			return err
+		}
+		// カテゴリは世帯ごとのため、世帯を移す前に移動先のカテゴリに付け替える
+		for from, to := range categoryIDs {
+			if err := tx.Model(&model.Expense{}).
+				Where("household_id = ? AND user_id = ? AND category_id = ?", fromHouseholdID, userID, from).
+				Update("category_id", to).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&model.Expense{}).
+			Where("household_id = ? AND user_id = ?", fromHouseholdID, userID).
+			Updates(map[string]interface{}{"household_id": toHouseholdID, "payer_id": userID}).Error
+	})
+}
+
 func toDomainExpense(em *model.Expense) (*expense.Expense, error) {
 	if em == nil {
 		return nil, nil
@@ -86,18 +382,35 @@ func toDomainExpense(em *model.Expense) (*expense.Expense, error) {
 	if err != nil {
 		return nil, err
 	}
+	var shares []expense.Share
+	for _, sm := range em.Shares {
+		share, err := expense.NewShare(sm.UserID, sm.Amount)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+
+	var userID uint
+	if em.UserID != nil {
+		userID = *em.UserID
+	}
 
 	return &expense.Expense{
-		ID:        expense.ExpenseID(em.ID),
-		Amount:    amount,
-		StoreName: storeName,
-		Date:      em.Date,
-		Category:  category,
-		Memo:      memo,
-		CreatedAt: em.CreatedAt,
-		UpdatedAt: em.UpdatedAt,
-		UserID:    expense.UserID(em.UserID),
-		PayerID:   expense.PayerID(em.PayerID),
+		ID:                 expense.ExpenseID(em.ID),
+		HouseholdID:        em.HouseholdID,
+		Amount:             amount,
+		StoreName:          storeName,
+		Date:               em.Date,
+		Category:           category,
+		Memo:               memo,
+		CreatedAt:          em.CreatedAt,
+		UpdatedAt:          em.UpdatedAt,
+		UserID:             expense.UserID(userID),
+		PayerID:            expense.PayerID(em.PayerID),
+		Shares:             shares,
+		RecurringExpenseID: em.RecurringExpenseID,
+		CategoryID:         em.CategoryID,
 	}, nil
 }
 
@@ -105,16 +418,33 @@ func toModelExpense(e *expense.Expense) *model.Expense {
 	if e == nil {
 		return nil
 	}
+	var shares []model.ExpenseShare
+	for _, share := range e.Shares {
+		shares = append(shares, model.ExpenseShare{
+			ExpenseID: e.ID.Value(),
+			UserID:    uint(share.UserID),
+			Amount:    share.Amount.Value(),
+		})
+	}
+	var userID *uint
+	if e.UserID != 0 {
+		id := uint(e.UserID)
+		userID = &id
+	}
 	return &model.Expense{
-		ID:        e.ID.Value(),
-		Amount:    e.Amount.Value(),
-		StoreName: e.StoreName.Value(),
-		Date:      e.Date,
-		Category:  e.Category.Value(),
-		Memo:      e.Memo.Value(),
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
-		UserID:    uint(e.UserID),
-		PayerID:   uint(e.PayerID),
+		ID:                 e.ID.Value(),
+		HouseholdID:        e.HouseholdID,
+		Amount:             e.Amount.Value(),
+		StoreName:          e.StoreName.Value(),
+		Date:               e.Date,
+		Category:           e.Category.Value(),
+		Memo:               e.Memo.Value(),
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+		UserID:             userID,
+		PayerID:            uint(e.PayerID),
+		Shares:             shares,
+		RecurringExpenseID: e.RecurringExpenseID,
+		CategoryID:         e.CategoryID,
 	}
 }
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ household.InvitationRepository = (*HouseholdInvitationRepositoryImpl)(nil)
+
+type HouseholdInvitationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHouseholdInvitationRepositoryImpl(db *gorm.DB) household.InvitationRepository {
+	return &HouseholdInvitationRepositoryImpl{db}
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) Create(ctx context.Context, i *household.Invitation) error {
+	invitationModel := toModelInvitation(i)
+	if err := ir.db.WithContext(ctx).Create(invitationModel).Error; err != nil {
+		return err
+	}
+	i.ID = invitationModel.ID
+	return nil
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) FindByID(ctx context.Context, id uint) (*household.Invitation, error) {
+	return ir.findOne(ctx, "id = ?", id)
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) FindByCode(ctx context.Context, code string) (*household.Invitation, error) {
+	return ir.findOne(ctx, "code = ?", code)
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*household.Invitation, error) {
+	var invitationModel model.HouseholdInvitation
+	if err := ir.db.WithContext(ctx).Where(query, args...).First(&invitationModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainInvitation(&invitationModel)
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) FindByHouseholdID(ctx context.Context, householdID uint) ([]*household.Invitation, error) {
+	var invitationModels []model.HouseholdInvitation
+	if err := ir.db.WithContext(ctx).Where("household_id = ?", householdID).Order("created_at DESC, id DESC").Find(&invitationModels).Error; err != nil {
+		return nil, err
+	}
+
+	var invitations []*household.Invitation
+	for i := range invitationModels {
+		invitation, err := toDomainInvitation(&invitationModels[i])
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	return invitations, nil
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) Update(ctx context.Context, i *household.Invitation) error {
+	return ir.db.WithContext(ctx).Model(&model.HouseholdInvitation{}).Where("id = ?", i.ID).Updates(map[string]interface{}{
+		"revoked_at": i.RevokedAt,
+		"expires_at": i.ExpiresAt,
+		"max_uses":   i.MaxUses,
+	}).Error
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) Redeem(ctx context.Context, invitationID uint, userID uint, now time.Time) (bool, error) {
+	redeemed := false
+	err := ir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同時に参加された場合も上限を超えないよう、条件付きの更新で利用回数を増やす
+		result := tx.Model(&model.HouseholdInvitation{}).
+			Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND (max_uses = 0 OR uses < max_uses)", invitationID, now).
+			Update("uses", gorm.Expr("uses + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		redeemed = true
+		return tx.Create(&model.HouseholdInvitationRedemption{
+			InvitationID: invitationID,
+			UserID:       &userID,
+			JoinedAt:     now,
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return redeemed, nil
+}
+
+func (ir *HouseholdInvitationRepositoryImpl) FindRedemptionsByHouseholdID(ctx context.Context, householdID uint) ([]*household.Redemption, error) {
+	var redemptionModels []model.HouseholdInvitationRedemption
+	if err := ir.db.WithContext(ctx).
+		Joins("JOIN household_invitations ON household_invitations.id = household_invitation_redemptions.invitation_id").
+		Where("household_invitations.household_id = ?", householdID).
+		Order("household_invitation_redemptions.joined_at, household_invitation_redemptions.id").
+		Find(&redemptionModels).Error; err != nil {
+		return nil, err
+	}
+
+	var redemptions []*household.Redemption
+	for _, m := range redemptionModels {
+		redemption := &household.Redemption{
+			ID:           m.ID,
+			InvitationID: m.InvitationID,
+			JoinedAt:     m.JoinedAt,
+		}
+		if m.UserID != nil {
+			redemption.UserID = *m.UserID
+		}
+		redemptions = append(redemptions, redemption)
+	}
+	return redemptions, nil
+}
+
+func toDomainInvitation(m *model.HouseholdInvitation) (*household.Invitation, error) {
+	code, err := household.NewInviteCode(m.Code)
+	if err != nil {
+		return nil, err
+	}
+	role, err := household.NewRole(m.Role)
+	if err != nil {
+		return nil, err
+	}
+	invitation := &household.Invitation{
+		ID:             m.ID,
+		HouseholdID:    m.HouseholdID,
+		Code:           code,
+		Role:           role,
+		TargetEmail:    m.TargetEmail,
+		TargetProvider: m.TargetProvider,
+		TargetSubject:  m.TargetSubject,
+		MaxUses:        m.MaxUses,
+		Uses:           m.Uses,
+		ExpiresAt:      m.ExpiresAt,
+		RevokedAt:      m.RevokedAt,
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.CreatedByID != nil {
+		invitation.CreatedBy = *m.CreatedByID
+	}
+	return invitation, nil
+}
+
+func toModelInvitation(i *household.Invitation) *model.HouseholdInvitation {
+	invitationModel := &model.HouseholdInvitation{
+		ID:             i.ID,
+		HouseholdID:    i.HouseholdID,
+		Code:           i.Code.Value(),
+		Role:           i.Role.Value(),
+		TargetEmail:    i.TargetEmail,
+		TargetProvider: i.TargetProvider,
+		TargetSubject:  i.TargetSubject,
+		MaxUses:        i.MaxUses,
+		Uses:           i.Uses,
+		ExpiresAt:      i.ExpiresAt,
+		RevokedAt:      i.RevokedAt,
+		CreatedAt:      i.CreatedAt,
+	}
+	if i.CreatedBy != 0 {
+		createdBy := i.CreatedBy
+		invitationModel.CreatedByID = &createdBy
+	}
+	return invitationModel
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ household.MemberRepository = (*HouseholdMemberRepositoryImpl)(nil)
+
+type HouseholdMemberRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewHouseholdMemberRepositoryImpl(db *gorm.DB) household.MemberRepository {
+	return &HouseholdMemberRepositoryImpl{db}
+}
+
+func (mr *HouseholdMemberRepositoryImpl) FindByUserID(ctx context.Context, userID uint) (*household.Member, error) {
+	var memberModel model.HouseholdMember
+	if err := mr.db.WithContext(ctx).Where("user_id = ?", userID).First(&memberModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainMember(&memberModel)
+}
+
+func (mr *HouseholdMemberRepositoryImpl) FindByHouseholdID(ctx context.Context, householdID uint) ([]*household.Member, error) {
+	var memberModels []model.HouseholdMember
+	if err := mr.db.WithContext(ctx).Where("household_id = ?", householdID).Order("joined_at, id").Find(&memberModels).Error; err != nil {
+		return nil, err
+	}
+
+	var members []*household.Member
+	for i := range memberModels {
+		member, err := toDomainMember(&memberModels[i])
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (mr *HouseholdMemberRepositoryImpl) Save(ctx context.Context, m *household.Member) error {
+	memberModel := &model.HouseholdMember{
+		HouseholdID: m.HouseholdID,
+		UserID:      m.UserID,
+		Role:        m.Role.Value(),
+		JoinedAt:    m.JoinedAt,
+	}
+	return mr.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"household_id", "role", "joined_at"}),
+	}).Create(memberModel).Error
+}
+
+func toDomainMember(m *model.HouseholdMember) (*household.Member, error) {
+	role, err := household.NewRole(m.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &household.Member{
+		HouseholdID: m.HouseholdID,
+		UserID:      m.UserID,
+		Role:        role,
+		JoinedAt:    m.JoinedAt,
+	}, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/yanatoritakuma/budget/back/domain/household"
@@ -25,19 +26,7 @@ func NewHouseholdRepositoryImpl(db *gorm.DB) household.HouseholdRepository {
 // FindByID finds a household by ID.
 func (repo *HouseholdRepositoryImpl) FindByID(ctx context.Context, id uint) (*household.Household, error) {
 	var householdModel model.Household
-	if err := repo.db.WithContext(ctx).First(&householdModel, id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil // Household not found
-		}
-		return nil, err
-	}
-	return toDomainHousehold(&householdModel)
-}
-
-// FindByInviteCode finds a household by invite code.
-func (repo *HouseholdRepositoryImpl) FindByInviteCode(ctx context.Context, inviteCode string) (*household.Household, error) {
-	var householdModel model.Household
-	if err := repo.db.WithContext(ctx).Where("invite_code = ?", inviteCode).First(&householdModel).Error; err != nil {
+	if err := repo.db.WithContext(ctx).Preload("SplitShares").First(&householdModel, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil // Household not found
 		}
@@ -62,7 +51,24 @@ func (repo *HouseholdRepositoryImpl) Create(ctx context.Context, householdEntity
 func (repo *HouseholdRepositoryImpl) Update(ctx context.Context, householdEntity *household.Household) error {
 	householdModel := toModelHousehold(householdEntity)
 	householdModel.UpdatedAt = time.Now() // Ensure updated_at is current
-	return repo.db.WithContext(ctx).Save(householdModel).Error
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("SplitShares").Save(householdModel).Error; err != nil {
+			return err
+		}
+		// 分担割合は全件置き換える
+		if err := tx.Where("household_id = ?", householdModel.ID).Delete(&model.HouseholdSplitShare{}).Error; err != nil {
+			return err
+		}
+		if len(householdModel.SplitShares) == 0 {
+			return nil
+		}
+		return tx.Create(&householdModel.SplitShares).Error
+	})
+}
+
+// Delete deletes a household and the records that belong to it.
+func (repo *HouseholdRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	return repo.db.WithContext(ctx).Delete(&model.Household{}, id).Error
 }
 
 func toDomainHousehold(h *model.Household) (*household.Household, error) {
@@ -74,17 +80,26 @@ func toDomainHousehold(h *model.Household) (*household.Household, error) {
 	if err != nil {
 		return nil, err
 	}
-	inviteCode, err := household.NewInviteCode(h.InviteCode)
+	shares := make([]household.SplitShare, 0, len(h.SplitShares))
+	for _, share := range h.SplitShares {
+		shares = append(shares, household.SplitShare{UserID: share.UserID, Percent: share.Percent})
+	}
+	splitRule, err := household.NewSplitRule(h.SplitMethod, shares)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := household.NewSettings(h.Currency, h.Timezone, h.MonthStartDay, strings.ToLower(time.Weekday(h.WeekStart).String()))
 	if err != nil {
 		return nil, err
 	}
 
 	return &household.Household{
-		ID:         household.HouseholdID(h.ID),
-		Name:       name,
-		InviteCode: inviteCode,
-		CreatedAt:  h.CreatedAt,
-		UpdatedAt:  h.UpdatedAt,
+		ID:        household.HouseholdID(h.ID),
+		Name:      name,
+		SplitRule: splitRule,
+		Settings:  settings,
+		CreatedAt: h.CreatedAt,
+		UpdatedAt: h.UpdatedAt,
 	}, nil
 }
 
@@ -92,11 +107,24 @@ func toModelHousehold(h *household.Household) *model.Household {
 	if h == nil {
 		return nil
 	}
+	shares := make([]model.HouseholdSplitShare, 0, len(h.SplitRule.Shares))
+	for _, share := range h.SplitRule.Shares {
+		shares = append(shares, model.HouseholdSplitShare{
+			HouseholdID: h.ID.Value(),
+			UserID:      share.UserID,
+			Percent:     share.Percent,
+		})
+	}
 	return &model.Household{
-		ID:         h.ID.Value(),
-		Name:       h.Name.Value(),
-		InviteCode: h.InviteCode.Value(),
-		CreatedAt:  h.CreatedAt,
-		UpdatedAt:  h.UpdatedAt,
+		ID:            h.ID.Value(),
+		Name:          h.Name.Value(),
+		SplitMethod:   h.SplitRule.Method.Value(),
+		SplitShares:   shares,
+		Currency:      h.Settings.Currency,
+		Timezone:      h.Settings.Timezone,
+		MonthStartDay: h.Settings.MonthStartDay,
+		WeekStart:     int(h.Settings.WeekStart),
+		CreatedAt:     h.CreatedAt,
+		UpdatedAt:     h.UpdatedAt,
 	}
 }
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ identity.IdentityRepository = (*IdentityRepositoryImpl)(nil)
+
+type IdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdentityRepositoryImpl(db *gorm.DB) identity.IdentityRepository {
+	return &IdentityRepositoryImpl{db}
+}
+
+func (ir *IdentityRepositoryImpl) FindByProviderSubject(ctx context.Context, provider, subject string) (*identity.Identity, error) {
+	var identityModel model.UserIdentity
+	if err := ir.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&identityModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainIdentity(&identityModel), nil
+}
+
+func (ir *IdentityRepositoryImpl) FindByUserID(ctx context.Context, userID uint) ([]*identity.Identity, error) {
+	var identityModels []model.UserIdentity
+	if err := ir.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at, id").Find(&identityModels).Error; err != nil {
+		return nil, err
+	}
+
+	var identities []*identity.Identity
+	for i := range identityModels {
+		identities = append(identities, toDomainIdentity(&identityModels[i]))
+	}
+	return identities, nil
+}
+
+func (ir *IdentityRepositoryImpl) Create(ctx context.Context, i *identity.Identity) error {
+	identityModel := &model.UserIdentity{
+		UserID:    i.UserID,
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		CreatedAt: i.CreatedAt,
+	}
+	if err := ir.db.WithContext(ctx).Create(identityModel).Error; err != nil {
+		return err
+	}
+	i.ID = identityModel.ID
+	return nil
+}
+
+func (ir *IdentityRepositoryImpl) Delete(ctx context.Context, userID uint, id uint) (bool, error) {
+	result := ir.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.UserIdentity{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func toDomainIdentity(m *model.UserIdentity) *identity.Identity {
+	return &identity.Identity{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Provider:  m.Provider,
+		Subject:   m.Subject,
+		CreatedAt: m.CreatedAt,
+	}
+}
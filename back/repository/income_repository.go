@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/income"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ income.IncomeRepository = (*IncomeRepositoryImpl)(nil)
+
+type IncomeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIncomeRepositoryImpl(db *gorm.DB) income.IncomeRepository {
+	return &IncomeRepositoryImpl{db}
+}
+
+func (ir *IncomeRepositoryImpl) CreateIncome(ctx context.Context, i *income.Income) error {
+	incomeModel := toModelIncome(i)
+	if err := ir.db.WithContext(ctx).Create(incomeModel).Error; err != nil {
+		return err
+	}
+	i.ID = income.IncomeID(incomeModel.ID)
+	i.CreatedAt = incomeModel.CreatedAt
+	i.UpdatedAt = incomeModel.UpdatedAt
+	return nil
+}
+
+func (ir *IncomeRepositoryImpl) FindByID(ctx context.Context, id uint) (*income.Income, error) {
+	var incomeModel model.Income
+	if err := ir.db.WithContext(ctx).First(&incomeModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // Income not found
+		}
+		return nil, err
+	}
+	return toDomainIncome(&incomeModel)
+}
+
+func (ir *IncomeRepositoryImpl) GetIncome(ctx context.Context, householdID uint, from time.Time, to time.Time, category *string) ([]*income.Income, error) {
+	var incomeModels []model.Income
+	query := ir.db.WithContext(ctx).Table("incomes").
+		Joins(`JOIN "user" ON "user".id = incomes.user_id`).
+		Where(`"user".household_id = ?`, householdID).
+		Where("incomes.date >= ? AND incomes.date < ?", from, to.AddDate(0, 0, 1))
+
+	if category != nil && *category != "" {
+		query = query.Where("category = ?", *category)
+	}
+
+	if err := query.Order("date").Find(&incomeModels).Error; err != nil {
+		return nil, err
+	}
+
+	var incomes []*income.Income
+	for i := range incomeModels {
+		domainIncome, err := toDomainIncome(&incomeModels[i])
+		if err != nil {
+			return nil, err
+		}
+		incomes = append(incomes, domainIncome)
+	}
+
+	return incomes, nil
+}
+
+func (ir *IncomeRepositoryImpl) UpdateIncome(ctx context.Context, i *income.Income) error {
+	incomeModel := toModelIncome(i)
+	return ir.db.WithContext(ctx).Model(&model.Income{}).Where("id = ?", i.ID.Value()).Updates(incomeModel).Error
+}
+
+func (ir *IncomeRepositoryImpl) DeleteIncome(ctx context.Context, incomeId income.IncomeID) error {
+	return ir.db.WithContext(ctx).Where("id = ?", incomeId.Value()).Delete(&model.Income{}).Error
+}
+
+func (ir *IncomeRepositoryImpl) GetMonthlyTotals(ctx context.Context, householdID uint, from time.Time, to time.Time, monthStartDay int) (map[int]int, error) {
+	var rows []struct {
+		Month int
+		Total int
+	}
+	err := ir.db.WithContext(ctx).Table("incomes").
+		Select("EXTRACT(MONTH FROM (incomes.date AT TIME ZONE 'UTC') - make_interval(days => ?))::int AS month, SUM(incomes.amount) AS total", max(monthStartDay, 1)-1).
+		Joins(`JOIN "user" ON "user".id = incomes.user_id`).
+		Where(`"user".household_id = ?`, householdID).
+		Where("incomes.date >= ? AND incomes.date < ?", from, to.AddDate(0, 0, 1)).
+		Group("month").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[int]int, len(rows))
+	for _, row := range rows {
+		totals[row.Month] = row.Total
+	}
+	return totals, nil
+}
+
+func (ir *IncomeRepositoryImpl) ReassignCreator(ctx context.Context, fromUserID uint, toUserID uint) error {
+	return ir.db.WithContext(ctx).Model(&model.Income{}).
+		Where("user_id = ?", fromUserID).
+		Update("user_id", toUserID).Error
+}
+
+func (ir *IncomeRepositoryImpl) AnonymizeRecipient(ctx context.Context, householdID uint, userID uint) error {
+	householdUsers := ir.db.WithContext(ctx).Model(&model.User{}).Select("id").Where("household_id = ?", householdID)
+	return ir.db.WithContext(ctx).Model(&model.Income{}).
+		Where("recipient_id = ? AND user_id IN (?)", userID, householdUsers).
+		Update("recipient_id", 0).Error
+}
+
+func (ir *IncomeRepositoryImpl) MakePersonal(ctx context.Context, userID uint) error {
+	return ir.db.WithContext(ctx).Model(&model.Income{}).
+		Where("user_id = ?", userID).
+		Update("recipient_id", userID).Error
+}
+
+func toDomainIncome(im *model.Income) (*income.Income, error) {
+	if im == nil {
+		return nil, nil
+	}
+
+	amount, err := income.NewAmount(im.Amount)
+	if err != nil {
+		return nil, err
+	}
+	source, err := income.NewSource(im.Source)
+	if err != nil {
+		return nil, err
+	}
+	category, err := income.NewCategory(im.Category)
+	if err != nil {
+		return nil, err
+	}
+	memo, err := income.NewMemo(im.Memo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &income.Income{
+		ID:          income.IncomeID(im.ID),
+		Amount:      amount,
+		Source:      source,
+		Date:        im.Date,
+		Category:    category,
+		Memo:        memo,
+		CreatedAt:   im.CreatedAt,
+		UpdatedAt:   im.UpdatedAt,
+		UserID:      income.UserID(im.UserID),
+		RecipientID: income.RecipientID(im.RecipientID),
+	}, nil
+}
+
+func toModelIncome(i *income.Income) *model.Income {
+	if i == nil {
+		return nil
+	}
+	return &model.Income{
+		ID:          i.ID.Value(),
+		Amount:      i.Amount.Value(),
+		Source:      i.Source.Value(),
+		Date:        i.Date,
+		Category:    i.Category.Value(),
+		Memo:        i.Memo.Value(),
+		CreatedAt:   i.CreatedAt,
+		UpdatedAt:   i.UpdatedAt,
+		UserID:      uint(i.UserID),
+		RecipientID: uint(i.RecipientID),
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/mfa"
+	"github.com/yanatoritakuma/budget/back/model"
+	"github.com/yanatoritakuma/budget/back/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ mfa.MFARepository = (*MFARepositoryImpl)(nil)
+
+type MFARepositoryImpl struct {
+	db *gorm.DB
+	// encryptionKey はTOTPシークレットの暗号化に使用する鍵です。
+	encryptionKey []byte
+}
+
+func NewMFARepositoryImpl(db *gorm.DB, encryptionKey []byte) mfa.MFARepository {
+	return &MFARepositoryImpl{db: db, encryptionKey: encryptionKey}
+}
+
+func (mr *MFARepositoryImpl) FindTOTPByUserID(ctx context.Context, userID uint) (*mfa.TOTPCredential, error) {
+	var credentialModel model.TOTPCredential
+	if err := mr.db.WithContext(ctx).Where("user_id = ?", userID).First(&credentialModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	secret, err := utils.DecryptString(mr.encryptionKey, credentialModel.SecretEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	return &mfa.TOTPCredential{
+		UserID:       credentialModel.UserID,
+		Secret:       secret,
+		ConfirmedAt:  credentialModel.ConfirmedAt,
+		LastUsedStep: credentialModel.LastUsedStep,
+		CreatedAt:    credentialModel.CreatedAt,
+		UpdatedAt:    credentialModel.UpdatedAt,
+	}, nil
+}
+
+func (mr *MFARepositoryImpl) SaveTOTP(ctx context.Context, c *mfa.TOTPCredential) error {
+	secretEncrypted, err := utils.EncryptString(mr.encryptionKey, c.Secret)
+	if err != nil {
+		return err
+	}
+	credentialModel := &model.TOTPCredential{
+		UserID:          c.UserID,
+		SecretEncrypted: secretEncrypted,
+		ConfirmedAt:     c.ConfirmedAt,
+		LastUsedStep:    c.LastUsedStep,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+	return mr.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret_encrypted", "confirmed_at", "last_used_step", "created_at", "updated_at"}),
+	}).Create(credentialModel).Error
+}
+
+func (mr *MFARepositoryImpl) UseTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	// 同じコードによる同時ログインは先に記録した方のみ成功させる
+	result := mr.db.WithContext(ctx).Model(&model.TOTPCredential{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (mr *MFARepositoryImpl) DeleteByUserID(ctx context.Context, userID uint) error {
+	return mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&model.TOTPCredential{}).Error
+	})
+}
+
+func (mr *MFARepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	return mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]model.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, model.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (mr *MFARepositoryImpl) UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	result := mr.db.WithContext(ctx).Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (mr *MFARepositoryImpl) CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int, error) {
+	var count int64
+	if err := mr.db.WithContext(ctx).Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
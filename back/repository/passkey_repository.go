@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yanatoritakuma/budget/back/domain/passkey"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ passkey.CredentialRepository = (*PasskeyRepositoryImpl)(nil)
+
+type PasskeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPasskeyRepositoryImpl(db *gorm.DB) passkey.CredentialRepository {
+	return &PasskeyRepositoryImpl{db}
+}
+
+func (pr *PasskeyRepositoryImpl) Create(ctx context.Context, c *passkey.Credential) error {
+	credentialModel := toModelPasskey(c)
+	if err := pr.db.WithContext(ctx).Create(credentialModel).Error; err != nil {
+		return err
+	}
+	c.ID = credentialModel.ID
+	return nil
+}
+
+func (pr *PasskeyRepositoryImpl) FindByCredentialID(ctx context.Context, credentialID []byte) (*passkey.Credential, error) {
+	var credentialModel model.PasskeyCredential
+	if err := pr.db.WithContext(ctx).Where("credential_id = ?", credentialID).First(&credentialModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainPasskey(&credentialModel), nil
+}
+
+func (pr *PasskeyRepositoryImpl) FindByUserID(ctx context.Context, userID uint) ([]*passkey.Credential, error) {
+	var credentialModels []model.PasskeyCredential
+	if err := pr.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at, id").Find(&credentialModels).Error; err != nil {
+		return nil, err
+	}
+
+	var credentials []*passkey.Credential
+	for i := range credentialModels {
+		credentials = append(credentials, toDomainPasskey(&credentialModels[i]))
+	}
+	return credentials, nil
+}
+
+func (pr *PasskeyRepositoryImpl) UpdateUsage(ctx context.Context, c *passkey.Credential) error {
+	return pr.db.WithContext(ctx).Model(&model.PasskeyCredential{}).Where("id = ?", c.ID).Updates(map[string]any{
+		"sign_count":   int64(c.SignCount),
+		"last_used_at": c.LastUsedAt,
+	}).Error
+}
+
+func (pr *PasskeyRepositoryImpl) Delete(ctx context.Context, userID uint, id uint) (bool, error) {
+	result := pr.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&model.PasskeyCredential{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func toDomainPasskey(cm *model.PasskeyCredential) *passkey.Credential {
+	var transports []string
+	if cm.Transports != "" {
+		transports = strings.Split(cm.Transports, ",")
+	}
+	return &passkey.Credential{
+		ID:           cm.ID,
+		UserID:       cm.UserID,
+		CredentialID: cm.CredentialID,
+		PublicKey:    cm.PublicKey,
+		SignCount:    uint32(cm.SignCount),
+		AAGUID:       cm.AAGUID,
+		Transports:   transports,
+		Name:         cm.Name,
+		CreatedAt:    cm.CreatedAt,
+		LastUsedAt:   cm.LastUsedAt,
+	}
+}
+
+func toModelPasskey(c *passkey.Credential) *model.PasskeyCredential {
+	return &model.PasskeyCredential{
+		ID:           c.ID,
+		UserID:       c.UserID,
+		CredentialID: c.CredentialID,
+		PublicKey:    c.PublicKey,
+		SignCount:    int64(c.SignCount),
+		AAGUID:       c.AAGUID,
+		Transports:   strings.Join(c.Transports, ","),
+		Name:         c.Name,
+		CreatedAt:    c.CreatedAt,
+		LastUsedAt:   c.LastUsedAt,
+	}
+}
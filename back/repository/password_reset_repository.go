@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/passwordreset"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ passwordreset.TokenRepository = (*PasswordResetTokenRepositoryImpl)(nil)
+
+type PasswordResetTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepositoryImpl(db *gorm.DB) passwordreset.TokenRepository {
+	return &PasswordResetTokenRepositoryImpl{db}
+}
+
+func (pr *PasswordResetTokenRepositoryImpl) Create(ctx context.Context, t *passwordreset.Token) error {
+	tokenModel := toModelPasswordResetToken(t)
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 再発行した場合は以前のトークンを使えなくする
+		if err := tx.Model(&model.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", t.UserID).
+			Update("used_at", t.CreatedAt).Error; err != nil {
+			return err
+		}
+		return tx.Create(tokenModel).Error
+	})
+	if err != nil {
+		return err
+	}
+	t.ID = tokenModel.ID
+	return nil
+}
+
+func (pr *PasswordResetTokenRepositoryImpl) FindByTokenHash(ctx context.Context, hash string) (*passwordreset.Token, error) {
+	var tokenModel model.PasswordResetToken
+	if err := pr.db.WithContext(ctx).Where("token_hash = ?", hash).First(&tokenModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainPasswordResetToken(&tokenModel), nil
+}
+
+func (pr *PasswordResetTokenRepositoryImpl) MarkUsed(ctx context.Context, id uint, usedAt time.Time) (bool, error) {
+	// 同じトークンによる同時リクエストは先に更新した方のみ成功させる
+	result := pr.db.WithContext(ctx).Model(&model.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func toDomainPasswordResetToken(tm *model.PasswordResetToken) *passwordreset.Token {
+	return &passwordreset.Token{
+		ID:        tm.ID,
+		UserID:    tm.UserID,
+		TokenHash: tm.TokenHash,
+		CreatedAt: tm.CreatedAt,
+		ExpiresAt: tm.ExpiresAt,
+		UsedAt:    tm.UsedAt,
+	}
+}
+
+func toModelPasswordResetToken(t *passwordreset.Token) *model.PasswordResetToken {
+	return &model.PasswordResetToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		TokenHash: t.TokenHash,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+		UsedAt:    t.UsedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/recurring"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ recurring.RecurringExpenseRepository = (*RecurringExpenseRepositoryImpl)(nil)
+
+// RecurringExpenseRepositoryImpl implements recurring.RecurringExpenseRepository using GORM.
+type RecurringExpenseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRecurringExpenseRepositoryImpl creates a new RecurringExpenseRepositoryImpl.
+func NewRecurringExpenseRepositoryImpl(db *gorm.DB) recurring.RecurringExpenseRepository {
+	return &RecurringExpenseRepositoryImpl{db: db}
+}
+
+// Create creates a new recurring expense.
+func (repo *RecurringExpenseRepositoryImpl) Create(ctx context.Context, r *recurring.RecurringExpense) error {
+	recurringModel := toModelRecurringExpense(r)
+	if err := repo.db.WithContext(ctx).Create(recurringModel).Error; err != nil {
+		return err
+	}
+	r.ID = recurring.RecurringExpenseID(recurringModel.ID)
+	r.CreatedAt = recurringModel.CreatedAt
+	r.UpdatedAt = recurringModel.UpdatedAt
+	return nil
+}
+
+// FindByID finds a recurring expense by ID.
+func (repo *RecurringExpenseRepositoryImpl) FindByID(ctx context.Context, id uint) (*recurring.RecurringExpense, error) {
+	var recurringModel model.RecurringExpense
+	if err := repo.db.WithContext(ctx).First(&recurringModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // Recurring expense not found
+		}
+		return nil, err
+	}
+	return toDomainRecurringExpense(&recurringModel)
+}
+
+// FindByHouseholdID finds recurring expenses by household ID.
+func (repo *RecurringExpenseRepositoryImpl) FindByHouseholdID(ctx context.Context, householdID uint) ([]*recurring.RecurringExpense, error) {
+	var recurringModels []model.RecurringExpense
+	if err := repo.db.WithContext(ctx).Where("household_id = ?", householdID).Order("id").Find(&recurringModels).Error; err != nil {
+		return nil, err
+	}
+	return toDomainRecurringExpenses(recurringModels)
+}
+
+// FindActive finds all active recurring expenses.
+func (repo *RecurringExpenseRepositoryImpl) FindActive(ctx context.Context) ([]*recurring.RecurringExpense, error) {
+	var recurringModels []model.RecurringExpense
+	if err := repo.db.WithContext(ctx).Where("active = ?", true).Order("id").Find(&recurringModels).Error; err != nil {
+		return nil, err
+	}
+	return toDomainRecurringExpenses(recurringModels)
+}
+
+// Update updates an existing recurring expense.
+func (repo *RecurringExpenseRepositoryImpl) Update(ctx context.Context, r *recurring.RecurringExpense) error {
+	recurringModel := toModelRecurringExpense(r)
+	recurringModel.UpdatedAt = time.Now()
+	return repo.db.WithContext(ctx).Omit("Household").Save(recurringModel).Error
+}
+
+// Delete deletes a recurring expense by ID.
+func (repo *RecurringExpenseRepositoryImpl) Delete(ctx context.Context, id recurring.RecurringExpenseID) error {
+	return repo.db.WithContext(ctx).Delete(&model.RecurringExpense{}, id.Value()).Error
+}
+
+func (repo *RecurringExpenseRepositoryImpl) RenameCategory(ctx context.Context, householdID uint, oldName string, newName string) error {
+	return repo.db.WithContext(ctx).Model(&model.RecurringExpense{}).
+		Where("household_id = ? AND category = ?", householdID, oldName).
+		Update("category", newName).Error
+}
+
+func (repo *RecurringExpenseRepositoryImpl) ReassignMember(ctx context.Context, householdID uint, fromUserID uint, toUserID uint) error {
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.RecurringExpense{}).
+			Where("household_id = ? AND user_id = ?", householdID, fromUserID).
+			Update("user_id", toUserID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.RecurringExpense{}).
+			Where("household_id = ? AND payer_id = ?", householdID, fromUserID).
+			Update("payer_id", toUserID).Error
+	})
+}
+
+func toDomainRecurringExpenses(recurringModels []model.RecurringExpense) ([]*recurring.RecurringExpense, error) {
+	var recurringExpenses []*recurring.RecurringExpense
+	for i := range recurringModels {
+		domainRecurring, err := toDomainRecurringExpense(&recurringModels[i])
+		if err != nil {
+			return nil, err
+		}
+		recurringExpenses = append(recurringExpenses, domainRecurring)
+	}
+	return recurringExpenses, nil
+}
+
+func toDomainRecurringExpense(rm *model.RecurringExpense) (*recurring.RecurringExpense, error) {
+	if rm == nil {
+		return nil, nil
+	}
+
+	amount, err := expense.NewAmount(rm.Amount)
+	if err != nil {
+		return nil, err
+	}
+	storeName, err := expense.NewStoreName(rm.StoreName)
+	if err != nil {
+		return nil, err
+	}
+	category, err := expense.NewCategory(rm.Category)
+	if err != nil {
+		return nil, err
+	}
+	memo, err := expense.NewMemo(rm.Memo)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := recurring.NewRule(rm.Frequency, rm.Day, rm.Weekday, rm.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recurring.RecurringExpense{
+		ID:                recurring.RecurringExpenseID(rm.ID),
+		HouseholdID:       rm.HouseholdID,
+		UserID:            rm.UserID,
+		PayerID:           rm.PayerID,
+		Amount:            amount,
+		StoreName:         storeName,
+		Category:          category,
+		Memo:              memo,
+		Rule:              rule,
+		StartDate:         rm.StartDate.UTC(),
+		EndDate:           toUTCPtr(rm.EndDate),
+		LastGeneratedDate: toUTCPtr(rm.LastGeneratedDate),
+		Active:            rm.Active,
+		CreatedAt:         rm.CreatedAt,
+		UpdatedAt:         rm.UpdatedAt,
+	}, nil
+}
+
+func toModelRecurringExpense(r *recurring.RecurringExpense) *model.RecurringExpense {
+	if r == nil {
+		return nil
+	}
+	return &model.RecurringExpense{
+		ID:                r.ID.Value(),
+		HouseholdID:       r.HouseholdID,
+		UserID:            r.UserID,
+		PayerID:           r.PayerID,
+		Amount:            r.Amount.Value(),
+		StoreName:         r.StoreName.Value(),
+		Category:          r.Category.Value(),
+		Memo:              r.Memo.Value(),
+		Frequency:         string(r.Rule.Frequency),
+		Day:               r.Rule.Day,
+		Weekday:           int(r.Rule.Weekday),
+		Month:             int(r.Rule.Month),
+		StartDate:         r.StartDate,
+		EndDate:           r.EndDate,
+		LastGeneratedDate: r.LastGeneratedDate,
+		Active:            r.Active,
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
+
+// toUTCPtr は日付をUTCに揃えて返します。
+func toUTCPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
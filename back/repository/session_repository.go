@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/session"
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+)
+
+var _ session.SessionRepository = (*SessionRepositoryImpl)(nil)
+
+type SessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSessionRepositoryImpl(db *gorm.DB) session.SessionRepository {
+	return &SessionRepositoryImpl{db}
+}
+
+func (sr *SessionRepositoryImpl) Create(ctx context.Context, s *session.Session) error {
+	sessionModel := toModelSession(s)
+	if err := sr.db.WithContext(ctx).Create(sessionModel).Error; err != nil {
+		return err
+	}
+	s.ID = sessionModel.ID
+	return nil
+}
+
+func (sr *SessionRepositoryImpl) FindByID(ctx context.Context, id uint) (*session.Session, error) {
+	var sessionModel model.Session
+	if err := sr.db.WithContext(ctx).First(&sessionModel, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainSession(&sessionModel), nil
+}
+
+func (sr *SessionRepositoryImpl) FindByRefreshTokenHash(ctx context.Context, hash string) (*session.Session, error) {
+	var sessionModel model.Session
+	if err := sr.db.WithContext(ctx).
+		Where("refresh_token_hash = ? OR previous_refresh_token_hash = ?", hash, hash).
+		First(&sessionModel).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toDomainSession(&sessionModel), nil
+}
+
+func (sr *SessionRepositoryImpl) FindActiveByUserID(ctx context.Context, userID uint) ([]*session.Session, error) {
+	var sessionModels []model.Session
+	if err := sr.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC, id DESC").
+		Find(&sessionModels).Error; err != nil {
+		return nil, err
+	}
+
+	var sessions []*session.Session
+	for i := range sessionModels {
+		sessions = append(sessions, toDomainSession(&sessionModels[i]))
+	}
+	return sessions, nil
+}
+
+func (sr *SessionRepositoryImpl) Update(ctx context.Context, s *session.Session) error {
+	return sr.db.WithContext(ctx).Model(&model.Session{}).Where("id = ?", s.ID).Updates(sessionColumns(s)).Error
+}
+
+func (sr *SessionRepositoryImpl) Rotate(ctx context.Context, s *session.Session, currentHash string) (bool, error) {
+	// 同じリフレッシュトークンによる同時更新は先に更新した方のみ成功させる
+	result := sr.db.WithContext(ctx).Model(&model.Session{}).
+		Where("id = ? AND refresh_token_hash = ?", s.ID, currentHash).
+		Updates(sessionColumns(s))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func sessionColumns(s *session.Session) map[string]any {
+	return map[string]any{
+		"refresh_token_hash":          s.RefreshTokenHash,
+		"previous_refresh_token_hash": s.PreviousRefreshTokenHash,
+		"ip_address":                  s.IPAddress,
+		"user_agent":                  s.UserAgent,
+		"last_used_at":                s.LastUsedAt,
+		"rotated_at":                  s.RotatedAt,
+		"expires_at":                  s.ExpiresAt,
+		"revoked_at":                  s.RevokedAt,
+	}
+}
+
+func (sr *SessionRepositoryImpl) RevokeByUserID(ctx context.Context, userID uint) error {
+	return sr.db.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (sr *SessionRepositoryImpl) RevokeOthersByUserID(ctx context.Context, userID uint, exceptID uint) error {
+	return sr.db.WithContext(ctx).Model(&model.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func toDomainSession(sm *model.Session) *session.Session {
+	return &session.Session{
+		ID:                       sm.ID,
+		UserID:                   sm.UserID,
+		RefreshTokenHash:         sm.RefreshTokenHash,
+		PreviousRefreshTokenHash: sm.PreviousRefreshTokenHash,
+		DeviceName:               sm.DeviceName,
+		IPAddress:                sm.IPAddress,
+		UserAgent:                sm.UserAgent,
+		CreatedAt:                sm.CreatedAt,
+		LastUsedAt:               sm.LastUsedAt,
+		RotatedAt:                sm.RotatedAt,
+		ExpiresAt:                sm.ExpiresAt,
+		RevokedAt:                sm.RevokedAt,
+	}
+}
+
+func toModelSession(s *session.Session) *model.Session {
+	return &model.Session{
+		ID:                       s.ID,
+		UserID:                   s.UserID,
+		RefreshTokenHash:         s.RefreshTokenHash,
+		PreviousRefreshTokenHash: s.PreviousRefreshTokenHash,
+		DeviceName:               s.DeviceName,
+		IPAddress:                s.IPAddress,
+		UserAgent:                s.UserAgent,
+		CreatedAt:                s.CreatedAt,
+		LastUsedAt:               s.LastUsedAt,
+		RotatedAt:                s.RotatedAt,
+		ExpiresAt:                s.ExpiresAt,
+		RevokedAt:                s.RevokedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"crypto/subtle"
+	"sync"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tokenSweepInterval は期限切れトークンを掃除する間隔です。
+const tokenSweepInterval = 10 * time.Minute
+
+var _ model.TokenStore = (*PostgresTokenStore)(nil)
+
+// PostgresTokenStore はトークンをPostgreSQLに保存するストアです。
+// Lambdaの複数インスタンス間でトークンを共有できます。
+type PostgresTokenStore struct {
+	db        *gorm.DB
+	mutex     sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresTokenStore(db *gorm.DB) *PostgresTokenStore {
+	return &PostgresTokenStore{db: db}
+}
+
+func (ts *PostgresTokenStore) IssueToken(ctx context.Context, sessionID string, ttl time.Duration) (string, error) {
+	ts.sweepExpired(ctx)
+
+	token, err := model.GenerateTokenValue()
+	if err != nil {
+		return "", err
+	}
+	record := model.TokenRecord{
+		SessionID: sessionID,
+		Token:     token,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := ts.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "expires_at", "created_at"}),
+	}).Create(&record).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (ts *PostgresTokenStore) GetToken(ctx context.Context, sessionID string) (string, bool, error) {
+	var record model.TokenRecord
+	err := ts.db.WithContext(ctx).
+		Where("session_id = ? AND expires_at > ?", sessionID, time.Now()).
+		First(&record).Error
+	if err == gorm.ErrRecordNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return record.Token, true, nil
+}
+
+func (ts *PostgresTokenStore) ValidateToken(ctx context.Context, sessionID, token string) (bool, error) {
+	storedToken, exists, err := ts.GetToken(ctx, sessionID)
+	if err != nil || !exists {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(storedToken), []byte(token)) == 1, nil
+}
+
+func (ts *PostgresTokenStore) DeleteToken(ctx context.Context, sessionID string) error {
+	return ts.db.WithContext(ctx).Where("session_id = ?", sessionID).Delete(&model.TokenRecord{}).Error
+}
+
+// CleanupExpiredTokens は期限切れのトークンを削除します
+func (ts *PostgresTokenStore) CleanupExpiredTokens(ctx context.Context) error {
+	return ts.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&model.TokenRecord{}).Error
+}
+
+// sweepExpired は前回の掃除から一定時間が経過していれば期限切れトークンを削除します。
+func (ts *PostgresTokenStore) sweepExpired(ctx context.Context) {
+	ts.mutex.Lock()
+	if time.Since(ts.lastSweep) < tokenSweepInterval {
+		ts.mutex.Unlock()
+		return
+	}
+	ts.lastSweep = time.Now()
+	ts.mutex.Unlock()
+
+	// 掃除に失敗してもトークンの発行は継続する
+	_ = ts.CleanupExpiredTokens(ctx)
+}
@@ -21,9 +21,16 @@ func (u *unitOfWork) Transaction(fn func(usecase.Repositories) error) error {
 		// トランザクション用の新しいリポジトリインスタンスを生成します。
 		// これにより、全てのDB操作が同じトランザクション(tx)を共有します。
 		repos := usecase.Repositories{
-			User:      NewUserRepositoryImpl(tx),
-			Household: NewHouseholdRepositoryImpl(tx),
-			Expense:   NewExpenseRepositoryImpl(tx),
+			User:       NewUserRepositoryImpl(tx),
+			Household:  NewHouseholdRepositoryImpl(tx),
+			Member:     NewHouseholdMemberRepositoryImpl(tx),
+			Invitation: NewHouseholdInvitationRepositoryImpl(tx),
+			Expense:    NewExpenseRepositoryImpl(tx),
+			Budget:     NewBudgetRepositoryImpl(tx),
+			Recurring:  NewRecurringExpenseRepositoryImpl(tx),
+			Income:     NewIncomeRepositoryImpl(tx),
+			Category:   NewCategoryRepositoryImpl(tx),
+			Identity:   NewIdentityRepositoryImpl(tx),
 		}
 		return fn(repos)
 	})
@@ -46,21 +46,6 @@ func (repo *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (
 	return toDomainUser(&userModel)
 }
 
-// FindByLineUserID finds a user by Line User ID.
-func (repo *UserRepositoryImpl) FindByLineUserID(ctx context.Context, lineUserID *user.LineUserID) (*user.User, error) {
-	if lineUserID == nil {
-		return nil, nil
-	}
-	var userModel model.User
-	if err := repo.db.WithContext(ctx).Where("line_user_id = ?", lineUserID.Value()).First(&userModel).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil // User not found
-		}
-		return nil, err
-	}
-	return toDomainUser(&userModel)
-}
-
 // Create creates a new user.
 func (repo *UserRepositoryImpl) Create(ctx context.Context, userEntity *user.User) error {
 	userModel := toModelUser(userEntity)
@@ -130,26 +115,17 @@ func toDomainUser(userModel *model.User) (*user.User, error) {
 		return nil, err
 	}
 
-	var lineUserIDStr string
-	if userModel.LineUserID != nil {
-		lineUserIDStr = *userModel.LineUserID
-	}
-	lineUserID, err := user.NewLineUserID(lineUserIDStr)
-	if err != nil {
-		return nil, err
-	}
-
 	return &user.User{
-		ID:          user.UserID(userModel.ID),
-		Email:       email,
-		LineUserID:  lineUserID,
-		Password:    password,
-		Name:        name,
-		Image:       userModel.Image,
-		Admin:       userModel.Admin,
-		CreatedAt:   userModel.CreatedAt,
-		UpdatedAt:   userModel.UpdatedAt,
-		HouseholdID: userModel.HouseholdID,
+		ID:              user.UserID(userModel.ID),
+		Email:           email,
+		EmailVerifiedAt: userModel.EmailVerifiedAt,
+		Password:        password,
+		Name:            name,
+		Image:           userModel.Image,
+		Admin:           userModel.Admin,
+		CreatedAt:       userModel.CreatedAt,
+		UpdatedAt:       userModel.UpdatedAt,
+		HouseholdID:     userModel.HouseholdID,
 	}, nil
 }
 
@@ -164,22 +140,16 @@ func toModelUser(userEntity *user.User) *model.User {
 		emailPtr = &emailStr
 	}
 
-	var lineUserIDPtr *string
-	lineUserIDStr := userEntity.LineUserID.Value()
-	if lineUserIDStr != "" {
-		lineUserIDPtr = &lineUserIDStr
-	}
-
 	return &model.User{
-		ID:          userEntity.ID.Value(),
-		Email:       emailPtr,
-		LineUserID:  lineUserIDPtr,
-		Password:    userEntity.Password.Value(),
-		Name:        userEntity.Name.Value(),
-		Image:       userEntity.Image,
-		Admin:       userEntity.Admin,
-		CreatedAt:   userEntity.CreatedAt,
-		UpdatedAt:   userEntity.UpdatedAt,
-		HouseholdID: userEntity.HouseholdID,
+		ID:              userEntity.ID.Value(),
+		Email:           emailPtr,
+		EmailVerifiedAt: userEntity.EmailVerifiedAt,
+		Password:        userEntity.Password.Value(),
+		Name:            userEntity.Name.Value(),
+		Image:           userEntity.Image,
+		Admin:           userEntity.Admin,
+		CreatedAt:       userEntity.CreatedAt,
+		UpdatedAt:       userEntity.UpdatedAt,
+		HouseholdID:     userEntity.HouseholdID,
 	}
 }
@@ -1,22 +1,36 @@
 package router
 
 import (
+	"crypto/subtle"
+
+	"errors"
+
+	"log"
+
+	"net"
+
 	"net/http"
 
 	"os"
 
+	"time"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+
 	"github.com/gin-contrib/cors"
 
 	"github.com/gin-gonic/gin"
 
-	"github.com/golang-jwt/jwt/v5"
-
 	"github.com/yanatoritakuma/budget/back/controller"
 
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+
 	"github.com/yanatoritakuma/budget/back/domain/household" // Added for IHouseholdRepository
 
 	"github.com/yanatoritakuma/budget/back/domain/user" // Added for IUserRepository
 
+	"github.com/yanatoritakuma/budget/back/model"
+
 	"github.com/yanatoritakuma/budget/back/usecase" // Added
 
 	"gorm.io/gorm" // Added
@@ -28,28 +42,73 @@ func NewRouter(
 
 	ec controller.ExpenseController,
 
+	eic controller.ExpenseImportController,
+
+	xc controller.ExpenseExportController,
+
+	bc controller.BudgetController,
+
+	rc controller.RecurringExpenseController,
+
+	ic controller.IncomeController,
+
+	cc controller.CategoryController,
+
+	pc controller.PasswordController,
+
+	evc controller.EmailVerificationController,
+
+	mc controller.MFAController,
+
+	pkc controller.PasskeyController,
+
+	olc controller.OIDCLoginController,
+
+	idc controller.IdentityController,
+
 	ur user.UserRepository,
 
 	hr household.HouseholdRepository,
 
+	mr household.MemberRepository,
+
+	hir household.InvitationRepository,
+
+	er expense.ExpenseRepository,
+
 	uow usecase.UnitOfWork,
 
 	userUsecase usecase.UserUsecase,
 
+	sessionUsecase usecase.SessionUsecase,
+
+	tokenStore model.TokenStore,
+
+	emailVerificationUsecase usecase.EmailVerificationUsecase,
+
+	mfaUsecase usecase.MFAUsecase,
+
+	attemptStore model.AttemptStore,
+
 ) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 
 	// プロキシの設定
-	r.ForwardedByClientIP = true
-	r.TrustedPlatform = "X-Forwarded-For"
+	// X-Forwarded-For はクライアントが自由に設定できるため信頼せず、API Gatewayが記録した接続元IPを使う
+	r.ForwardedByClientIP = false
+	if err := r.SetTrustedProxies(nil); err != nil {
+		log.Fatalf("failed to set trusted proxies: %v", err)
+	}
+	r.Use(sourceIPMiddleware())
 
 	// CORSの設定
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", os.Getenv("FE_URL")},
 		AllowMethods:     []string{"GET", "PUT", "POST", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-CSRF-Token"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-CSRF-Token", "X-Device-Name"},
+		ExposeHeaders:    []string{"X-Next-Cursor"},
 		AllowCredentials: true,
 	}))
 
@@ -64,65 +123,164 @@ func NewRouter(
 		c.Next()
 	})
 
+	// IPアドレスごとのリクエスト数の制限
+	r.Use(rateLimitMiddleware(attemptStore, "all", generalRequestLimit, rateLimitWindow))
+	authRateLimit := rateLimitMiddleware(attemptStore, "auth", authRequestLimit, rateLimitWindow)
+
 	// --- Dependency Injection for Household module ---
-	householdUsecase := usecase.NewHouseholdUsecase(hr, ur)
+	householdUsecase := usecase.NewHouseholdUsecase(hr, ur, er, mr, hir, uow)
 	householdController := controller.NewHouseholdController(householdUsecase)
 	// --- End Dependency Injection for Household module ---
 
 	// --- Dependency Injection for User module ---
 	// userUsecase := usecase.NewUserUsecase(ur, hr, uow) // main.goから引数として受け取るため不要
-	userController := controller.NewUserController(userUsecase)
+	userController := controller.NewUserController(userUsecase, sessionUsecase, mfaUsecase)
+	sessionController := controller.NewSessionController(sessionUsecase)
 	// --- End Dependency Injection for User module ---
 
-	// --- Dependency Injection for LINE Login module ---
-	lineLoginUsecase := usecase.NewLineLoginUsecaseImpl(ur, userUsecase)
-	lineLoginController := controller.NewLineLoginController(lineLoginUsecase)
-	// --- End Dependency Injection for LINE Login module ---
-
 	// CSRF保護を適用
 	r.Use(csrfMiddleware(userController))
 
 	// -------------------------
 	// 認証不要ルート
 	// -------------------------
-	r.POST("/signup", gin.HandlerFunc(userController.SignUp))
-	r.POST("/login", gin.HandlerFunc(userController.LogIn))
+	r.POST("/signup", authRateLimit, gin.HandlerFunc(userController.SignUp))
+	r.POST("/login", authRateLimit, gin.HandlerFunc(userController.LogIn))
+	r.POST("/login/2fa", authRateLimit, gin.HandlerFunc(mc.CompleteLogin))
+	r.POST("/passkeys/login/begin", authRateLimit, gin.HandlerFunc(pkc.BeginLogin))
+	r.POST("/passkeys/login/finish", authRateLimit, gin.HandlerFunc(pkc.FinishLogin))
 	r.POST("/logout", gin.HandlerFunc(userController.LogOut))
 	r.GET("/csrf", gin.HandlerFunc(userController.CsrfToken))
+	r.POST("/auth/refresh", gin.HandlerFunc(sessionController.Refresh))
+	r.POST("/password/forgot", authRateLimit, gin.HandlerFunc(pc.ForgotPassword))
+	r.POST("/password/reset", authRateLimit, gin.HandlerFunc(pc.ResetPassword))
+	r.POST("/email/verify", authRateLimit, gin.HandlerFunc(evc.VerifyEmail))
 	// LINE Login routes
-	r.GET("/api/v1/auth/line/login", gin.HandlerFunc(lineLoginController.Login))
-	r.GET("/api/v1/auth/line/callback", gin.HandlerFunc(lineLoginController.Callback))
-	r.POST("/api/v1/auth/line/link", gin.HandlerFunc(lineLoginController.LinkAccount))
-	r.POST("/api/v1/auth/line/create", gin.HandlerFunc(lineLoginController.CreateAccount))
+	r.GET("/api/v1/auth/line/login", gin.HandlerFunc(olc.Login))
+	r.GET("/api/v1/auth/line/callback", gin.HandlerFunc(olc.Callback))
+	r.POST("/api/v1/auth/line/link", authRateLimit, gin.HandlerFunc(olc.LinkAccount))
+	r.POST("/api/v1/auth/line/create", gin.HandlerFunc(olc.CreateAccount))
+	// OpenID Connect Login routes
+	r.GET("/api/v1/auth/oidc/:provider/login", gin.HandlerFunc(olc.Login))
+	r.GET("/api/v1/auth/oidc/:provider/callback", gin.HandlerFunc(olc.Callback))
+	r.POST("/api/v1/auth/oidc/:provider/link", authRateLimit, gin.HandlerFunc(olc.LinkAccount))
+	r.POST("/api/v1/auth/oidc/:provider/create", gin.HandlerFunc(olc.CreateAccount))
 
 	// -------------------------
 	// 認証必須ルート
 	// -------------------------
 	auth := r.Group("/user")
-	auth.Use(authMiddleware())
+	auth.Use(authMiddleware(sessionUsecase))
 	{
 		auth.GET("", gin.HandlerFunc(userController.GetLoggedInUser))
 		auth.PUT("", gin.HandlerFunc(userController.UpdateUser))
+		auth.POST("/password", gin.HandlerFunc(pc.SetPassword))
+		auth.PUT("/password", gin.HandlerFunc(pc.ChangePassword))
+		auth.POST("/email/verification", gin.HandlerFunc(evc.ResendVerification))
+		auth.GET("/2fa", gin.HandlerFunc(mc.GetStatus))
+		auth.POST("/2fa/totp/setup", gin.HandlerFunc(mc.SetupTOTP))
+		auth.POST("/2fa/totp/confirm", gin.HandlerFunc(mc.ConfirmTOTP))
+		auth.POST("/2fa/totp/disable", gin.HandlerFunc(mc.DisableTOTP))
+		auth.POST("/2fa/recovery-codes", gin.HandlerFunc(mc.RegenerateRecoveryCodes))
+		auth.GET("/passkeys", gin.HandlerFunc(pkc.ListPasskeys))
+		auth.POST("/passkeys/register/begin", gin.HandlerFunc(pkc.BeginRegistration))
+		auth.POST("/passkeys/register/finish", gin.HandlerFunc(pkc.FinishRegistration))
+		auth.DELETE("/passkeys/:id", gin.HandlerFunc(pkc.DeletePasskey))
+		auth.GET("/identities", gin.HandlerFunc(idc.ListIdentities))
+		auth.DELETE("/identities/:id", gin.HandlerFunc(idc.UnlinkIdentity))
 		auth.DELETE("/:userId", gin.HandlerFunc(userController.DeleteUser))
 	}
 
+	// ログインセッション管理のエンドポイント（認証必要）
+	sessions := r.Group("/sessions")
+	sessions.Use(authMiddleware(sessionUsecase))
+	{
+		sessions.GET("", gin.HandlerFunc(sessionController.ListSessions))
+		sessions.DELETE("", gin.HandlerFunc(sessionController.RevokeAllSessions))
+		sessions.DELETE("/:id", gin.HandlerFunc(sessionController.RevokeSession))
+	}
+
 	// 支出管理のエンドポイント（認証必要）
 	expenses := r.Group("/expenses")
-	expenses.Use(authMiddleware())
+	expenses.Use(authMiddleware(sessionUsecase))
 	{
 		expenses.POST("", gin.HandlerFunc(ec.CreateExpense))
 		expenses.GET("", gin.HandlerFunc(ec.GetExpense))
+		expenses.GET("/summary", gin.HandlerFunc(ec.GetExpenseSummary))
+		expenses.GET("/export", emailVerifiedMiddleware(emailVerificationUsecase, usecase.FeatureExpenseExport), gin.HandlerFunc(xc.ExportExpenses))
+		expenses.POST("/import", emailVerifiedMiddleware(emailVerificationUsecase, usecase.FeatureExpenseImport), gin.HandlerFunc(eic.ImportExpenses))
 		expenses.PUT("/:id", gin.HandlerFunc(ec.UpdateExpense))
 		expenses.DELETE("/:id", gin.HandlerFunc(ec.DeleteExpense))
 	}
 
+	// 予算管理のエンドポイント（認証必要）
+	budgets := r.Group("/budgets")
+	budgets.Use(authMiddleware(sessionUsecase))
+	{
+		budgets.POST("", gin.HandlerFunc(bc.CreateBudget))
+		budgets.GET("", gin.HandlerFunc(bc.GetBudgets))
+		budgets.GET("/status", gin.HandlerFunc(bc.GetBudgetStatus))
+		budgets.PUT("/:id", gin.HandlerFunc(bc.UpdateBudget))
+		budgets.DELETE("/:id", gin.HandlerFunc(bc.DeleteBudget))
+	}
+
+	// カテゴリ管理のエンドポイント（認証必要）
+	categories := r.Group("/categories")
+	categories.Use(authMiddleware(sessionUsecase))
+	{
+		categories.GET("", gin.HandlerFunc(cc.GetCategories))
+		categories.POST("", gin.HandlerFunc(cc.CreateCategory))
+		categories.PUT("/:id", gin.HandlerFunc(cc.UpdateCategory))
+		categories.DELETE("/:id", gin.HandlerFunc(cc.DeleteCategory))
+	}
+
+	// 定期支出のエンドポイント（認証必要）
+	recurringExpenses := r.Group("/recurring-expenses")
+	recurringExpenses.Use(authMiddleware(sessionUsecase))
+	{
+		recurringExpenses.POST("", gin.HandlerFunc(rc.CreateRecurringExpense))
+		recurringExpenses.GET("", gin.HandlerFunc(rc.GetRecurringExpenses))
+		recurringExpenses.PUT("/:id", gin.HandlerFunc(rc.UpdateRecurringExpense))
+		recurringExpenses.DELETE("/:id", gin.HandlerFunc(rc.DeleteRecurringExpense))
+	}
+
+	// 収入管理のエンドポイント（認証必要）
+	incomes := r.Group("/incomes")
+	incomes.Use(authMiddleware(sessionUsecase))
+	{
+		incomes.POST("", gin.HandlerFunc(ic.CreateIncome))
+		incomes.GET("", gin.HandlerFunc(ic.GetIncomes))
+		incomes.PUT("/:id", gin.HandlerFunc(ic.UpdateIncome))
+		incomes.DELETE("/:id", gin.HandlerFunc(ic.DeleteIncome))
+	}
+
+	// 月次収支のエンドポイント（認証必要）
+	cashFlow := r.Group("/cash-flow")
+	cashFlow.Use(authMiddleware(sessionUsecase))
+	{
+		cashFlow.GET("", gin.HandlerFunc(ic.GetCashFlow))
+	}
+
 	// 世帯管理のエンドポイント（認証必要）
 	household := r.Group("/household")
-	household.Use(authMiddleware())
+	household.Use(authMiddleware(sessionUsecase))
 	{
 		household.GET("/users", gin.HandlerFunc(userController.GetHouseholdUsers))
-		household.POST("/invite-code", gin.HandlerFunc(householdController.GenerateInviteCode))
-		household.POST("/join", gin.HandlerFunc(userController.JoinHousehold))
+		household.POST("/invite-code", emailVerifiedMiddleware(emailVerificationUsecase, usecase.FeatureHouseholdInvite), gin.HandlerFunc(householdController.GenerateInviteCode))
+		household.GET("/invitations", gin.HandlerFunc(householdController.ListInvitations))
+		household.POST("/invitations", emailVerifiedMiddleware(emailVerificationUsecase, usecase.FeatureHouseholdInvite), gin.HandlerFunc(householdController.CreateInvitation))
+		household.DELETE("/invitations/:id", gin.HandlerFunc(householdController.RevokeInvitation))
+		household.POST("/join", emailVerifiedMiddleware(emailVerificationUsecase, usecase.FeatureHouseholdJoin), gin.HandlerFunc(userController.JoinHousehold))
+		household.POST("/leave", gin.HandlerFunc(householdController.Leave))
+		household.GET("/members", gin.HandlerFunc(householdController.ListMembers))
+		household.DELETE("/members/:userId", gin.HandlerFunc(householdController.RemoveMember))
+		household.PUT("/members/:userId/role", gin.HandlerFunc(householdController.ChangeMemberRole))
+		household.PUT("/owner", gin.HandlerFunc(householdController.TransferOwnership))
+		household.GET("/split", gin.HandlerFunc(householdController.GetSplitRule))
+		household.PUT("/split", gin.HandlerFunc(householdController.UpdateSplitRule))
+		household.GET("/settings", gin.HandlerFunc(householdController.GetSettings))
+		household.PUT("/settings", gin.HandlerFunc(householdController.UpdateSettings))
+		household.GET("/settlement", gin.HandlerFunc(householdController.GetSettlement))
 	}
 
 	return r
@@ -143,11 +301,11 @@ func csrfMiddleware(uc controller.UserController) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		sessionID, err := c.Cookie("token")
-		if err != nil {
-			sessionID = "default"
-		}
-		if !uc.ValidateCSRFToken(sessionID, token) {
+		// ヘッダーのトークンがCookieのトークンと一致し、ブラウザの識別子に対して発行されたものであることを確認する
+		cookieToken, _ := c.Cookie(controller.CSRFTokenCookie)
+		clientID, _ := c.Cookie(controller.CSRFSessionCookie)
+		sessionKey := usecase.CSRFSessionKey(clientID)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(cookieToken)) != 1 || sessionKey == "" || !uc.ValidateCSRFToken(sessionKey, token) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Invalid CSRF token"})
 			c.Abort()
 			return
@@ -159,30 +317,97 @@ func csrfMiddleware(uc controller.UserController) gin.HandlerFunc {
 // ==========================
 // Auth Middleware
 // ==========================
-func authMiddleware() gin.HandlerFunc {
+// authMiddleware はアクセストークンとセッションの失効を検証します。
+// アクセストークンが無い・期限切れの場合はリフレッシュトークンで再発行して処理を続けます。
+func authMiddleware(su usecase.SessionUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		cookie, err := c.Cookie("token")
+		var claims usecase.AccessClaims
+		accessToken, err := c.Cookie(controller.AccessTokenCookie)
+		if err == nil {
+			claims, err = su.Authenticate(c.Request.Context(), accessToken)
+		}
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
-			c.Abort()
+			refreshToken, cookieErr := c.Cookie(controller.RefreshTokenCookie)
+			if cookieErr != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+				c.Abort()
+				return
+			}
+			tokens, refreshErr := su.Refresh(c.Request.Context(), refreshToken, controller.ClientInfo(c))
+			if refreshErr != nil {
+				controller.ClearAuthCookies(c)
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+				c.Abort()
+				return
+			}
+			controller.SetAuthCookies(c, tokens)
+			claims = usecase.AccessClaims{UserID: tokens.UserID, SessionID: tokens.SessionID}
+		}
+
+		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
+		c.Next()
+	}
+}
+
+// ==========================
+// Source IP Middleware
+// ==========================
+// sourceIPMiddleware はAPI Gatewayのリクエストコンテキストにある接続元IPをRemoteAddrに設定し、ClientIPで参照できるようにします。
+// Lambda以外で動かす場合はTCP接続の相手先がそのまま使われます。
+func sourceIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if requestContext, ok := core.GetAPIGatewayV2ContextFromContext(c.Request.Context()); ok && requestContext.HTTP.SourceIP != "" {
+			c.Request.RemoteAddr = net.JoinHostPort(requestContext.HTTP.SourceIP, "0")
+		}
+		c.Next()
+	}
+}
+
+// ==========================
+// Rate Limit Middleware
+// ==========================
+// リクエスト数の制限値。認証系のエンドポイントには総当たり対策としてより厳しい制限を加えます。
+const (
+	rateLimitWindow     = time.Minute
+	generalRequestLimit = 300
+	authRequestLimit    = 20
+)
+
+// rateLimitMiddleware はIPアドレスごとのリクエスト数を固定ウィンドウで数え、上限を超えたリクエストに429を返します。
+// 回数はストアに保存するため、Lambdaの複数インスタンス間で共有されます。
+func rateLimitMiddleware(store model.AttemptStore, scope string, limit int, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		record, err := store.Increment(c.Request.Context(), "rate-"+scope+"-"+c.ClientIP(), window)
+		if err != nil {
+			// ストアの障害でサービス全体を止めないよう、制限せずに処理を続ける
+			log.Printf("rate limit store error: %v", err)
+			c.Next()
 			return
 		}
-		token, err := jwt.Parse(cookie, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
+		if record.Count > limit {
+			controller.RespondTooManyAttempts(c, &usecase.TooManyAttemptsError{RetryAfter: time.Until(record.WindowEndsAt)})
+			return
+		}
+		c.Next()
+	}
+}
+
+// ==========================
+// Email Verified Middleware
+// ==========================
+// emailVerifiedMiddleware は設定でメールアドレスの確認が必須とされた機能へのアクセスを制限します。
+func emailVerifiedMiddleware(evu usecase.EmailVerificationUsecase, feature usecase.RestrictedFeature) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := evu.RequireVerified(c.Request.Context(), c.GetUint("user_id"), feature); err != nil {
+			if errors.Is(err, usecase.ErrForbidden) {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": "email_not_verified"})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			}
-			return []byte(os.Getenv("SECRET")), nil
-		})
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
 			c.Abort()
 			return
 		}
-		claims := token.Claims.(jwt.MapClaims)
-		c.Set("user", claims)
-		if userID, ok := claims["user_id"].(float64); ok {
-			c.Set("user_id", uint(userID))
-		}
 		c.Next()
 	}
 }
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/yanatoritakuma/budget/back/domain/budget"
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+type BudgetUsecase interface {
+	CreateBudget(ctx context.Context, userID uint, req api.BudgetRequest) (api.BudgetResponse, error)
+	GetBudgets(ctx context.Context, userID uint, year int, month int) ([]api.BudgetResponse, error)
+	UpdateBudget(ctx context.Context, userID uint, req api.BudgetRequest, budgetId uint) (api.BudgetResponse, error)
+	DeleteBudget(ctx context.Context, userID uint, budgetId uint) error
+	GetBudgetStatus(ctx context.Context, userID uint, year int, month int) (api.BudgetStatusResponse, error)
+}
+
+type budgetUsecase struct {
+	br budget.BudgetRepository
+	er expense.ExpenseRepository
+	ur user.UserRepository
+	hr household.HouseholdRepository
+	mr household.MemberRepository
+}
+
+func NewBudgetUsecase(br budget.BudgetRepository, er expense.ExpenseRepository, ur user.UserRepository, hr household.HouseholdRepository, mr household.MemberRepository) BudgetUsecase {
+	return &budgetUsecase{br: br, er: er, ur: ur, hr: hr, mr: mr}
+}
+
+func (bu *budgetUsecase) CreateBudget(ctx context.Context, userID uint, req api.BudgetRequest) (api.BudgetResponse, error) {
+	if _, err := requireEditor(ctx, bu.mr, userID); err != nil {
+		return api.BudgetResponse{}, err
+	}
+
+	householdID, err := bu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return api.BudgetResponse{}, err
+	}
+
+	domainBudget, err := budget.NewBudget(householdID, req.Category, req.Year, req.Month, req.LimitAmount)
+	if err != nil {
+		return api.BudgetResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	if err := bu.br.Create(ctx, domainBudget); err != nil {
+		return api.BudgetResponse{}, err
+	}
+
+	return toBudgetResponse(domainBudget), nil
+}
+
+func (bu *budgetUsecase) GetBudgets(ctx context.Context, userID uint, year int, month int) ([]api.BudgetResponse, error) {
+	householdID, err := bu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := bu.br.FindByHouseholdAndMonth(ctx, householdID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetResponses := []api.BudgetResponse{}
+	for _, domainBudget := range budgets {
+		budgetResponses = append(budgetResponses, toBudgetResponse(domainBudget))
+	}
+	return budgetResponses, nil
+}
+
+func (bu *budgetUsecase) UpdateBudget(ctx context.Context, userID uint, req api.BudgetRequest, budgetId uint) (api.BudgetResponse, error) {
+	if _, err := requireEditor(ctx, bu.mr, userID); err != nil {
+		return api.BudgetResponse{}, err
+	}
+
+	existingBudget, err := bu.findOwnBudget(ctx, userID, budgetId)
+	if err != nil {
+		return api.BudgetResponse{}, err
+	}
+
+	domainBudget, err := budget.NewBudget(existingBudget.HouseholdID, req.Category, req.Year, req.Month, req.LimitAmount)
+	if err != nil {
+		return api.BudgetResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	domainBudget.ID = existingBudget.ID
+	domainBudget.CreatedAt = existingBudget.CreatedAt
+
+	if err := bu.br.Update(ctx, domainBudget); err != nil {
+		return api.BudgetResponse{}, err
+	}
+
+	return toBudgetResponse(domainBudget), nil
+}
+
+func (bu *budgetUsecase) DeleteBudget(ctx context.Context, userID uint, budgetId uint) error {
+	if _, err := requireEditor(ctx, bu.mr, userID); err != nil {
+		return err
+	}
+
+	existingBudget, err := bu.findOwnBudget(ctx, userID, budgetId)
+	if err != nil {
+		return err
+	}
+	return bu.br.Delete(ctx, existingBudget.ID)
+}
+
+// GetBudgetStatus は指定月の予算と支出実績を突き合わせた消化状況を返します。月の区切りは世帯の設定に従います。
+func (bu *budgetUsecase) GetBudgetStatus(ctx context.Context, userID uint, year int, month int) (api.BudgetStatusResponse, error) {
+	householdID, err := bu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return api.BudgetStatusResponse{}, err
+	}
+
+	budgets, err := bu.br.FindByHouseholdAndMonth(ctx, householdID, year, month)
+	if err != nil {
+		return api.BudgetStatusResponse{}, err
+	}
+
+	settings, err := findSettings(ctx, bu.hr, householdID)
+	if err != nil {
+		return api.BudgetStatusResponse{}, err
+	}
+	from, to := settings.MonthRange(year, month)
+
+	totals, err := bu.er.GetCategoryTotals(ctx, householdID, from, to)
+	if err != nil {
+		return api.BudgetStatusResponse{}, err
+	}
+
+	res := api.BudgetStatusResponse{
+		Year:     year,
+		Month:    month,
+		From:     openapi_types.Date{Time: from},
+		To:       openapi_types.Date{Time: to},
+		Currency: settings.Currency,
+		Items:    []api.BudgetStatusItem{},
+	}
+	for _, domainBudget := range budgets {
+		status := domainBudget.Status(totals[domainBudget.Category.Value()])
+		res.Items = append(res.Items, api.BudgetStatusItem{
+			BudgetId:    int(domainBudget.ID.Value()),
+			Category:    domainBudget.Category.Value(),
+			LimitAmount: domainBudget.LimitAmount.Value(),
+			Spent:       status.Spent,
+			Remaining:   status.Remaining,
+			PercentUsed: status.PercentUsed,
+		})
+		res.TotalLimit += domainBudget.LimitAmount.Value()
+		res.TotalSpent += status.Spent
+	}
+	res.TotalRemaining = res.TotalLimit - res.TotalSpent
+
+	return res, nil
+}
+
+// findHouseholdID はユーザーの所属する世帯IDを取得します。
+func (bu *budgetUsecase) findHouseholdID(ctx context.Context, userID uint) (uint, error) {
+	currentUser, err := bu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return 0, fmt.Errorf("current user not found")
+	}
+	return currentUser.HouseholdID, nil
+}
+
+// findOwnBudget はユーザーの世帯に属する予算を取得します。
+func (bu *budgetUsecase) findOwnBudget(ctx context.Context, userID uint, budgetId uint) (*budget.Budget, error) {
+	householdID, err := bu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingBudget, err := bu.br.FindByID(ctx, budgetId)
+	if err != nil {
+		return nil, err
+	}
+	if existingBudget == nil || existingBudget.HouseholdID != householdID {
+		return nil, fmt.Errorf("予算が見つかりません: %w", ErrNotFound)
+	}
+	return existingBudget, nil
+}
+
+func toBudgetResponse(domainBudget *budget.Budget) api.BudgetResponse {
+	return api.BudgetResponse{
+		Id:          int(domainBudget.ID.Value()),
+		Category:    domainBudget.Category.Value(),
+		Year:        domainBudget.Year.Value(),
+		Month:       domainBudget.Month.Value(),
+		LimitAmount: domainBudget.LimitAmount.Value(),
+		CreatedAt:   domainBudget.CreatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yanatoritakuma/budget/back/domain/budget"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+type fakeBudgetRepository struct {
+	budget.BudgetRepository
+	budgets map[uint]*budget.Budget
+	updated []uint
+}
+
+func (r *fakeBudgetRepository) FindByID(_ context.Context, id uint) (*budget.Budget, error) {
+	return r.budgets[id], nil
+}
+
+func (r *fakeBudgetRepository) Update(_ context.Context, b *budget.Budget) error {
+	r.updated = append(r.updated, b.ID.Value())
+	return nil
+}
+
+func TestBudgetUsecaseUpdateBudgetErrors(t *testing.T) {
+	const ownBudgetID, otherBudgetID, missingBudgetID uint = 1, 2, 99
+	newBudget := func(id uint, householdID uint) *budget.Budget {
+		b, err := budget.NewBudget(householdID, "食費", 2026, 10, 30000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.ID = budget.BudgetID(id)
+		return b
+	}
+	valid := api.BudgetRequest{Category: "食費", Year: 2026, Month: 10, LimitAmount: 40000}
+	invalid := valid
+	invalid.Month = 13
+
+	tests := []struct {
+		name     string
+		userID   uint
+		budgetID uint
+		req      api.BudgetRequest
+		wantErr  error
+	}{
+		{"own household", ownerID, ownBudgetID, valid, nil},
+		{"other household", ownerID, otherBudgetID, valid, ErrNotFound},
+		{"missing budget", ownerID, missingBudgetID, valid, ErrNotFound},
+		{"viewer", viewerID, ownBudgetID, valid, ErrForbidden},
+		{"invalid month", ownerID, ownBudgetID, invalid, ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExpenseFixture(t)
+			br := &fakeBudgetRepository{budgets: map[uint]*budget.Budget{
+				ownBudgetID:   newBudget(ownBudgetID, ownHousehold),
+				otherBudgetID: newBudget(otherBudgetID, otherHousehold),
+			}}
+			bu := NewBudgetUsecase(br, f.er, f.ur, &fakeHouseholdRepository{}, f.mr)
+
+			_, err := bu.UpdateBudget(context.Background(), tt.userID, tt.req, tt.budgetID)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("UpdateBudget() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateBudget() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(br.updated) != 0 {
+				t.Errorf("UpdateBudget() updated %v, want no update", br.updated)
+			}
+		})
+	}
+}
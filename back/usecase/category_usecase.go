@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanatoritakuma/budget/back/domain/category"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+type CategoryUsecase interface {
+	GetCategories(ctx context.Context, userID uint, includeArchived bool) ([]api.CategoryResponse, error)
+	CreateCategory(ctx context.Context, userID uint, req api.CategoryRequest) (api.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, userID uint, req api.CategoryRequest, categoryId uint) (api.CategoryResponse, error)
+	DeleteCategory(ctx context.Context, userID uint, categoryId uint) error
+}
+
+type categoryUsecase struct {
+	cr  category.CategoryRepository
+	ur  user.UserRepository
+	mr  household.MemberRepository
+	uow UnitOfWork
+}
+
+func NewCategoryUsecase(cr category.CategoryRepository, ur user.UserRepository, mr household.MemberRepository, uow UnitOfWork) CategoryUsecase {
+	return &categoryUsecase{cr: cr, ur: ur, mr: mr, uow: uow}
+}
+
+func (cu *categoryUsecase) GetCategories(ctx context.Context, userID uint, includeArchived bool) ([]api.CategoryResponse, error) {
+	householdID, err := cu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := cu.cr.FindByHouseholdID(ctx, householdID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryResponses := []api.CategoryResponse{}
+	for _, domainCategory := range categories {
+		categoryResponses = append(categoryResponses, toCategoryResponse(domainCategory))
+	}
+	return categoryResponses, nil
+}
+
+func (cu *categoryUsecase) CreateCategory(ctx context.Context, userID uint, req api.CategoryRequest) (api.CategoryResponse, error) {
+	if _, err := requireEditor(ctx, cu.mr, userID); err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	householdID, err := cu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	domainCategory, err := category.NewCategory(householdID, req.Name, derefString(req.Color), derefString(req.Icon), derefInt(req.SortOrder), nil)
+	if err != nil {
+		return api.CategoryResponse{}, err
+	}
+	if err := cu.applyParent(ctx, domainCategory, req.ParentId); err != nil {
+		return api.CategoryResponse{}, err
+	}
+	if err := cu.ensureUniqueName(ctx, domainCategory); err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	if err := cu.cr.Create(ctx, domainCategory); err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	return toCategoryResponse(domainCategory), nil
+}
+
+// UpdateCategory はカテゴリを更新します。名前が変わった場合は支出・予算・定期支出のカテゴリ名も合わせて更新します。
+func (cu *categoryUsecase) UpdateCategory(ctx context.Context, userID uint, req api.CategoryRequest, categoryId uint) (api.CategoryResponse, error) {
+	if _, err := requireEditor(ctx, cu.mr, userID); err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	existingCategory, err := cu.findOwnCategory(ctx, userID, categoryId)
+	if err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	sortOrder := existingCategory.SortOrder
+	if req.SortOrder != nil {
+		sortOrder = *req.SortOrder
+	}
+	domainCategory, err := category.NewCategory(existingCategory.HouseholdID, req.Name, derefString(req.Color), derefString(req.Icon), sortOrder, nil)
+	if err != nil {
+		return api.CategoryResponse{}, err
+	}
+	domainCategory.ID = existingCategory.ID
+	domainCategory.CreatedAt = existingCategory.CreatedAt
+	domainCategory.Archived = existingCategory.Archived
+	if req.Archived != nil {
+		domainCategory.Archived = *req.Archived
+	}
+	if err := cu.applyParent(ctx, domainCategory, req.ParentId); err != nil {
+		return api.CategoryResponse{}, err
+	}
+	if err := cu.ensureUniqueName(ctx, domainCategory); err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	oldName := existingCategory.Name.Value()
+	newName := domainCategory.Name.Value()
+	err = cu.uow.Transaction(func(repos Repositories) error {
+		if err := repos.Category.Update(ctx, domainCategory); err != nil {
+			return err
+		}
+		if oldName == newName {
+			return nil
+		}
+		if err := repos.Expense.RenameCategory(ctx, domainCategory.ID.Value(), newName); err != nil {
+			return err
+		}
+		if err := repos.Budget.RenameCategory(ctx, domainCategory.HouseholdID, oldName, newName); err != nil {
+			return err
+		}
+		return repos.Recurring.RenameCategory(ctx, domainCategory.HouseholdID, oldName, newName)
+	})
+	if err != nil {
+		return api.CategoryResponse{}, err
+	}
+
+	return toCategoryResponse(domainCategory), nil
+}
+
+// DeleteCategory はカテゴリを削除します。紐付いていた支出はカテゴリ名を保持したまま参照のみ外れます。
+func (cu *categoryUsecase) DeleteCategory(ctx context.Context, userID uint, categoryId uint) error {
+	if _, err := requireEditor(ctx, cu.mr, userID); err != nil {
+		return err
+	}
+
+	existingCategory, err := cu.findOwnCategory(ctx, userID, categoryId)
+	if err != nil {
+		return err
+	}
+	return cu.cr.Delete(ctx, existingCategory.ID)
+}
+
+// findHouseholdID はユーザーの所属する世帯IDを取得します。
+func (cu *categoryUsecase) findHouseholdID(ctx context.Context, userID uint) (uint, error) {
+	currentUser, err := cu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return 0, fmt.Errorf("current user not found")
+	}
+	return currentUser.HouseholdID, nil
+}
+
+// findOwnCategory はユーザーの世帯に属するカテゴリを取得します。
+func (cu *categoryUsecase) findOwnCategory(ctx context.Context, userID uint, categoryId uint) (*category.Category, error) {
+	householdID, err := cu.findHouseholdID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingCategory, err := cu.cr.FindByID(ctx, categoryId)
+	if err != nil {
+		return nil, err
+	}
+	if existingCategory == nil || existingCategory.HouseholdID != householdID {
+		return nil, fmt.Errorf("カテゴリが見つかりません: %w", ErrNotFound)
+	}
+	return existingCategory, nil
+}
+
+func (cu *categoryUsecase) applyParent(ctx context.Context, domainCategory *category.Category, parentID *int) error {
+	if parentID == nil {
+		return domainCategory.SetParent(nil)
+	}
+	parent, err := cu.cr.FindByID(ctx, uint(*parentID))
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return fmt.Errorf("親カテゴリが見つかりません: %w", ErrInvalidInput)
+	}
+	if err := domainCategory.SetParent(parent); err != nil {
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	return nil
+}
+
+func (cu *categoryUsecase) ensureUniqueName(ctx context.Context, domainCategory *category.Category) error {
+	sameName, err := cu.cr.FindByName(ctx, domainCategory.HouseholdID, domainCategory.Name.Value())
+	if err != nil {
+		return err
+	}
+	if sameName != nil && sameName.ID != domainCategory.ID {
+		return fmt.Errorf("同じ名前のカテゴリが既に存在します: %s: %w", domainCategory.Name.Value(), ErrConflict)
+	}
+	return nil
+}
+
+// createDefaultCategories は世帯に初期カテゴリを登録します。
+func createDefaultCategories(ctx context.Context, cr category.CategoryRepository, householdID uint) error {
+	categories, err := category.NewDefaultCategories(householdID)
+	if err != nil {
+		return err
+	}
+	for _, domainCategory := range categories {
+		if err := cr.Create(ctx, domainCategory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveCategory はリクエストで指定された支出に紐付けるカテゴリを取得します。
+// IDが指定されていない場合は名前で検索します。存在しない名前やアーカイブ済みのカテゴリ名は受け付けません。
+func resolveCategory(ctx context.Context, cr category.CategoryRepository, householdID uint, categoryID *int, name string) (*category.Category, error) {
+	if categoryID != nil {
+		domainCategory, err := cr.FindByID(ctx, uint(*categoryID))
+		if err != nil {
+			return nil, err
+		}
+		if domainCategory == nil || domainCategory.HouseholdID != householdID {
+			return nil, fmt.Errorf("カテゴリが見つかりません: %w", ErrInvalidInput)
+		}
+		return domainCategory, nil
+	}
+
+	voName, err := category.NewName(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	domainCategory, err := cr.FindByName(ctx, householdID, voName.Value())
+	if err != nil {
+		return nil, err
+	}
+	if domainCategory == nil {
+		return nil, fmt.Errorf("カテゴリが見つかりません: %s: %w", voName.Value(), ErrInvalidInput)
+	}
+	if domainCategory.Archived {
+		return nil, fmt.Errorf("アーカイブ済みのカテゴリは指定できません: %s: %w", voName.Value(), ErrInvalidInput)
+	}
+	return domainCategory, nil
+}
+
+// findOrCreateCategory は世帯のカテゴリを名前で検索し、存在しなければ新しいカテゴリとして登録します。
+// 世帯間の移動や定期支出の生成など、ユーザーの入力を介さない処理でのみ使います。
+func findOrCreateCategory(ctx context.Context, cr category.CategoryRepository, householdID uint, name string) (*category.Category, error) {
+	voName, err := category.NewName(name)
+	if err != nil {
+		return nil, err
+	}
+	domainCategory, err := cr.FindByName(ctx, householdID, voName.Value())
+	if err != nil {
+		return nil, err
+	}
+	if domainCategory != nil {
+		return domainCategory, nil
+	}
+
+	existing, err := cr.FindByHouseholdID(ctx, householdID, true)
+	if err != nil {
+		return nil, err
+	}
+	domainCategory, err = category.NewCategory(householdID, voName.Value(), "", "", len(existing)+1, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := cr.Create(ctx, domainCategory); err != nil {
+		return nil, err
+	}
+	return domainCategory, nil
+}
+
+func toCategoryResponse(domainCategory *category.Category) api.CategoryResponse {
+	var parentID *int
+	if domainCategory.ParentID != nil {
+		id := int(*domainCategory.ParentID)
+		parentID = &id
+	}
+	return api.CategoryResponse{
+		Id:        int(domainCategory.ID.Value()),
+		Name:      domainCategory.Name.Value(),
+		Color:     domainCategory.Color.Value(),
+		Icon:      domainCategory.Icon.Value(),
+		SortOrder: domainCategory.SortOrder,
+		Archived:  domainCategory.Archived,
+		ParentId:  parentID,
+		CreatedAt: domainCategory.CreatedAt,
+	}
+}
+
+func derefString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yanatoritakuma/budget/back/domain/category"
+)
+
+func newCategoryRepositoryFixture(t *testing.T) *fakeCategoryRepository {
+	t.Helper()
+	newCategory := func(id uint, name string, archived bool) *category.Category {
+		c, err := category.NewCategory(ownHousehold, name, "", "", int(id), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.ID = category.CategoryID(id)
+		c.Archived = archived
+		return c
+	}
+	return &fakeCategoryRepository{categories: []*category.Category{
+		newCategory(ownCategoryID, "食費", false),
+		newCategory(ownCategoryID+1, "旧カテゴリ", true),
+	}}
+}
+
+func TestResolveCategory(t *testing.T) {
+	ownID, otherID := int(ownCategoryID), int(missingExpenseID)
+	tests := []struct {
+		name       string
+		categoryID *int
+		catName    string
+		wantID     uint
+		wantErr    error
+	}{
+		{"by id", &ownID, "", ownCategoryID, nil},
+		{"by name", nil, "食費", ownCategoryID, nil},
+		{"unknown id", &otherID, "", 0, ErrInvalidInput},
+		{"unknown name", nil, "新しいカテゴリ", 0, ErrInvalidInput},
+		{"archived name", nil, "旧カテゴリ", 0, ErrInvalidInput},
+		{"empty name", nil, "", 0, ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newCategoryRepositoryFixture(t)
+
+			got, err := resolveCategory(context.Background(), cr, ownHousehold, tt.categoryID, tt.catName)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveCategory() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("resolveCategory() error = %v", err)
+			} else if got.ID.Value() != tt.wantID {
+				t.Errorf("resolveCategory() = %d, want %d", got.ID.Value(), tt.wantID)
+			}
+			if len(cr.created) != 0 {
+				t.Errorf("resolveCategory() created %d categories, want none", len(cr.created))
+			}
+		})
+	}
+}
+
+func TestFindOrCreateCategory(t *testing.T) {
+	cr := newCategoryRepositoryFixture(t)
+
+	got, err := findOrCreateCategory(context.Background(), cr, ownHousehold, "食費")
+	if err != nil || got.ID.Value() != ownCategoryID {
+		t.Fatalf("findOrCreateCategory() = %v, %v, want the existing category", got, err)
+	}
+	got, err = findOrCreateCategory(context.Background(), cr, ownHousehold, "新しいカテゴリ")
+	if err != nil {
+		t.Fatalf("findOrCreateCategory() error = %v", err)
+	}
+	if len(cr.created) != 1 || got.Name.Value() != "新しいカテゴリ" {
+		t.Errorf("findOrCreateCategory() created %v, want the new category", cr.created)
+	}
+}
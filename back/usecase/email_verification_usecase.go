@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/mailer"
+)
+
+// EmailVerificationTokenTTL はメールアドレス確認リンクの有効期間です。
+const EmailVerificationTokenTTL = 24 * time.Hour
+
+const emailVerificationTokenType = "email_verification"
+
+// RestrictedFeature はメールアドレスの確認を必須にできる機能です。
+type RestrictedFeature string
+
+const (
+	FeatureHouseholdInvite RestrictedFeature = "household_invite"
+	FeatureHouseholdJoin   RestrictedFeature = "household_join"
+	FeatureExpenseImport   RestrictedFeature = "expense_import"
+	FeatureExpenseExport   RestrictedFeature = "expense_export"
+)
+
+// DefaultRestrictedFeatures は設定が無い場合にメールアドレスの確認を必須とする機能です。
+var DefaultRestrictedFeatures = []RestrictedFeature{FeatureHouseholdInvite, FeatureHouseholdJoin}
+
+// ParseRestrictedFeatures はカンマ区切りの機能名を解析します。
+// 空文字の場合は DefaultRestrictedFeatures を、"none" の場合は制限なしを返します。
+func ParseRestrictedFeatures(value string) ([]RestrictedFeature, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "":
+		return DefaultRestrictedFeatures, nil
+	case "none":
+		return nil, nil
+	}
+
+	var features []RestrictedFeature
+	for _, name := range strings.Split(value, ",") {
+		feature := RestrictedFeature(strings.TrimSpace(name))
+		switch feature {
+		case FeatureHouseholdInvite, FeatureHouseholdJoin, FeatureExpenseImport, FeatureExpenseExport:
+			features = append(features, feature)
+		default:
+			return nil, fmt.Errorf("unknown restricted feature: %s", name)
+		}
+	}
+	return features, nil
+}
+
+type EmailVerificationUsecase interface {
+	// SendVerification はユーザーのメールアドレスに確認リンクを送信します。
+	SendVerification(ctx context.Context, userEntity *user.User) error
+	// ResendVerification は未確認のユーザーに確認リンクを再送信します。
+	ResendVerification(ctx context.Context, userID uint) error
+	// VerifyEmail は確認リンクのトークンを検証してメールアドレスを確認済みにします。
+	VerifyEmail(ctx context.Context, req api.EmailVerificationRequest) error
+	// RequireVerified は機能の利用にメールアドレスの確認が必要な場合、未確認のユーザーに ErrForbidden を返します。
+	// メールアドレスを登録していないLINEログインのユーザーは制限しません。
+	RequireVerified(ctx context.Context, userID uint, feature RestrictedFeature) error
+}
+
+type emailVerificationUsecase struct {
+	ur         user.UserRepository
+	mailer     mailer.Mailer
+	restricted map[RestrictedFeature]bool
+}
+
+func NewEmailVerificationUsecase(ur user.UserRepository, m mailer.Mailer, restricted []RestrictedFeature) EmailVerificationUsecase {
+	restrictedSet := make(map[RestrictedFeature]bool, len(restricted))
+	for _, feature := range restricted {
+		restrictedSet[feature] = true
+	}
+	return &emailVerificationUsecase{
+		ur:         ur,
+		mailer:     m,
+		restricted: restrictedSet,
+	}
+}
+
+func (eu *emailVerificationUsecase) SendVerification(ctx context.Context, userEntity *user.User) error {
+	if userEntity.Email == nil {
+		return fmt.Errorf("メールアドレスが登録されていません: %w", ErrInvalidInput)
+	}
+
+	token, err := generateEmailVerificationToken(userEntity.ID.Value(), userEntity.Email.Value())
+	if err != nil {
+		return err
+	}
+	verifyURL := os.Getenv("FE_URL") + "/email/verify?" + url.Values{"token": {token}}.Encode()
+	msg := mailer.Message{
+		To:      userEntity.Email.Value(),
+		Subject: "メールアドレスの確認",
+		Body: fmt.Sprintf(
+			"%s 様\n\n以下のリンクからメールアドレスの確認を完了してください。\n%s\n\nこのリンクの有効期限は%d時間です。\nお心当たりのない場合はこのメールを破棄してください。\n",
+			userEntity.Name.Value(), verifyURL, int(EmailVerificationTokenTTL/time.Hour),
+		),
+	}
+	if err := eu.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send verification mail: %w", err)
+	}
+	return nil
+}
+
+func (eu *emailVerificationUsecase) ResendVerification(ctx context.Context, userID uint) error {
+	domainUser, err := eu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+	if domainUser.IsEmailVerified() {
+		return fmt.Errorf("メールアドレスは確認済みです: %w", ErrInvalidInput)
+	}
+	return eu.SendVerification(ctx, domainUser)
+}
+
+func (eu *emailVerificationUsecase) VerifyEmail(ctx context.Context, req api.EmailVerificationRequest) error {
+	userID, email, err := parseEmailVerificationToken(req.Token)
+	if err != nil {
+		return err
+	}
+
+	domainUser, err := eu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// 確認リンクの送信後にメールアドレスが変更された場合は無効にする
+	if domainUser == nil || domainUser.Email == nil || domainUser.Email.Value() != email {
+		return fmt.Errorf("確認リンクが無効または期限切れです: %w", ErrInvalidInput)
+	}
+	if domainUser.IsEmailVerified() {
+		return nil
+	}
+
+	domainUser.VerifyEmail(time.Now())
+	if err := eu.ur.Update(ctx, domainUser); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	return nil
+}
+
+func (eu *emailVerificationUsecase) RequireVerified(ctx context.Context, userID uint, feature RestrictedFeature) error {
+	if !eu.restricted[feature] {
+		return nil
+	}
+
+	domainUser, err := eu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+	if domainUser.Email != nil && !domainUser.IsEmailVerified() {
+		return fmt.Errorf("メールアドレスの確認が必要です: %w", ErrForbidden)
+	}
+	return nil
+}
+
+func generateEmailVerificationToken(userID uint, email string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"email":   email,
+		"type":    emailVerificationTokenType,
+		"exp":     time.Now().Add(EmailVerificationTokenTTL).Unix(),
+	})
+	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET")))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return tokenString, nil
+}
+
+func parseEmailVerificationToken(tokenString string) (uint, string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(os.Getenv("SECRET")), nil
+	}, jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return 0, "", fmt.Errorf("確認リンクが無効または期限切れです: %w", ErrInvalidInput)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["type"] != emailVerificationTokenType {
+		return 0, "", fmt.Errorf("確認リンクが無効または期限切れです: %w", ErrInvalidInput)
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, "", fmt.Errorf("確認リンクが無効または期限切れです: %w", ErrInvalidInput)
+	}
+	email, _ := claims["email"].(string)
+	return uint(userID), email, nil
+}
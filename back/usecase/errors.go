@@ -0,0 +1,19 @@
+package usecase
+
+import "errors"
+
+// コントローラーでHTTPステータスを判定するためのエラーです。
+var (
+	// ErrNotFound は対象のリソースが存在しないことを表します。
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden は対象のリソースを操作する権限がないことを表します。
+	ErrForbidden = errors.New("forbidden")
+	// ErrUnauthorized は認証情報が無効または失効していることを表します。
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInvalidInput はリクエストの内容が不正であることを表します。
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrConflict は現在の状態では操作を受け付けられないことを表します。
+	ErrConflict = errors.New("conflict")
+	// ErrTooManyAttempts は試行回数の超過により一時的に拒否されたことを表します。
+	ErrTooManyAttempts = errors.New("too many attempts")
+)
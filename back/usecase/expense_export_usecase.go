@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/utils"
+)
+
+// exportHeader はCSVとXLSXの見出し行です。
+var exportHeader = []string{"ID", "日付", "店名", "カテゴリ", "金額", "支払者", "メモ"}
+
+type ExpenseExportUsecase interface {
+	// ExportExpenses は検索条件に一致する支出を指定の形式で w に書き出します。
+	// 検索条件の誤りなど書き出し前に発生したエラーの場合、w には何も書き込みません。
+	ExportExpenses(ctx context.Context, userID uint, params api.GetExpensesExportParams, w io.Writer) error
+}
+
+type expenseExportUsecase struct {
+	er expense.ExpenseRepository
+	ur user.UserRepository
+	hr household.HouseholdRepository
+}
+
+func NewExpenseExportUsecase(er expense.ExpenseRepository, ur user.UserRepository, hr household.HouseholdRepository) ExpenseExportUsecase {
+	return &expenseExportUsecase{er: er, ur: ur, hr: hr}
+}
+
+func (xu *expenseExportUsecase) ExportExpenses(ctx context.Context, userID uint, params api.GetExpensesExportParams, w io.Writer) error {
+	currentUser, err := xu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return fmt.Errorf("current user not found")
+	}
+
+	settings, err := findSettings(ctx, xu.hr, currentUser.HouseholdID)
+	if err != nil {
+		return err
+	}
+	q, err := newExpenseQuery(currentUser.HouseholdID, settings, toExpensesParams(params))
+	if err != nil {
+		return err
+	}
+	// 全件をメモリに載せないよう、カーソルで区切って取得しながら書き出す
+	q.Limit = expense.MaxQueryLimit
+
+	expenses, err := xu.er.Find(ctx, q)
+	if err != nil {
+		return findExpensesError(err)
+	}
+
+	ew, err := newExpenseExportWriter(w, params)
+	if err != nil {
+		return err
+	}
+
+	payerNames := make(map[uint]string)
+	for {
+		for _, domainExpense := range expenses {
+			if err := ew.Write(xu.toExportResponse(ctx, domainExpense, payerNames)); err != nil {
+				return err
+			}
+		}
+		if len(expenses) < q.Limit {
+			break
+		}
+
+		cursor := q.CursorOf(expenses[len(expenses)-1])
+		q.Cursor = &cursor
+		if expenses, err = xu.er.Find(ctx, q); err != nil {
+			return err
+		}
+	}
+
+	return ew.Close()
+}
+
+func (xu *expenseExportUsecase) toExportResponse(ctx context.Context, domainExpense *expense.Expense, payerNames map[uint]string) api.ExpenseResponse {
+	payerID := uint(domainExpense.PayerID)
+	payerName, ok := payerNames[payerID]
+	if !ok {
+		payerName = "不明"
+		if payer, err := xu.ur.FindByID(ctx, payerID); err == nil && payer != nil {
+			payerName = payer.Name.Value()
+		}
+		payerNames[payerID] = payerName
+	}
+
+	memo := domainExpense.Memo.Value()
+	return api.ExpenseResponse{
+		Id:         int(domainExpense.ID.Value()),
+		UserId:     int(domainExpense.UserID),
+		Amount:     domainExpense.Amount.Value(),
+		StoreName:  domainExpense.StoreName.Value(),
+		Date:       domainExpense.Date,
+		Category:   domainExpense.Category.Value(),
+		CategoryId: toCategoryIDResponse(domainExpense),
+		Memo:       &memo,
+		CreatedAt:  domainExpense.CreatedAt,
+		PayerId:    toPayerIDResponse(domainExpense),
+		PayerName:  &payerName,
+		Shares:     toExpenseSharesResponse(domainExpense),
+	}
+}
+
+// toExpensesParams は書き出しの条件を一覧取得の検索条件に変換します。
+// 書き出しでは並び順の指定がなければ日付の古い順にします。
+func toExpensesParams(params api.GetExpensesExportParams) api.GetExpensesParams {
+	order := api.GetExpensesParamsOrderAsc
+	if params.Order != nil {
+		order = api.GetExpensesParamsOrder(*params.Order)
+	}
+	var sort *api.GetExpensesParamsSort
+	if params.Sort != nil {
+		s := api.GetExpensesParamsSort(*params.Sort)
+		sort = &s
+	}
+
+	return api.GetExpensesParams{
+		Year:       params.Year,
+		Month:      params.Month,
+		From:       params.From,
+		To:         params.To,
+		Category:   params.Category,
+		CategoryId: params.CategoryId,
+		PayerId:    params.PayerId,
+		AmountMin:  params.AmountMin,
+		AmountMax:  params.AmountMax,
+		Q:          params.Q,
+		Sort:       sort,
+		Order:      &order,
+	}
+}
+
+// expenseExportWriter は書き出し形式ごとに支出を1件ずつ出力します。
+type expenseExportWriter interface {
+	Write(res api.ExpenseResponse) error
+	Close() error
+}
+
+func newExpenseExportWriter(w io.Writer, params api.GetExpensesExportParams) (expenseExportWriter, error) {
+	switch params.Format {
+	case api.GetExpensesExportParamsFormatCsv:
+		encoding := api.GetExpensesExportParamsEncodingUtf8
+		if params.Encoding != nil {
+			encoding = *params.Encoding
+		}
+		ew, err := utils.NewEncodingWriter(w, string(encoding))
+		if err != nil {
+			return nil, err
+		}
+		cw := csv.NewWriter(ew)
+		if err := cw.Write(exportHeader); err != nil {
+			return nil, err
+		}
+		return &csvExportWriter{enc: ew, cw: cw}, nil
+	case api.GetExpensesExportParamsFormatJsonl:
+		return &jsonlExportWriter{enc: json.NewEncoder(w)}, nil
+	case api.GetExpensesExportParamsFormatXlsx:
+		xw, err := utils.NewXLSXWriter(w, "支出")
+		if err != nil {
+			return nil, err
+		}
+		header := make([]any, len(exportHeader))
+		for i, h := range exportHeader {
+			header[i] = h
+		}
+		if err := xw.WriteRow(header); err != nil {
+			return nil, err
+		}
+		return &xlsxExportWriter{xw: xw}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", params.Format)
+	}
+}
+
+type csvExportWriter struct {
+	enc io.WriteCloser
+	cw  *csv.Writer
+}
+
+func (w *csvExportWriter) Write(res api.ExpenseResponse) error {
+	return w.cw.Write([]string{
+		strconv.Itoa(res.Id),
+		res.Date.Format(time.DateOnly),
+		res.StoreName,
+		res.Category,
+		strconv.Itoa(res.Amount),
+		derefString(res.PayerName),
+		derefString(res.Memo),
+	})
+}
+
+func (w *csvExportWriter) Close() error {
+	w.cw.Flush()
+	if err := w.cw.Error(); err != nil {
+		return err
+	}
+	return w.enc.Close()
+}
+
+type jsonlExportWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlExportWriter) Write(res api.ExpenseResponse) error {
+	return w.enc.Encode(res)
+}
+
+func (w *jsonlExportWriter) Close() error {
+	return nil
+}
+
+type xlsxExportWriter struct {
+	xw *utils.XLSXWriter
+}
+
+func (w *xlsxExportWriter) Write(res api.ExpenseResponse) error {
+	return w.xw.WriteRow([]any{
+		res.Id,
+		res.Date,
+		res.StoreName,
+		res.Category,
+		res.Amount,
+		derefString(res.PayerName),
+		derefString(res.Memo),
+	})
+}
+
+func (w *xlsxExportWriter) Close() error {
+	return w.xw.Close()
+}
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/yanatoritakuma/budget/back/domain/category"
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/utils"
+	"golang.org/x/text/width"
+)
+
+// 取り込みファイルの上限
+const (
+	MaxImportFileSize = 5 << 20
+	MaxImportRows     = 5000
+)
+
+// ExpenseImportOptions はCSV取り込みの設定です。
+type ExpenseImportOptions struct {
+	Mapping         api.ExpenseImportMapping
+	Encoding        string
+	HasHeader       bool
+	DryRun          bool
+	DefaultCategory string
+	PayerID         *int
+}
+
+type ExpenseImportUsecase interface {
+	ImportExpenses(ctx context.Context, userID uint, file io.Reader, opts ExpenseImportOptions) (api.ExpenseImportResult, error)
+}
+
+type expenseImportUsecase struct {
+	ur  user.UserRepository
+	cr  category.CategoryRepository
+	mr  household.MemberRepository
+	uow UnitOfWork
+}
+
+func NewExpenseImportUsecase(ur user.UserRepository, cr category.CategoryRepository, mr household.MemberRepository, uow UnitOfWork) ExpenseImportUsecase {
+	return &expenseImportUsecase{ur: ur, cr: cr, mr: mr, uow: uow}
+}
+
+// ImportExpenses はCSVの各行を支出として検証し、取り込み結果を返します。
+// カテゴリはドライランでも検証し、世帯に存在しないカテゴリの行は取り込みません。
+// ドライランでなければ、検証を通過した行を1つのトランザクションでまとめて登録します。
+func (iu *expenseImportUsecase) ImportExpenses(ctx context.Context, userID uint, file io.Reader, opts ExpenseImportOptions) (api.ExpenseImportResult, error) {
+	currentUser, err := iu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return api.ExpenseImportResult{}, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return api.ExpenseImportResult{}, fmt.Errorf("current user not found")
+	}
+	if _, err := requireEditor(ctx, iu.mr, userID); err != nil {
+		return api.ExpenseImportResult{}, err
+	}
+
+	payerID := currentUser.ID.Value()
+	if opts.PayerID != nil {
+		payer, err := iu.ur.FindByID(ctx, uint(*opts.PayerID))
+		if err != nil {
+			return api.ExpenseImportResult{}, fmt.Errorf("failed to get payer: %w", err)
+		}
+		if payer == nil || payer.HouseholdID != currentUser.HouseholdID {
+			return api.ExpenseImportResult{}, fmt.Errorf("支払者は同じ世帯のメンバーである必要があります")
+		}
+		payerID = payer.ID.Value()
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, MaxImportFileSize+1))
+	if err != nil {
+		return api.ExpenseImportResult{}, err
+	}
+	if len(data) > MaxImportFileSize {
+		return api.ExpenseImportResult{}, fmt.Errorf("ファイルサイズは%dMB以内にしてください", MaxImportFileSize>>20)
+	}
+	decoded, err := utils.DecodeText(data, opts.Encoding)
+	if err != nil {
+		return api.ExpenseImportResult{}, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(decoded))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var columns importColumns
+	if opts.HasHeader {
+		header, err := reader.Read()
+		if err != nil {
+			return api.ExpenseImportResult{}, fmt.Errorf("ヘッダー行を読み込めません: %w", err)
+		}
+		columns, err = resolveImportColumns(opts.Mapping, header)
+		if err != nil {
+			return api.ExpenseImportResult{}, err
+		}
+	} else {
+		columns, err = resolveImportColumns(opts.Mapping, nil)
+		if err != nil {
+			return api.ExpenseImportResult{}, err
+		}
+	}
+
+	res := api.ExpenseImportResult{
+		DryRun: opts.DryRun,
+		Rows:   []api.ExpenseImportRow{},
+	}
+	var accepted []*expense.Expense
+	categories := map[string]*category.Category{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return api.ExpenseImportResult{}, fmt.Errorf("%d行目を読み込めません: %w", line, err)
+		}
+		if res.TotalRows >= MaxImportRows {
+			return api.ExpenseImportResult{}, fmt.Errorf("一度に取り込めるのは%d行までです", MaxImportRows)
+		}
+		res.TotalRows++
+
+		row, domainExpense := parseImportRow(line, record, columns, opts.DefaultCategory, currentUser.HouseholdID, currentUser.ID.Value(), payerID)
+		if domainExpense != nil {
+			if err := iu.applyCategory(ctx, categories, currentUser.HouseholdID, domainExpense); err != nil {
+				if !errors.Is(err, ErrInvalidInput) {
+					return api.ExpenseImportResult{}, err
+				}
+				row.Accepted = false
+				row.Errors = append(row.Errors, err.Error())
+				domainExpense = nil
+			}
+		}
+		res.Rows = append(res.Rows, row)
+		if domainExpense == nil {
+			res.RejectedCount++
+			continue
+		}
+		res.AcceptedCount++
+		accepted = append(accepted, domainExpense)
+	}
+
+	if opts.DryRun || len(accepted) == 0 {
+		return res, nil
+	}
+
+	err = iu.uow.Transaction(func(repos Repositories) error {
+		for _, domainExpense := range accepted {
+			if err := repos.Expense.CreateExpense(ctx, domainExpense); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return api.ExpenseImportResult{}, fmt.Errorf("支出の登録に失敗しました: %w", err)
+	}
+	res.ImportedCount = len(accepted)
+
+	return res, nil
+}
+
+// applyCategory は行のカテゴリ名に対応する世帯のカテゴリを支出に紐付けます。同じ名前の検索結果は使い回します。
+func (iu *expenseImportUsecase) applyCategory(ctx context.Context, categories map[string]*category.Category, householdID uint, domainExpense *expense.Expense) error {
+	name := domainExpense.Category.Value()
+	domainCategory, ok := categories[name]
+	if !ok {
+		var err error
+		domainCategory, err = resolveCategory(ctx, iu.cr, householdID, nil, name)
+		if err != nil {
+			return err
+		}
+		categories[name] = domainCategory
+	}
+	return domainExpense.SetCategory(domainCategory.ID.Value(), domainCategory.Name.Value())
+}
+
+// importColumns は各項目に対応する列番号（0始まり）です。未指定の項目は-1です。
+type importColumns struct {
+	date      int
+	amount    int
+	storeName int
+	category  int
+	memo      int
+}
+
+// resolveImportColumns は列の指定をヘッダー名または1始まりの列番号として解釈します。
+func resolveImportColumns(mapping api.ExpenseImportMapping, header []string) (importColumns, error) {
+	resolve := func(field string, spec *string, required bool) (int, error) {
+		if spec == nil || strings.TrimSpace(*spec) == "" {
+			if required {
+				return -1, fmt.Errorf("%s の列を指定してください", field)
+			}
+			return -1, nil
+		}
+		name := strings.TrimSpace(*spec)
+		for i, h := range header {
+			if strings.TrimSpace(h) == name {
+				return i, nil
+			}
+		}
+		if n, err := strconv.Atoi(name); err == nil && n >= 1 {
+			return n - 1, nil
+		}
+		return -1, fmt.Errorf("%s の列が見つかりません: %s", field, name)
+	}
+
+	var columns importColumns
+	var err error
+	if columns.date, err = resolve("date", &mapping.Date, true); err != nil {
+		return importColumns{}, err
+	}
+	if columns.amount, err = resolve("amount", &mapping.Amount, true); err != nil {
+		return importColumns{}, err
+	}
+	if columns.storeName, err = resolve("store_name", &mapping.StoreName, true); err != nil {
+		return importColumns{}, err
+	}
+	if columns.category, err = resolve("category", mapping.Category, false); err != nil {
+		return importColumns{}, err
+	}
+	if columns.memo, err = resolve("memo", mapping.Memo, false); err != nil {
+		return importColumns{}, err
+	}
+	return columns, nil
+}
+
+// parseImportRow は1行分の値を検証し、取り込み可能であれば支出エンティティを返します。
+func parseImportRow(line int, record []string, columns importColumns, defaultCategory string, householdID uint, userID uint, payerID uint) (api.ExpenseImportRow, *expense.Expense) {
+	value := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	row := api.ExpenseImportRow{
+		Line:      line,
+		StoreName: value(columns.storeName),
+		Category:  value(columns.category),
+		Memo:      value(columns.memo),
+		Errors:    []string{},
+	}
+	if row.Category == "" {
+		row.Category = defaultCategory
+	}
+
+	date, err := parseImportDate(value(columns.date))
+	if err != nil {
+		row.Errors = append(row.Errors, err.Error())
+	} else {
+		row.Date = &openapi_types.Date{Time: date}
+	}
+	amount, err := parseImportAmount(value(columns.amount))
+	if err != nil {
+		row.Errors = append(row.Errors, err.Error())
+	} else {
+		row.Amount = &amount
+	}
+	if len(row.Errors) > 0 {
+		return row, nil
+	}
+
+	domainExpense, err := expense.NewExpense(householdID, amount, row.StoreName, date, row.Category, row.Memo, userID, payerID)
+	if err != nil {
+		row.Errors = append(row.Errors, err.Error())
+		return row, nil
+	}
+	row.Accepted = true
+	return row, domainExpense
+}
+
+// importDateLayouts は銀行やカード会社の明細でよく使われる日付形式です。
+var importDateLayouts = []string{
+	"2006/1/2",
+	"2006-1-2",
+	"2006.1.2",
+	"20060102",
+	"2006年1月2日",
+}
+
+func parseImportDate(s string) (time.Time, error) {
+	s = width.Narrow.String(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("日付が空です")
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("日付の形式が不正です: %s", s)
+}
+
+var amountReplacer = strings.NewReplacer(",", "", "¥", "", "\\", "", "円", "", " ", "")
+
+func parseImportAmount(s string) (int, error) {
+	s = amountReplacer.Replace(width.Narrow.String(s))
+	if s == "" {
+		return 0, fmt.Errorf("金額が空です")
+	}
+	amount, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("金額の形式が不正です: %s", s)
+	}
+	if amount < 0 {
+		return 0, fmt.Errorf("マイナスの金額は取り込めません: %d", amount)
+	}
+	return amount, nil
+}
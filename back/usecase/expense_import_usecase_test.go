@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+func TestExpenseImportUsecaseDryRunValidatesCategories(t *testing.T) {
+	f := newExpenseFixture(t)
+	categoryColumn := "category"
+	csv := "date,amount,store,category\n" +
+		"2026/10/01,1000,store,食費\n" +
+		"2026/10/02,2000,store,新しいカテゴリ\n" +
+		"2026/10/03,3000,store,旧カテゴリ\n"
+	cr := newCategoryRepositoryFixture(t)
+	// ドライランではトランザクションを使わないため UnitOfWork は不要
+	iu := NewExpenseImportUsecase(f.ur, cr, f.mr, nil)
+
+	res, err := iu.ImportExpenses(context.Background(), ownerID, strings.NewReader(csv), ExpenseImportOptions{
+		Mapping:   api.ExpenseImportMapping{Date: "date", Amount: "amount", StoreName: "store", Category: &categoryColumn},
+		HasHeader: true,
+		DryRun:    true,
+	})
+	if err != nil {
+		t.Fatalf("ImportExpenses() error = %v", err)
+	}
+	if res.AcceptedCount != 1 || res.RejectedCount != 2 {
+		t.Fatalf("ImportExpenses() accepted %d, rejected %d, want 1 and 2", res.AcceptedCount, res.RejectedCount)
+	}
+	for i, want := range []bool{true, false, false} {
+		row := res.Rows[i]
+		if row.Accepted != want || (!want && len(row.Errors) == 0) {
+			t.Errorf("row %d = %+v, want accepted %v", row.Line, row, want)
+		}
+	}
+	if len(cr.created) != 0 {
+		t.Errorf("ImportExpenses() created %d categories, want none", len(cr.created))
+	}
+}
@@ -2,43 +2,52 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/yanatoritakuma/budget/back/domain/category"
 	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
 	"github.com/yanatoritakuma/budget/back/domain/user"
 	"github.com/yanatoritakuma/budget/back/internal/api"
 )
 
 type ExpenseUsecase interface {
 	CreateExpense(ctx context.Context, req api.ExpenseRequest) (api.ExpenseResponse, error)
-	GetExpense(ctx context.Context, userID uint, year int, month int, category *string) ([]api.ExpenseResponse, error)
-	UpdateExpense(ctx context.Context, req api.ExpenseRequest, expenseId uint) (api.ExpenseResponse, error)
-	DeleteExpense(ctx context.Context, expenseId uint) error
+	// GetExpense は検索条件に一致する支出と、次のページがある場合はそのカーソルを返します。
+	GetExpense(ctx context.Context, userID uint, params api.GetExpensesParams) ([]api.ExpenseResponse, *string, error)
+	// UpdateExpense は同じ世帯の支出のみ更新できます。存在しない場合は ErrNotFound、他の世帯の支出の場合や閲覧者の場合は ErrForbidden を返します。
+	UpdateExpense(ctx context.Context, userID uint, req api.ExpenseRequest, expenseId uint) (api.ExpenseResponse, error)
+	// DeleteExpense は同じ世帯の支出のみ削除できます。エラーは UpdateExpense と同様です。
+	DeleteExpense(ctx context.Context, userID uint, expenseId uint) error
+	GetExpenseSummary(ctx context.Context, userID uint, from time.Time, to time.Time, groupBy string) (api.ExpenseSummaryResponse, error)
 }
 
 type expenseUsecase struct {
 	er expense.ExpenseRepository
 	ur user.UserRepository
+	cr category.CategoryRepository
+	mr household.MemberRepository
+	hr household.HouseholdRepository
 }
 
-func NewExpenseUsecase(er expense.ExpenseRepository, ur user.UserRepository) ExpenseUsecase {
-	return &expenseUsecase{er: er, ur: ur}
+func NewExpenseUsecase(er expense.ExpenseRepository, ur user.UserRepository, cr category.CategoryRepository, mr household.MemberRepository, hr household.HouseholdRepository) ExpenseUsecase {
+	return &expenseUsecase{er: er, ur: ur, cr: cr, mr: mr, hr: hr}
 }
 
 func (eu *expenseUsecase) CreateExpense(ctx context.Context, req api.ExpenseRequest) (api.ExpenseResponse, error) {
+	if _, err := requireEditor(ctx, eu.mr, uint(req.UserId)); err != nil {
+		return api.ExpenseResponse{}, err
+	}
+
 	memo := ""
 	if req.Memo != nil {
 		memo = *req.Memo
 	}
-	domainExpense, err := expense.NewExpense(
-		req.Amount,
-		req.StoreName,
-		req.Date,
-		req.Category,
-		memo,
-		uint(req.UserId),
-		uint(req.UserId), // PayerID is the same as UserID for now
-	)
+	domainExpense, err := eu.newDomainExpense(ctx, req, memo, false)
 	if err != nil {
 		return api.ExpenseResponse{}, err
 	}
@@ -48,31 +57,55 @@ func (eu *expenseUsecase) CreateExpense(ctx context.Context, req api.ExpenseRequ
 	}
 
 	resExpense := api.ExpenseResponse{
-		Id:        int(domainExpense.ID.Value()),
-		UserId:    int(domainExpense.UserID),
-		Amount:    domainExpense.Amount.Value(),
-		StoreName: domainExpense.StoreName.Value(),
-		Date:      domainExpense.Date,
-		Category:  domainExpense.Category.Value(),
-		Memo:      &memo,
-		CreatedAt: domainExpense.CreatedAt,
+		Id:         int(domainExpense.ID.Value()),
+		UserId:     int(domainExpense.UserID),
+		Amount:     domainExpense.Amount.Value(),
+		StoreName:  domainExpense.StoreName.Value(),
+		Date:       domainExpense.Date,
+		Category:   domainExpense.Category.Value(),
+		CategoryId: toCategoryIDResponse(domainExpense),
+		Memo:       &memo,
+		CreatedAt:  domainExpense.CreatedAt,
+		PayerId:    toPayerIDResponse(domainExpense),
+		Shares:     toExpenseSharesResponse(domainExpense),
 	}
 
 	return resExpense, nil
 }
 
-func (eu *expenseUsecase) GetExpense(ctx context.Context, userID uint, year int, month int, category *string) ([]api.ExpenseResponse, error) {
+func (eu *expenseUsecase) GetExpense(ctx context.Context, userID uint, params api.GetExpensesParams) ([]api.ExpenseResponse, *string, error) {
 	currentUser, err := eu.ur.FindByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
+		return nil, nil, fmt.Errorf("failed to get current user: %w", err)
 	}
 	if currentUser == nil {
-		return nil, fmt.Errorf("current user not found")
+		return nil, nil, fmt.Errorf("current user not found")
 	}
 
-	expenses, err := eu.er.GetExpense(ctx, currentUser.HouseholdID, year, month, category)
+	settings, err := findSettings(ctx, eu.hr, currentUser.HouseholdID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	q, err := newExpenseQuery(currentUser.HouseholdID, settings, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 次のページの有無を判定するため1件多く取得する
+	limit := q.Limit
+	if limit > 0 {
+		q.Limit = limit + 1
+	}
+	expenses, err := eu.er.Find(ctx, q)
+	if err != nil {
+		return nil, nil, findExpensesError(err)
+	}
+
+	var nextCursor *string
+	if limit > 0 && len(expenses) > limit {
+		expenses = expenses[:limit]
+		cursor := q.CursorOf(expenses[limit-1]).Encode()
+		nextCursor = &cursor
 	}
 
 	var expenseResponses []api.ExpenseResponse
@@ -89,74 +122,373 @@ func (eu *expenseUsecase) GetExpense(ctx context.Context, userID uint, year int,
 
 		memo := domainExpense.Memo.Value()
 		expenseResponse := api.ExpenseResponse{
-			Id:        int(domainExpense.ID.Value()),
-			UserId:    int(domainExpense.UserID),
-			Amount:    domainExpense.Amount.Value(),
-			StoreName: domainExpense.StoreName.Value(),
-			Date:      domainExpense.Date,
-			Category:  domainExpense.Category.Value(),
-			Memo:      &memo,
-			CreatedAt: domainExpense.CreatedAt,
-			PayerName: &payerName,
+			Id:         int(domainExpense.ID.Value()),
+			UserId:     int(domainExpense.UserID),
+			Amount:     domainExpense.Amount.Value(),
+			StoreName:  domainExpense.StoreName.Value(),
+			Date:       domainExpense.Date,
+			Category:   domainExpense.Category.Value(),
+			CategoryId: toCategoryIDResponse(domainExpense),
+			Memo:       &memo,
+			CreatedAt:  domainExpense.CreatedAt,
+			PayerId:    toPayerIDResponse(domainExpense),
+			PayerName:  &payerName,
+			Shares:     toExpenseSharesResponse(domainExpense),
 		}
 		expenseResponses = append(expenseResponses, expenseResponse)
 	}
 
-	return expenseResponses, nil
+	return expenseResponses, nextCursor, nil
 }
 
-func (eu *expenseUsecase) UpdateExpense(ctx context.Context, req api.ExpenseRequest, expenseId uint) (api.ExpenseResponse, error) {
+func (eu *expenseUsecase) UpdateExpense(ctx context.Context, userID uint, req api.ExpenseRequest, expenseId uint) (api.ExpenseResponse, error) {
+	existingExpense, err := eu.findHouseholdExpense(ctx, userID, expenseId)
+	if err != nil {
+		return api.ExpenseResponse{}, err
+	}
+
 	memo := ""
 	if req.Memo != nil {
 		memo = *req.Memo
 	}
+	// 支払者の指定がなければ登録済みの支払者を引き継ぐ
+	req.UserId = int(userID)
+	if req.PayerId == nil {
+		payerID := int(existingExpense.PayerID)
+		req.PayerId = &payerID
+	}
+
+	// 匿名化された支出は、元メンバーを支払者・負担者としたまま編集できる
+	domainExpense, err := eu.newDomainExpense(ctx, req, memo, existingExpense.InvolvesFormerMember())
+	if err != nil {
+		return api.ExpenseResponse{}, err
+	}
+	domainExpense.ID = existingExpense.ID
+	domainExpense.HouseholdID = existingExpense.HouseholdID
+	domainExpense.UserID = existingExpense.UserID
+	domainExpense.CreatedAt = existingExpense.CreatedAt
+	domainExpense.RecurringExpenseID = existingExpense.RecurringExpenseID
+
+	if err := eu.er.UpdateExpense(ctx, domainExpense); err != nil {
+		return api.ExpenseResponse{}, err
+	}
+
+	payerName := "不明"
+	if domainExpense.PayerID != expense.FormerMemberID {
+		payer, err := eu.ur.FindByID(ctx, uint(domainExpense.PayerID))
+		if err != nil {
+			return api.ExpenseResponse{}, err
+		}
+		if payer == nil {
+			return api.ExpenseResponse{}, fmt.Errorf("payer not found")
+		}
+		payerName = payer.Name.Value()
+	}
+	resMemo := domainExpense.Memo.Value()
+
+	resExpense := api.ExpenseResponse{
+		Id:         int(domainExpense.ID.Value()),
+		UserId:     int(domainExpense.UserID),
+		Amount:     domainExpense.Amount.Value(),
+		StoreName:  domainExpense.StoreName.Value(),
+		Date:       domainExpense.Date,
+		Category:   domainExpense.Category.Value(),
+		CategoryId: toCategoryIDResponse(domainExpense),
+		Memo:       &resMemo,
+		CreatedAt:  domainExpense.CreatedAt,
+		PayerId:    toPayerIDResponse(domainExpense),
+		PayerName:  &payerName,
+		Shares:     toExpenseSharesResponse(domainExpense),
+	}
+	return resExpense, nil
+}
+
+func (eu *expenseUsecase) DeleteExpense(ctx context.Context, userID uint, expenseId uint) error {
+	existingExpense, err := eu.findHouseholdExpense(ctx, userID, expenseId)
+	if err != nil {
+		return err
+	}
+	if err := eu.er.DeleteExpense(ctx, existingExpense.ID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// findHouseholdExpense は支出を取得し、支出がユーザーの世帯に属し、ユーザーが記録を編集できることを確認します。
+func (eu *expenseUsecase) findHouseholdExpense(ctx context.Context, userID uint, expenseId uint) (*expense.Expense, error) {
+	existingExpense, err := eu.er.FindByID(ctx, expense.ExpenseID(expenseId))
+	if err != nil {
+		return nil, err
+	}
+	if existingExpense == nil {
+		return nil, fmt.Errorf("expense %d: %w", expenseId, ErrNotFound)
+	}
+
+	member, err := requireEditor(ctx, eu.mr, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existingExpense.HouseholdID != member.HouseholdID {
+		return nil, fmt.Errorf("expense %d: %w", expenseId, ErrForbidden)
+	}
+	return existingExpense, nil
+}
+
+// findExpensesError は支出の検索で発生したエラーのうち、カーソルの値が不正なものを入力エラーとして返します。
+func findExpensesError(err error) error {
+	if errors.Is(err, expense.ErrInvalidCursor) {
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	return err
+}
+
+// newExpenseQuery はリクエストのパラメータから支出の検索条件を生成します。
+// 期間は from/to が優先され、指定がなければ year/month の月全体を対象とします。月の区切りは世帯の設定に従います。
+func newExpenseQuery(householdID uint, settings household.Settings, params api.GetExpensesParams) (expense.Query, error) {
+	sortField, err := expense.NewSortField(string(derefSort(params.Sort)))
+	if err != nil {
+		return expense.Query{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	sortOrder, err := expense.NewSortOrder(string(derefOrder(params.Order)))
+	if err != nil {
+		return expense.Query{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	q := expense.Query{
+		HouseholdID: householdID,
+		SortField:   sortField,
+		SortOrder:   sortOrder,
+		AmountMin:   params.AmountMin,
+		AmountMax:   params.AmountMax,
+		Limit:       derefInt(params.Limit),
+	}
+
+	if (params.Year == nil) != (params.Month == nil) {
+		return expense.Query{}, fmt.Errorf("年と月は両方指定してください: %w", ErrInvalidInput)
+	}
+	if params.Year != nil {
+		from, to := settings.MonthRange(*params.Year, *params.Month)
+		q.From, q.To = &from, &to
+	}
+	if params.From != nil {
+		q.From = &params.From.Time
+	}
+	if params.To != nil {
+		q.To = &params.To.Time
+	}
+
+	if params.Category != nil {
+		for _, name := range *params.Category {
+			voCategory, err := expense.NewCategory(name)
+			if err != nil {
+				return expense.Query{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+			}
+			q.Categories = append(q.Categories, voCategory.Value())
+		}
+	}
+	if params.CategoryId != nil {
+		for _, id := range *params.CategoryId {
+			q.CategoryIDs = append(q.CategoryIDs, uint(id))
+		}
+	}
+	if params.PayerId != nil {
+		payerID := uint(*params.PayerId)
+		q.PayerID = &payerID
+	}
+	if params.Q != nil {
+		q.Text = strings.TrimSpace(*params.Q)
+	}
+	if params.Cursor != nil && *params.Cursor != "" {
+		cursor, err := expense.DecodeCursor(*params.Cursor)
+		if err != nil {
+			return expense.Query{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+		q.Cursor = cursor
+		if q.Limit == 0 {
+			q.Limit = defaultExpensePageSize
+		}
+	}
+
+	if err := q.Validate(); err != nil {
+		return expense.Query{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	return q, nil
+}
+
+// defaultExpensePageSize はカーソル指定時に件数の指定がない場合の取得件数です。
+const defaultExpensePageSize = 100
+
+func derefSort(p *api.GetExpensesParamsSort) api.GetExpensesParamsSort {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func derefOrder(p *api.GetExpensesParamsOrder) api.GetExpensesParamsOrder {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+// GetExpenseSummary は期間内の支出を集計単位ごとに集計して返します。
+func (eu *expenseUsecase) GetExpenseSummary(ctx context.Context, userID uint, from time.Time, to time.Time, groupBy string) (api.ExpenseSummaryResponse, error) {
+	currentUser, err := eu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return api.ExpenseSummaryResponse{}, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return api.ExpenseSummaryResponse{}, fmt.Errorf("current user not found")
+	}
+
+	period, err := expense.NewSummaryPeriod(from, to)
+	if err != nil {
+		return api.ExpenseSummaryResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	voGroupBy, err := expense.NewSummaryGroupBy(groupBy)
+	if err != nil {
+		return api.ExpenseSummaryResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	settings, err := findSettings(ctx, eu.hr, currentUser.HouseholdID)
+	if err != nil {
+		return api.ExpenseSummaryResponse{}, err
+	}
+
+	rows, err := eu.er.GetSummary(ctx, currentUser.HouseholdID, period, voGroupBy, calendarOf(settings))
+	if err != nil {
+		return api.ExpenseSummaryResponse{}, err
+	}
+
+	res := api.ExpenseSummaryResponse{
+		From:     openapi_types.Date{Time: period.From},
+		To:       openapi_types.Date{Time: period.To},
+		GroupBy:  api.ExpenseSummaryGroupBy(voGroupBy),
+		Currency: settings.Currency,
+		Items:    make([]api.ExpenseSummaryItem, 0, len(rows)),
+	}
+	for _, row := range rows {
+		res.Items = append(res.Items, api.ExpenseSummaryItem{
+			Key:   row.Key,
+			Label: row.Label,
+			Total: row.Total,
+			Count: row.Count,
+		})
+		res.Total += row.Total
+		res.Count += row.Count
+	}
+	return res, nil
+}
+
+// newDomainExpense はリクエストから支出エンティティを生成します。
+// 支払者と負担者は登録者と同じ世帯のメンバーである必要があります。allowFormerMember の場合は元メンバーも指定できます。
+func (eu *expenseUsecase) newDomainExpense(ctx context.Context, req api.ExpenseRequest, memo string, allowFormerMember bool) (*expense.Expense, error) {
+	payerID := uint(req.UserId)
+	if req.PayerId != nil {
+		payerID = uint(*req.PayerId)
+	}
 
+	householdID, memberIDs, err := eu.householdMemberIDs(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, err
+	}
 	domainExpense, err := expense.NewExpense(
+		householdID,
 		req.Amount,
 		req.StoreName,
-		req.Date,
+		req.Date.Time,
 		req.Category,
 		memo,
 		uint(req.UserId),
-		uint(req.UserId), // PayerID is the same as UserID for now
+		payerID,
 	)
 	if err != nil {
-		return api.ExpenseResponse{}, err
+		return nil, err
 	}
-	domainExpense.ID = expense.ExpenseID(expenseId)
 
-	if err := eu.er.UpdateExpense(ctx, domainExpense); err != nil {
-		return api.ExpenseResponse{}, err
+	if req.Shares != nil {
+		var shares []expense.Share
+		for _, s := range *req.Shares {
+			share, err := expense.NewShare(uint(s.UserId), s.Amount)
+			if err != nil {
+				return nil, err
+			}
+			shares = append(shares, share)
+		}
+		if err := domainExpense.SetShares(shares); err != nil {
+			return nil, err
+		}
+	}
+
+	if allowFormerMember {
+		memberIDs[expense.FormerMemberID] = true
+	}
+	if !memberIDs[payerID] {
+		return nil, fmt.Errorf("支払者は同じ世帯のメンバーである必要があります")
+	}
+	for _, share := range domainExpense.Shares {
+		if !memberIDs[uint(share.UserID)] {
+			return nil, fmt.Errorf("負担者は同じ世帯のメンバーである必要があります")
+		}
 	}
 
-	payer, err := eu.ur.FindByID(ctx, uint(domainExpense.UserID))
+	domainCategory, err := resolveCategory(ctx, eu.cr, householdID, req.CategoryId, req.Category)
 	if err != nil {
-		return api.ExpenseResponse{}, err
+		return nil, err
 	}
-	if payer == nil {
-		return api.ExpenseResponse{}, fmt.Errorf("payer not found")
+	if err := domainExpense.SetCategory(domainCategory.ID.Value(), domainCategory.Name.Value()); err != nil {
+		return nil, err
 	}
 
-	payerName := payer.Name.Value()
-	resMemo := domainExpense.Memo.Value()
+	return domainExpense, nil
+}
 
-	resExpense := api.ExpenseResponse{
-		Id:        int(domainExpense.ID.Value()),
-		UserId:    int(domainExpense.UserID),
-		Amount:    domainExpense.Amount.Value(),
-		StoreName: domainExpense.StoreName.Value(),
-		Date:      domainExpense.Date,
-		Category:  domainExpense.Category.Value(),
-		Memo:      &resMemo,
-		CreatedAt: domainExpense.CreatedAt,
-		PayerName: &payerName,
+// householdMemberIDs はユーザーの世帯IDと、同じ世帯に属するメンバーのIDを返します。
+func (eu *expenseUsecase) householdMemberIDs(ctx context.Context, userID uint) (uint, map[uint]bool, error) {
+	currentUser, err := eu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get current user: %w", err)
 	}
-	return resExpense, nil
+	if currentUser == nil {
+		return 0, nil, fmt.Errorf("current user not found")
+	}
+
+	members, err := eu.ur.FindByHouseholdID(ctx, currentUser.HouseholdID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get household users: %w", err)
+	}
+
+	memberIDs := make(map[uint]bool, len(members))
+	for _, member := range members {
+		memberIDs[member.ID.Value()] = true
+	}
+	return currentUser.HouseholdID, memberIDs, nil
 }
 
-func (eu *expenseUsecase) DeleteExpense(ctx context.Context, expenseId uint) error {
-	if err := eu.er.DeleteExpense(ctx, expense.ExpenseID(expenseId)); err != nil {
-		return err
+func toPayerIDResponse(domainExpense *expense.Expense) *int {
+	payerID := int(domainExpense.PayerID)
+	return &payerID
+}
+
+func toCategoryIDResponse(domainExpense *expense.Expense) *int {
+	if domainExpense.CategoryID == nil {
+		return nil
 	}
-	return nil
+	categoryID := int(*domainExpense.CategoryID)
+	return &categoryID
+}
+
+func toExpenseSharesResponse(domainExpense *expense.Expense) *[]api.ExpenseShare {
+	if !domainExpense.HasCustomShares() {
+		return nil
+	}
+	shares := make([]api.ExpenseShare, 0, len(domainExpense.Shares))
+	for _, share := range domainExpense.Shares {
+		shares = append(shares, api.ExpenseShare{
+			UserId: int(share.UserID),
+			Amount: share.Amount.Value(),
+		})
+	}
+	return &shares
 }
@@ -0,0 +1,386 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/yanatoritakuma/budget/back/domain/category"
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+// 以下のフェイクはテストで使うメソッドのみ実装し、それ以外は埋め込んだインターフェースに委ねます（呼ばれるとpanicします）。
+
+type fakeExpenseRepository struct {
+	expense.ExpenseRepository
+	expenses map[expense.ExpenseID]*expense.Expense
+	queries  []expense.Query
+	updated  []expense.ExpenseID
+	deleted  []expense.ExpenseID
+}
+
+func (r *fakeExpenseRepository) FindByID(_ context.Context, id expense.ExpenseID) (*expense.Expense, error) {
+	e, ok := r.expenses[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (r *fakeExpenseRepository) Find(_ context.Context, q expense.Query) ([]*expense.Expense, error) {
+	r.queries = append(r.queries, q)
+	var result []*expense.Expense
+	for _, e := range r.expenses {
+		if e.HouseholdID == q.HouseholdID {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeExpenseRepository) UpdateExpense(_ context.Context, e *expense.Expense) error {
+	r.expenses[e.ID] = e
+	r.updated = append(r.updated, e.ID)
+	return nil
+}
+
+func (r *fakeExpenseRepository) DeleteExpense(_ context.Context, id expense.ExpenseID) error {
+	delete(r.expenses, id)
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type fakeUserRepository struct {
+	user.UserRepository
+	users map[uint]*user.User
+}
+
+func (r *fakeUserRepository) FindByID(_ context.Context, id uint) (*user.User, error) {
+	return r.users[id], nil
+}
+
+func (r *fakeUserRepository) FindByHouseholdID(_ context.Context, householdID uint) ([]*user.User, error) {
+	var result []*user.User
+	for _, u := range r.users {
+		if u.HouseholdID == householdID {
+			result = append(result, u)
+		}
+	}
+	return result, nil
+}
+
+type fakeMemberRepository struct {
+	household.MemberRepository
+	members map[uint]*household.Member
+}
+
+func (r *fakeMemberRepository) FindByUserID(_ context.Context, userID uint) (*household.Member, error) {
+	return r.members[userID], nil
+}
+
+type fakeHouseholdRepository struct {
+	household.HouseholdRepository
+}
+
+func (r *fakeHouseholdRepository) FindByID(_ context.Context, _ uint) (*household.Household, error) {
+	return nil, nil
+}
+
+type fakeCategoryRepository struct {
+	category.CategoryRepository
+	categories []*category.Category
+	created    []*category.Category
+}
+
+func (r *fakeCategoryRepository) FindByName(_ context.Context, householdID uint, name string) (*category.Category, error) {
+	for _, c := range r.categories {
+		if c.HouseholdID == householdID && c.Name.Value() == name {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeCategoryRepository) FindByHouseholdID(_ context.Context, householdID uint, _ bool) ([]*category.Category, error) {
+	var result []*category.Category
+	for _, c := range r.categories {
+		if c.HouseholdID == householdID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeCategoryRepository) Create(_ context.Context, c *category.Category) error {
+	c.ID = category.CategoryID(1000 + len(r.created))
+	r.categories = append(r.categories, c)
+	r.created = append(r.created, c)
+	return nil
+}
+
+func (r *fakeCategoryRepository) FindByID(_ context.Context, id uint) (*category.Category, error) {
+	for _, c := range r.categories {
+		if c.ID.Value() == id {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+const (
+	// 世帯1: ユーザー1（所有者）、ユーザー2（閲覧者）。世帯2: ユーザー3（所有者）。ユーザー4はどの世帯にも所属していない。
+	ownHousehold   uint = 1
+	otherHousehold uint = 2
+	ownerID        uint = 1
+	viewerID       uint = 2
+	otherOwnerID   uint = 3
+	nonMemberID    uint = 4
+
+	ownExpenseID     uint = 10
+	otherExpenseID   uint = 20
+	missingExpenseID uint = 99
+	ownCategoryID    uint = 100
+)
+
+type expenseFixture struct {
+	usecase ExpenseUsecase
+	er      *fakeExpenseRepository
+	ur      *fakeUserRepository
+	mr      *fakeMemberRepository
+}
+
+func newExpenseFixture(t *testing.T) expenseFixture {
+	t.Helper()
+	newUser := func(id uint, householdID uint) *user.User {
+		return &user.User{ID: user.UserID(id), Name: user.Name("user"), HouseholdID: householdID}
+	}
+	newExpense := func(id uint, householdID uint, userID uint) *expense.Expense {
+		e, err := expense.NewExpense(householdID, 1000, "store", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), "食費", "", userID, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.ID = expense.ExpenseID(id)
+		return e
+	}
+	ownCategory, err := category.NewCategory(ownHousehold, "食費", "", "", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownCategory.ID = category.CategoryID(ownCategoryID)
+
+	er := &fakeExpenseRepository{expenses: map[expense.ExpenseID]*expense.Expense{
+		expense.ExpenseID(ownExpenseID):   newExpense(ownExpenseID, ownHousehold, ownerID),
+		expense.ExpenseID(otherExpenseID): newExpense(otherExpenseID, otherHousehold, otherOwnerID),
+	}}
+	ur := &fakeUserRepository{users: map[uint]*user.User{
+		ownerID:      newUser(ownerID, ownHousehold),
+		viewerID:     newUser(viewerID, ownHousehold),
+		otherOwnerID: newUser(otherOwnerID, otherHousehold),
+		nonMemberID:  newUser(nonMemberID, 0),
+	}}
+	mr := &fakeMemberRepository{members: map[uint]*household.Member{
+		ownerID:      household.NewMember(ownHousehold, ownerID, household.RoleOwner),
+		viewerID:     household.NewMember(ownHousehold, viewerID, household.RoleViewer),
+		otherOwnerID: household.NewMember(otherHousehold, otherOwnerID, household.RoleOwner),
+	}}
+	cr := &fakeCategoryRepository{categories: []*category.Category{ownCategory}}
+
+	return expenseFixture{
+		usecase: NewExpenseUsecase(er, ur, cr, mr, &fakeHouseholdRepository{}),
+		er:      er,
+		ur:      ur,
+		mr:      mr,
+	}
+}
+
+// accessCases は他の世帯の支出や存在しない支出、権限のないユーザーからの変更を拒否することを確認するケースです。
+var accessCases = []struct {
+	name      string
+	userID    uint
+	expenseID uint
+	wantErr   error
+}{
+	{"own household", ownerID, ownExpenseID, nil},
+	{"other household", ownerID, otherExpenseID, ErrForbidden},
+	{"other household from the other side", otherOwnerID, ownExpenseID, ErrForbidden},
+	{"missing expense", ownerID, missingExpenseID, ErrNotFound},
+	{"viewer", viewerID, ownExpenseID, ErrForbidden},
+	{"not a member", nonMemberID, ownExpenseID, ErrForbidden},
+}
+
+func TestExpenseUsecaseUpdateExpenseAccess(t *testing.T) {
+	for _, tt := range accessCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExpenseFixture(t)
+			categoryID := int(ownCategoryID)
+			req := api.ExpenseRequest{
+				Amount:     2500,
+				StoreName:  "updated",
+				Date:       openapi_types.Date{Time: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+				Category:   "食費",
+				CategoryId: &categoryID,
+			}
+
+			res, err := f.usecase.UpdateExpense(context.Background(), tt.userID, req, tt.expenseID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UpdateExpense() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(f.er.updated) != 0 {
+					t.Errorf("UpdateExpense() updated %v, want no update", f.er.updated)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateExpense() error = %v", err)
+			}
+			if res.Amount != 2500 || res.StoreName != "updated" {
+				t.Errorf("UpdateExpense() = %+v, want updated amount and store name", res)
+			}
+			updated := f.er.expenses[expense.ExpenseID(tt.expenseID)]
+			if updated.HouseholdID != ownHousehold {
+				t.Errorf("HouseholdID = %d, want %d", updated.HouseholdID, ownHousehold)
+			}
+		})
+	}
+}
+
+func TestExpenseUsecaseDeleteExpenseAccess(t *testing.T) {
+	for _, tt := range accessCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExpenseFixture(t)
+
+			err := f.usecase.DeleteExpense(context.Background(), tt.userID, tt.expenseID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DeleteExpense() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(f.er.deleted) != 0 {
+					t.Errorf("DeleteExpense() deleted %v, want no deletion", f.er.deleted)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteExpense() error = %v", err)
+			}
+			if _, ok := f.er.expenses[expense.ExpenseID(tt.expenseID)]; ok {
+				t.Errorf("expense %d was not deleted", tt.expenseID)
+			}
+		})
+	}
+}
+
+func TestExpenseUsecaseGetExpenseOnlyOwnHousehold(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        uint
+		wantHousehold uint
+		wantExpenseID uint
+	}{
+		{"owner", ownerID, ownHousehold, ownExpenseID},
+		{"viewer", viewerID, ownHousehold, ownExpenseID},
+		{"other household", otherOwnerID, otherHousehold, otherExpenseID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExpenseFixture(t)
+
+			res, _, err := f.usecase.GetExpense(context.Background(), tt.userID, api.GetExpensesParams{})
+			if err != nil {
+				t.Fatalf("GetExpense() error = %v", err)
+			}
+			if len(f.er.queries) != 1 || f.er.queries[0].HouseholdID != tt.wantHousehold {
+				t.Fatalf("queries = %+v, want a query for household %d", f.er.queries, tt.wantHousehold)
+			}
+			if len(res) != 1 || res[0].Id != int(tt.wantExpenseID) {
+				t.Errorf("GetExpense() = %+v, want only expense %d", res, tt.wantExpenseID)
+			}
+		})
+	}
+}
+
+func TestExpenseUsecaseUpdateAnonymizedExpense(t *testing.T) {
+	f := newExpenseFixture(t)
+	anonymized := f.er.expenses[expense.ExpenseID(ownExpenseID)]
+	anonymized.UserID = 0
+	anonymized.PayerID = expense.FormerMemberID
+	categoryID := int(ownCategoryID)
+	req := api.ExpenseRequest{
+		Amount:     1200,
+		StoreName:  "updated",
+		Date:       openapi_types.Date{Time: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)},
+		Category:   "食費",
+		CategoryId: &categoryID,
+	}
+
+	res, err := f.usecase.UpdateExpense(context.Background(), ownerID, req, ownExpenseID)
+	if err != nil {
+		t.Fatalf("UpdateExpense() error = %v", err)
+	}
+	if res.PayerId == nil || *res.PayerId != expense.FormerMemberID || res.PayerName == nil || *res.PayerName != "不明" {
+		t.Errorf("UpdateExpense() payer = %v %v, want the former member", res.PayerId, res.PayerName)
+	}
+
+	// 通常の支出では元メンバーを支払者に指定できない
+	formerMember := expense.FormerMemberID
+	req.PayerId = &formerMember
+	if _, err := newExpenseFixture(t).usecase.UpdateExpense(context.Background(), ownerID, req, ownExpenseID); err == nil || errors.Is(err, ErrForbidden) {
+		t.Errorf("UpdateExpense() with the former member as payer error = %v, want a validation error", err)
+	}
+}
+
+func TestExpenseUsecaseGetExpenseInvalidParams(t *testing.T) {
+	year, limit, min, max := 2026, expense.MaxQueryLimit+1, 2000, 1000
+	cursor := "invalid"
+	tests := []struct {
+		name   string
+		params api.GetExpensesParams
+	}{
+		{"year without month", api.GetExpensesParams{Year: &year}},
+		{"malformed cursor", api.GetExpensesParams{Cursor: &cursor}},
+		{"limit over the maximum", api.GetExpensesParams{Limit: &limit}},
+		{"amount range reversed", api.GetExpensesParams{AmountMin: &min, AmountMax: &max}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExpenseFixture(t)
+
+			_, _, err := f.usecase.GetExpense(context.Background(), ownerID, tt.params)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("GetExpense() error = %v, want %v", err, ErrInvalidInput)
+			}
+			if len(f.er.queries) != 0 {
+				t.Errorf("queries = %+v, want no query", f.er.queries)
+			}
+		})
+	}
+}
+
+func TestExpenseUsecaseGetExpenseSummaryInvalidParams(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		to      time.Time
+		groupBy string
+	}{
+		{"unknown group_by", from, "unknown"},
+		{"end before start", from.AddDate(0, 0, -1), "category"},
+		{"period too long", from.AddDate(0, 0, expense.MaxSummaryDays+1), "category"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExpenseFixture(t)
+
+			_, err := f.usecase.GetExpenseSummary(context.Background(), ownerID, from, tt.to, tt.groupBy)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("GetExpenseSummary() error = %v, want %v", err, ErrInvalidInput)
+			}
+		})
+	}
+}
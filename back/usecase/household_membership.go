@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanatoritakuma/budget/back/domain/household"
+)
+
+// departure は世帯を抜けるメンバーと、世帯に残る所有者を示します。
+type departure struct {
+	member *household.Member
+	// owner は世帯に残る所有者です。残るメンバーがいない場合は nil です。
+	owner     *household.Member
+	remaining int
+}
+
+// beginDeparture はユーザーが世帯を抜けられることを確認します。
+// 他のメンバーがいる世帯の所有者は、所有権を移譲するまで抜けられません。
+func beginDeparture(ctx context.Context, repos Repositories, userID uint) (*departure, error) {
+	member, err := findMember(ctx, repos.Member, userID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := repos.Member.FindByHouseholdID(ctx, member.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household members: %w", err)
+	}
+
+	d := &departure{member: member}
+	for _, m := range members {
+		if m.UserID == userID {
+			continue
+		}
+		d.remaining++
+		if m.Role == household.RoleOwner {
+			d.owner = m
+		}
+	}
+	if d.remaining > 0 && member.Role == household.RoleOwner {
+		return nil, fmt.Errorf("世帯の所有権を他のメンバーに移譲してください: %w", ErrConflict)
+	}
+	return d, nil
+}
+
+// finish はメンバーが抜けた後の世帯を片付けます。
+// メンバーがいなくなった世帯は削除し、それ以外は分担ルールからメンバーの分担割合を外します。
+func (d *departure) finish(ctx context.Context, repos Repositories) error {
+	if d.remaining == 0 {
+		if err := repos.Household.Delete(ctx, d.member.HouseholdID); err != nil {
+			return fmt.Errorf("failed to delete empty household: %w", err)
+		}
+		return nil
+	}
+
+	previous, err := repos.Household.FindByID(ctx, d.member.HouseholdID)
+	if err != nil {
+		return fmt.Errorf("could not find household: %w", err)
+	}
+	if previous == nil {
+		return nil
+	}
+	previous.ChangeSplitRule(previous.SplitRule.WithoutMember(d.member.UserID))
+	return repos.Household.Update(ctx, previous)
+}
+
+// moveMember はユーザーを現在の世帯から抜けさせ、destinationID の世帯に role で所属させます。
+// ユーザーが登録した過去の記録は handoff に従って引き継ぎます。
+func moveMember(ctx context.Context, repos Repositories, userID uint, destinationID uint, role household.Role, handoff household.Handoff) error {
+	d, err := beginDeparture(ctx, repos, userID)
+	if err != nil {
+		return err
+	}
+	if d.member.HouseholdID == destinationID {
+		return nil
+	}
+
+	// 残るメンバーがいない世帯は削除するため、記録はユーザーとともに移す
+	if d.owner == nil {
+		handoff = household.HandoffMove
+	}
+	if err := handOffRecords(ctx, repos, d, destinationID, handoff); err != nil {
+		return err
+	}
+
+	domainUser, err := repos.User.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("could not find user: %w", err)
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user not found")
+	}
+	domainUser.HouseholdID = destinationID
+	if err := repos.User.Update(ctx, domainUser); err != nil {
+		return fmt.Errorf("failed to update user's household: %w", err)
+	}
+	if err := repos.Member.Save(ctx, household.NewMember(destinationID, userID, role)); err != nil {
+		return err
+	}
+	return d.finish(ctx, repos)
+}
+
+// handOffRecords はメンバーが登録した支出・収入と、世帯の定期支出を handoff に従って引き継ぎます。
+// 収入は登録者の世帯で集計するため、世帯に残す場合は登録者を所有者に引き継ぎます。
+// 匿名化する場合は、支出の登録者・支払者・負担者と収入の受取人からメンバーへの参照を外します。
+// 定期支出は世帯に残るため、どの場合も登録者と支払者を所有者に引き継ぎます。
+func handOffRecords(ctx context.Context, repos Repositories, d *departure, destinationID uint, handoff household.Handoff) error {
+	member := d.member
+	switch handoff {
+	case household.HandoffMove:
+		categoryIDs, err := mapCategories(ctx, repos, member.HouseholdID, destinationID)
+		if err != nil {
+			return err
+		}
+		if err := repos.Expense.MoveToHousehold(ctx, member.HouseholdID, member.UserID, destinationID, categoryIDs); err != nil {
+			return fmt.Errorf("failed to move expenses: %w", err)
+		}
+		if err := repos.Income.MakePersonal(ctx, member.UserID); err != nil {
+			return fmt.Errorf("failed to move incomes: %w", err)
+		}
+	case household.HandoffStay, household.HandoffAnonymize:
+		if err := repos.Income.ReassignCreator(ctx, member.UserID, d.owner.UserID); err != nil {
+			return fmt.Errorf("failed to hand over incomes: %w", err)
+		}
+		if handoff == household.HandoffAnonymize {
+			if err := repos.Expense.Anonymize(ctx, member.HouseholdID, member.UserID); err != nil {
+				return fmt.Errorf("failed to anonymize expenses: %w", err)
+			}
+			if err := repos.Income.AnonymizeRecipient(ctx, member.HouseholdID, member.UserID); err != nil {
+				return fmt.Errorf("failed to anonymize incomes: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("記録の扱いが不正です: %s: %w", handoff, ErrInvalidInput)
+	}
+
+	if d.owner == nil {
+		return nil
+	}
+	if err := repos.Recurring.ReassignMember(ctx, member.HouseholdID, member.UserID, d.owner.UserID); err != nil {
+		return fmt.Errorf("failed to hand over recurring expenses: %w", err)
+	}
+	return nil
+}
+
+// mapCategories は移動元の世帯のカテゴリを、移動先の世帯の同じ名前のカテゴリに対応付けます。
+// 移動先に同じ名前のカテゴリがない場合は作成します。
+func mapCategories(ctx context.Context, repos Repositories, fromHouseholdID uint, toHouseholdID uint) (map[uint]uint, error) {
+	categories, err := repos.Category.FindByHouseholdID(ctx, fromHouseholdID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	categoryIDs := make(map[uint]uint, len(categories))
+	for _, c := range categories {
+		destination, err := findOrCreateCategory(ctx, repos.Category, toHouseholdID, c.Name.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve category %s: %w", c.Name.Value(), err)
+		}
+		categoryIDs[c.ID.Value()] = destination.ID.Value()
+	}
+	return categoryIDs, nil
+}
+
+// createPersonalHousehold はユーザーが一人で所属する新しい世帯を初期カテゴリとともに作成します。
+func createPersonalHousehold(ctx context.Context, repos Repositories, userID uint) (*household.Household, error) {
+	domainUser, err := repos.User.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find user: %w", err)
+	}
+	if domainUser == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	name := domainUser.Name.Value()
+	if name == "" {
+		name = "Unknown"
+	}
+	domainHousehold, err := household.NewHousehold(fmt.Sprintf("%s's Household", name))
+	if err != nil {
+		return nil, err
+	}
+	if err := repos.Household.Create(ctx, domainHousehold); err != nil {
+		return nil, err
+	}
+	if err := createDefaultCategories(ctx, repos.Category, domainHousehold.ID.Value()); err != nil {
+		return nil, err
+	}
+	return domainHousehold, nil
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanatoritakuma/budget/back/domain/household"
+)
+
+// findMember はユーザーの世帯での所属を返します。所属していない場合は ErrForbidden を返します。
+func findMember(ctx context.Context, mr household.MemberRepository, userID uint) (*household.Member, error) {
+	member, err := mr.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household member: %w", err)
+	}
+	if member == nil {
+		return nil, fmt.Errorf("世帯に所属していません: %w", ErrForbidden)
+	}
+	return member, nil
+}
+
+// requireEditor はユーザーが世帯の記録を登録・編集できることを確認します。閲覧者の場合は ErrForbidden を返します。
+func requireEditor(ctx context.Context, mr household.MemberRepository, userID uint) (*household.Member, error) {
+	member, err := findMember(ctx, mr, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.Role.CanEdit() {
+		return nil, fmt.Errorf("閲覧者は記録を変更できません: %w", ErrForbidden)
+	}
+	return member, nil
+}
+
+// requireOwner はユーザーが世帯の所有者であることを確認します。
+func requireOwner(ctx context.Context, mr household.MemberRepository, userID uint) (*household.Member, error) {
+	member, err := findMember(ctx, mr, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role != household.RoleOwner {
+		return nil, fmt.Errorf("世帯の所有者のみ実行できます: %w", ErrForbidden)
+	}
+	return member, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+)
+
+// findSettings は世帯の設定を返します。世帯が見つからない場合は既定の設定を返します。
+func findSettings(ctx context.Context, hr household.HouseholdRepository, householdID uint) (household.Settings, error) {
+	domainHousehold, err := hr.FindByID(ctx, householdID)
+	if err != nil {
+		return household.Settings{}, fmt.Errorf("could not find household: %w", err)
+	}
+	if domainHousehold == nil {
+		return household.DefaultSettings(), nil
+	}
+	return domainHousehold.Settings, nil
+}
+
+// calendarOf は世帯の設定から集計に使う週と月の区切りを返します。
+func calendarOf(settings household.Settings) expense.Calendar {
+	return expense.Calendar{
+		WeekStart:     settings.WeekStart,
+		MonthStartDay: settings.MonthStartDay,
+	}
+}
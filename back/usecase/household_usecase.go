@@ -3,59 +3,587 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/yanatoritakuma/budget/back/domain/expense"
 	"github.com/yanatoritakuma/budget/back/domain/household" // Added
+	"github.com/yanatoritakuma/budget/back/domain/settlement"
 	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
 	"github.com/yanatoritakuma/budget/back/utils"
 )
 
 type HouseholdUsecase interface {
+	// GenerateInviteCode は招待コードを発行します。招待の管理は世帯の所有者のみ実行できます。
 	GenerateInviteCode(userID uint) (string, error)
+	CreateInvitation(ctx context.Context, userID uint, req api.InvitationRequest) (api.InvitationResponse, error)
+	ListInvitations(ctx context.Context, userID uint) ([]api.InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, userID uint, id uint) error
+	GetSplitRule(ctx context.Context, userID uint) (api.SplitRule, error)
+	// UpdateSplitRule は分担ルールを更新します。所有者と管理者のみ実行できます。
+	UpdateSplitRule(ctx context.Context, userID uint, req api.SplitRule) (api.SplitRule, error)
+	GetSettings(ctx context.Context, userID uint) (api.HouseholdSettings, error)
+	// UpdateSettings は世帯の設定を更新します。所有者と管理者のみ実行できます。
+	UpdateSettings(ctx context.Context, userID uint, req api.HouseholdSettings) (api.HouseholdSettings, error)
+	GetSettlement(ctx context.Context, userID uint, year int, month int) (api.SettlementResponse, error)
+	ListMembers(ctx context.Context, userID uint) ([]api.HouseholdMemberResponse, error)
+	// ChangeMemberRole はメンバーの役割を変更します。変更できる役割は Role.CanAssign に従います。
+	ChangeMemberRole(ctx context.Context, userID uint, targetUserID uint, req api.HouseholdRoleRequest) (api.HouseholdMemberResponse, error)
+	// TransferOwnership は所有権を他のメンバーに移譲します。元の所有者は管理者になります。
+	TransferOwnership(ctx context.Context, userID uint, req api.HouseholdOwnerRequest) error
+	// Leave は世帯を抜け、ユーザー一人の新しい世帯に移ります。
+	Leave(ctx context.Context, userID uint, req api.HouseholdLeaveRequest) error
+	// RemoveMember はメンバーを世帯から外します。外されたメンバーは一人の新しい世帯に移ります。
+	RemoveMember(ctx context.Context, userID uint, targetUserID uint, params api.DeleteHouseholdMembersUserIdParams) error
 }
 
 type householdUsecase struct {
-	hr household.HouseholdRepository
-	ur user.UserRepository
+	hr  household.HouseholdRepository
+	ur  user.UserRepository
+	er  expense.ExpenseRepository
+	mr  household.MemberRepository
+	ir  household.InvitationRepository
+	uow UnitOfWork
 }
 
-func NewHouseholdUsecase(hr household.HouseholdRepository, ur user.UserRepository) HouseholdUsecase { // Changed hr type
-	return &householdUsecase{hr, ur}
+func NewHouseholdUsecase(hr household.HouseholdRepository, ur user.UserRepository, er expense.ExpenseRepository, mr household.MemberRepository, ir household.InvitationRepository, uow UnitOfWork) HouseholdUsecase { // Changed hr type
+	return &householdUsecase{hr, ur, er, mr, ir, uow}
 }
 
+// GenerateInviteCode は既定の有効期間で、人数を制限しない招待を発行します。
 func (hu *householdUsecase) GenerateInviteCode(userID uint) (string, error) {
 	ctx := context.Background()
 
-	// Get user to find their household
+	owner, err := requireOwner(ctx, hu.mr, userID)
+	if err != nil {
+		return "", err
+	}
+
+	invitation, err := newInvitation(owner, household.RoleMember, household.DefaultInvitationTTL, 0)
+	if err != nil {
+		return "", err
+	}
+	if err := hu.ir.Create(ctx, invitation); err != nil {
+		return "", fmt.Errorf("could not save invitation: %w", err)
+	}
+
+	return invitation.Code.Value(), nil
+}
+
+// CreateInvitation は有効期間・人数・対象を指定して招待を発行します。
+func (hu *householdUsecase) CreateInvitation(ctx context.Context, userID uint, req api.InvitationRequest) (api.InvitationResponse, error) {
+	owner, err := requireOwner(ctx, hu.mr, userID)
+	if err != nil {
+		return api.InvitationResponse{}, err
+	}
+
+	role := household.RoleMember
+	if req.Role != nil {
+		role, err = household.NewRole(string(*req.Role))
+		if err != nil {
+			return api.InvitationResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+	}
+	ttl := household.DefaultInvitationTTL
+	if req.ExpiresInHours != nil {
+		ttl = time.Duration(*req.ExpiresInHours) * time.Hour
+	}
+	maxUses := 0
+	if req.MaxUses != nil {
+		maxUses = *req.MaxUses
+	}
+
+	invitation, err := newInvitation(owner, role, ttl, maxUses)
+	if err != nil {
+		return api.InvitationResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	if req.Email != nil {
+		if err := invitation.RestrictToEmail(string(*req.Email)); err != nil {
+			return api.InvitationResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+	}
+	if req.Provider != nil || req.Subject != nil {
+		if err := invitation.RestrictToIdentity(derefString(req.Provider), derefString(req.Subject)); err != nil {
+			return api.InvitationResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+	}
+
+	if err := hu.ir.Create(ctx, invitation); err != nil {
+		return api.InvitationResponse{}, fmt.Errorf("could not save invitation: %w", err)
+	}
+	return hu.toInvitationResponse(ctx, invitation, nil), nil
+}
+
+// ListInvitations は世帯の招待を新しい順に、参加の記録とともに返します。
+func (hu *householdUsecase) ListInvitations(ctx context.Context, userID uint) ([]api.InvitationResponse, error) {
+	owner, err := requireOwner(ctx, hu.mr, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations, err := hu.ir.FindByHouseholdID(ctx, owner.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	redemptions, err := hu.ir.FindRedemptionsByHouseholdID(ctx, owner.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation redemptions: %w", err)
+	}
+	redemptionsByInvitation := make(map[uint][]*household.Redemption)
+	for _, r := range redemptions {
+		redemptionsByInvitation[r.InvitationID] = append(redemptionsByInvitation[r.InvitationID], r)
+	}
+
+	res := []api.InvitationResponse{}
+	for _, invitation := range invitations {
+		res = append(res, hu.toInvitationResponse(ctx, invitation, redemptionsByInvitation[invitation.ID]))
+	}
+	return res, nil
+}
+
+// RevokeInvitation は招待を取り消します。取り消した招待では参加できなくなります。
+func (hu *householdUsecase) RevokeInvitation(ctx context.Context, userID uint, id uint) error {
+	owner, err := requireOwner(ctx, hu.mr, userID)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := hu.ir.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation == nil || invitation.HouseholdID != owner.HouseholdID {
+		return fmt.Errorf("invitation %d: %w", id, ErrNotFound)
+	}
+
+	invitation.Revoke(time.Now())
+	if err := hu.ir.Update(ctx, invitation); err != nil {
+		return fmt.Errorf("could not revoke invitation: %w", err)
+	}
+	return nil
+}
+
+// newInvitation は所有者の世帯への招待を新しい招待コードで生成します。
+func newInvitation(owner *household.Member, role household.Role, ttl time.Duration, maxUses int) (*household.Invitation, error) {
+	code, err := household.NewInviteCode(utils.GenerateRandomString(household.InviteCodeLength))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a valid invite code: %w", err)
+	}
+	return household.NewInvitation(owner.HouseholdID, owner.UserID, code, role, ttl, maxUses)
+}
+
+func (hu *householdUsecase) toInvitationResponse(ctx context.Context, i *household.Invitation, redemptions []*household.Redemption) api.InvitationResponse {
+	res := api.InvitationResponse{
+		Id:          int(i.ID),
+		Code:        i.Code.Value(),
+		Role:        api.HouseholdRole(i.Role.Value()),
+		Status:      api.InvitationResponseStatus(i.Status(time.Now())),
+		MaxUses:     i.MaxUses,
+		Uses:        i.Uses,
+		ExpiresAt:   i.ExpiresAt,
+		RevokedAt:   i.RevokedAt,
+		CreatedAt:   i.CreatedAt,
+		Redemptions: []api.InvitationRedemption{},
+	}
+	if i.CreatedBy != 0 {
+		createdBy := int(i.CreatedBy)
+		res.CreatedBy = &createdBy
+	}
+	if i.TargetEmail != "" {
+		email := openapi_types.Email(i.TargetEmail)
+		res.Email = &email
+	}
+	if i.IsTargetedToIdentity() {
+		provider, subject := i.TargetProvider, i.TargetSubject
+		res.Provider, res.Subject = &provider, &subject
+	}
+	for _, r := range redemptions {
+		redemption := api.InvitationRedemption{JoinedAt: r.JoinedAt}
+		if r.UserID != 0 {
+			userID := int(r.UserID)
+			redemption.UserId = &userID
+			if joined, err := hu.ur.FindByID(ctx, r.UserID); err == nil && joined != nil {
+				name := joined.Name.Value()
+				redemption.Name = &name
+			}
+		}
+		res.Redemptions = append(res.Redemptions, redemption)
+	}
+	return res
+}
+
+// GetSplitRule は世帯の支出分担ルールを取得します。
+func (hu *householdUsecase) GetSplitRule(ctx context.Context, userID uint) (api.SplitRule, error) {
+	domainHousehold, err := hu.findUserHousehold(ctx, userID)
+	if err != nil {
+		return api.SplitRule{}, err
+	}
+	return toSplitRuleResponse(domainHousehold.SplitRule), nil
+}
+
+// UpdateSplitRule は世帯の支出分担ルールを更新します。
+func (hu *householdUsecase) UpdateSplitRule(ctx context.Context, userID uint, req api.SplitRule) (api.SplitRule, error) {
+	member, err := findMember(ctx, hu.mr, userID)
+	if err != nil {
+		return api.SplitRule{}, err
+	}
+	if !member.Role.CanManage() {
+		return api.SplitRule{}, fmt.Errorf("分担ルールは所有者と管理者のみ変更できます: %w", ErrForbidden)
+	}
+
+	domainHousehold, err := hu.findUserHousehold(ctx, userID)
+	if err != nil {
+		return api.SplitRule{}, err
+	}
+
+	members, err := hu.ur.FindByHouseholdID(ctx, domainHousehold.ID.Value())
+	if err != nil {
+		return api.SplitRule{}, fmt.Errorf("failed to get household users: %w", err)
+	}
+	memberIDs := make(map[uint]bool, len(members))
+	for _, member := range members {
+		memberIDs[member.ID.Value()] = true
+	}
+
+	var shares []household.SplitShare
+	if req.Shares != nil {
+		for _, share := range *req.Shares {
+			if !memberIDs[uint(share.UserId)] {
+				return api.SplitRule{}, fmt.Errorf("世帯のメンバーではないユーザーが含まれています: %d: %w", share.UserId, ErrInvalidInput)
+			}
+			shares = append(shares, household.SplitShare{UserID: uint(share.UserId), Percent: share.Percent})
+		}
+	}
+
+	rule, err := household.NewSplitRule(string(req.Method), shares)
+	if err != nil {
+		return api.SplitRule{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	domainHousehold.ChangeSplitRule(rule)
+	if err := hu.hr.Update(ctx, domainHousehold); err != nil {
+		return api.SplitRule{}, fmt.Errorf("could not save split rule: %w", err)
+	}
+
+	return toSplitRuleResponse(rule), nil
+}
+
+// GetSettings は世帯の表示通貨・タイムゾーン・月と週の始まりを取得します。
+func (hu *householdUsecase) GetSettings(ctx context.Context, userID uint) (api.HouseholdSettings, error) {
+	domainHousehold, err := hu.findUserHousehold(ctx, userID)
+	if err != nil {
+		return api.HouseholdSettings{}, err
+	}
+	return toHouseholdSettingsResponse(domainHousehold.Settings), nil
+}
+
+// UpdateSettings は世帯の表示通貨・タイムゾーン・月と週の始まりを更新します。
+func (hu *householdUsecase) UpdateSettings(ctx context.Context, userID uint, req api.HouseholdSettings) (api.HouseholdSettings, error) {
+	member, err := findMember(ctx, hu.mr, userID)
+	if err != nil {
+		return api.HouseholdSettings{}, err
+	}
+	if !member.Role.CanManage() {
+		return api.HouseholdSettings{}, fmt.Errorf("世帯の設定は所有者と管理者のみ変更できます: %w", ErrForbidden)
+	}
+
+	domainHousehold, err := hu.findUserHousehold(ctx, userID)
+	if err != nil {
+		return api.HouseholdSettings{}, err
+	}
+
+	settings, err := household.NewSettings(req.Currency, req.Timezone, req.MonthStartDay, string(req.WeekStart))
+	if err != nil {
+		return api.HouseholdSettings{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	domainHousehold.ChangeSettings(settings)
+	if err := hu.hr.Update(ctx, domainHousehold); err != nil {
+		return api.HouseholdSettings{}, fmt.Errorf("could not save household settings: %w", err)
+	}
+
+	return toHouseholdSettingsResponse(settings), nil
+}
+
+// GetSettlement は指定月の支出から各メンバーの精算額と送金内容を計算します。月の区切りは世帯の設定に従います。
+func (hu *householdUsecase) GetSettlement(ctx context.Context, userID uint, year int, month int) (api.SettlementResponse, error) {
+	domainHousehold, err := hu.findUserHousehold(ctx, userID)
+	if err != nil {
+		return api.SettlementResponse{}, err
+	}
+
+	members, err := hu.ur.FindByHouseholdID(ctx, domainHousehold.ID.Value())
+	if err != nil {
+		return api.SettlementResponse{}, fmt.Errorf("failed to get household users: %w", err)
+	}
+	names := make(map[uint]string, len(members))
+	memberIDs := make([]uint, 0, len(members))
+	for _, member := range members {
+		names[member.ID.Value()] = member.Name.Value()
+		memberIDs = append(memberIDs, member.ID.Value())
+	}
+
+	from, to := domainHousehold.Settings.MonthRange(year, month)
+	expenses, err := hu.er.GetExpense(ctx, domainHousehold.ID.Value(), from, to, nil)
+	if err != nil {
+		return api.SettlementResponse{}, err
+	}
+
+	balances := settlement.Calculate(memberIDs, domainHousehold.SplitRule, expenses)
+	transfers := settlement.MinimizeTransfers(balances)
+
+	nameOf := func(id uint) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return "不明"
+	}
+
+	res := api.SettlementResponse{
+		Year:      year,
+		Month:     month,
+		From:      openapi_types.Date{Time: from},
+		To:        openapi_types.Date{Time: to},
+		Currency:  domainHousehold.Settings.Currency,
+		Members:   []api.SettlementMember{},
+		Transfers: []api.SettlementTransfer{},
+	}
+	for _, b := range balances {
+		res.Total += b.Paid
+		res.Members = append(res.Members, api.SettlementMember{
+			UserId:  int(b.UserID),
+			Name:    nameOf(b.UserID),
+			Paid:    b.Paid,
+			Owed:    b.Owed,
+			Balance: b.Net(),
+		})
+	}
+	for _, t := range transfers {
+		res.Transfers = append(res.Transfers, api.SettlementTransfer{
+			FromUserId: int(t.FromUserID),
+			FromName:   nameOf(t.FromUserID),
+			ToUserId:   int(t.ToUserID),
+			ToName:     nameOf(t.ToUserID),
+			Amount:     t.Amount,
+		})
+	}
+
+	return res, nil
+}
+
+// ListMembers は世帯のメンバーと役割を参加順に返します。
+func (hu *householdUsecase) ListMembers(ctx context.Context, userID uint) ([]api.HouseholdMemberResponse, error) {
+	member, err := findMember(ctx, hu.mr, userID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := hu.mr.FindByHouseholdID(ctx, member.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household members: %w", err)
+	}
+
+	res := []api.HouseholdMemberResponse{}
+	for _, m := range members {
+		memberRes, err := hu.toMemberResponse(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, memberRes)
+	}
+	return res, nil
+}
+
+func (hu *householdUsecase) ChangeMemberRole(ctx context.Context, userID uint, targetUserID uint, req api.HouseholdRoleRequest) (api.HouseholdMemberResponse, error) {
+	role, err := household.NewRole(string(req.Role))
+	if err != nil {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	actor, err := findMember(ctx, hu.mr, userID)
+	if err != nil {
+		return api.HouseholdMemberResponse{}, err
+	}
+	target, err := hu.mr.FindByUserID(ctx, targetUserID)
+	if err != nil {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("failed to get household member: %w", err)
+	}
+	if target == nil || target.HouseholdID != actor.HouseholdID {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("member %d: %w", targetUserID, ErrNotFound)
+	}
+	if role == household.RoleOwner {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("所有者の変更は所有権の移譲で行ってください: %w", ErrInvalidInput)
+	}
+	if !actor.Role.CanAssign(target.Role, role) {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("このメンバーの役割を変更する権限がありません: %w", ErrForbidden)
+	}
+
+	target.ChangeRole(role)
+	if err := hu.mr.Save(ctx, target); err != nil {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("could not save member role: %w", err)
+	}
+	return hu.toMemberResponse(ctx, target)
+}
+
+func (hu *householdUsecase) TransferOwnership(ctx context.Context, userID uint, req api.HouseholdOwnerRequest) error {
+	targetUserID := uint(req.UserId)
+	if targetUserID == userID {
+		return fmt.Errorf("既に世帯の所有者です: %w", ErrInvalidInput)
+	}
+
+	return hu.uow.Transaction(func(repos Repositories) error {
+		owner, err := findMember(ctx, repos.Member, userID)
+		if err != nil {
+			return err
+		}
+		if owner.Role != household.RoleOwner {
+			return fmt.Errorf("所有権は世帯の所有者のみ移譲できます: %w", ErrForbidden)
+		}
+		target, err := repos.Member.FindByUserID(ctx, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to get household member: %w", err)
+		}
+		if target == nil || target.HouseholdID != owner.HouseholdID {
+			return fmt.Errorf("member %d: %w", targetUserID, ErrNotFound)
+		}
+
+		owner.ChangeRole(household.RoleAdmin)
+		if err := repos.Member.Save(ctx, owner); err != nil {
+			return err
+		}
+		target.ChangeRole(household.RoleOwner)
+		return repos.Member.Save(ctx, target)
+	})
+}
+
+func (hu *householdUsecase) Leave(ctx context.Context, userID uint, req api.HouseholdLeaveRequest) error {
+	handoff, err := toHandoff(req.Expenses)
+	if err != nil {
+		return err
+	}
+
+	return hu.uow.Transaction(func(repos Repositories) error {
+		member, err := findMember(ctx, repos.Member, userID)
+		if err != nil {
+			return err
+		}
+		members, err := repos.Member.FindByHouseholdID(ctx, member.HouseholdID)
+		if err != nil {
+			return fmt.Errorf("failed to get household members: %w", err)
+		}
+		if len(members) <= 1 {
+			return fmt.Errorf("他のメンバーがいない世帯からは抜けられません: %w", ErrConflict)
+		}
+
+		personal, err := createPersonalHousehold(ctx, repos, userID)
+		if err != nil {
+			return err
+		}
+		return moveMember(ctx, repos, userID, personal.ID.Value(), household.RoleOwner, handoff)
+	})
+}
+
+func (hu *householdUsecase) RemoveMember(ctx context.Context, userID uint, targetUserID uint, params api.DeleteHouseholdMembersUserIdParams) error {
+	if targetUserID == userID {
+		return fmt.Errorf("自分自身を外すことはできません。世帯から抜けてください: %w", ErrInvalidInput)
+	}
+	handoff, err := toHandoff(params.Expenses)
+	if err != nil {
+		return err
+	}
+
+	return hu.uow.Transaction(func(repos Repositories) error {
+		actor, err := findMember(ctx, repos.Member, userID)
+		if err != nil {
+			return err
+		}
+		target, err := repos.Member.FindByUserID(ctx, targetUserID)
+		if err != nil {
+			return fmt.Errorf("failed to get household member: %w", err)
+		}
+		if target == nil || target.HouseholdID != actor.HouseholdID {
+			return fmt.Errorf("member %d: %w", targetUserID, ErrNotFound)
+		}
+		if !actor.Role.CanRemove(target.Role) {
+			return fmt.Errorf("このメンバーを外す権限がありません: %w", ErrForbidden)
+		}
+
+		personal, err := createPersonalHousehold(ctx, repos, targetUserID)
+		if err != nil {
+			return err
+		}
+		return moveMember(ctx, repos, targetUserID, personal.ID.Value(), household.RoleOwner, handoff)
+	})
+}
+
+// toHandoff はリクエストで指定された記録の扱いを変換します。
+func toHandoff(expenses *api.HouseholdDataHandoff) (household.Handoff, error) {
+	value := ""
+	if expenses != nil {
+		value = string(*expenses)
+	}
+	handoff, err := household.NewHandoff(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	return handoff, nil
+}
+
+func (hu *householdUsecase) toMemberResponse(ctx context.Context, m *household.Member) (api.HouseholdMemberResponse, error) {
+	res := api.HouseholdMemberResponse{
+		UserId:   int(m.UserID),
+		Role:     api.HouseholdRole(m.Role.Value()),
+		JoinedAt: m.JoinedAt,
+		Name:     "不明",
+	}
+	memberUser, err := hu.ur.FindByID(ctx, m.UserID)
+	if err != nil {
+		return api.HouseholdMemberResponse{}, fmt.Errorf("failed to get member user: %w", err)
+	}
+	if memberUser != nil {
+		image := memberUser.Image
+		res.Name = memberUser.Name.Value()
+		res.Image = &image
+	}
+	return res, nil
+}
+
+// findUserHousehold はユーザーが所属する世帯を取得します。
+func (hu *householdUsecase) findUserHousehold(ctx context.Context, userID uint) (*household.Household, error) {
 	domainUser, err := hu.ur.FindByID(ctx, userID)
 	if err != nil {
-		return "", fmt.Errorf("could not find user: %w", err)
+		return nil, fmt.Errorf("could not find user: %w", err)
 	}
 	if domainUser == nil {
-		return "", fmt.Errorf("user not found")
+		return nil, fmt.Errorf("user not found")
 	}
 
-	// Get the household
-	domainHousehold, err := hu.hr.FindByID(ctx, domainUser.HouseholdID) // Use FindByID
+	domainHousehold, err := hu.hr.FindByID(ctx, domainUser.HouseholdID)
 	if err != nil {
-		return "", fmt.Errorf("could not find household: %w", err)
+		return nil, fmt.Errorf("could not find household: %w", err)
 	}
 	if domainHousehold == nil {
-		return "", fmt.Errorf("household not found")
+		return nil, fmt.Errorf("household not found")
 	}
+	return domainHousehold, nil
+}
 
-	// Generate a unique invite code
-	inviteCodeStr := utils.GenerateRandomString(household.InviteCodeLength)
-	inviteCode, err := household.NewInviteCode(inviteCodeStr)
-	if err != nil {
-		// This should theoretically not happen if GenerateRandomString is correct
-		return "", fmt.Errorf("failed to create a valid invite code: %w", err)
+func toHouseholdSettingsResponse(settings household.Settings) api.HouseholdSettings {
+	return api.HouseholdSettings{
+		Currency:      settings.Currency,
+		Timezone:      settings.Timezone,
+		MonthStartDay: settings.MonthStartDay,
+		WeekStart:     api.HouseholdSettingsWeekStart(settings.WeekStartName()),
 	}
+}
 
-	// Save the code to the household
-	domainHousehold.GenerateNewInviteCode(inviteCode)          // Use domain method
-	if err := hu.hr.Update(ctx, domainHousehold); err != nil { // Use Update
-		return "", fmt.Errorf("could not save invite code: %w", err)
+func toSplitRuleResponse(rule household.SplitRule) api.SplitRule {
+	shares := make([]api.SplitShare, 0, len(rule.Shares))
+	for _, share := range rule.Shares {
+		shares = append(shares, api.SplitShare{UserId: int(share.UserID), Percent: share.Percent})
+	}
+	return api.SplitRule{
+		Method: api.SplitRuleMethod(rule.Method.Value()),
+		Shares: &shares,
 	}
-
-	return inviteCode.Value(), nil
 }
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/domain/passkey"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+type IdentityUsecase interface {
+	// ListIdentities はユーザーに紐付けられた外部IDプロバイダのアカウントを返します。
+	ListIdentities(ctx context.Context, userID uint) ([]api.IdentityResponse, error)
+	// UnlinkIdentity は外部IDプロバイダとの紐付けを解除します。
+	// 解除するとログインする方法が無くなる場合は ErrConflict を返します。
+	UnlinkIdentity(ctx context.Context, userID uint, id uint) error
+}
+
+type identityUsecase struct {
+	ur user.UserRepository
+	ir identity.IdentityRepository
+	pr passkey.CredentialRepository
+}
+
+func NewIdentityUsecase(ur user.UserRepository, ir identity.IdentityRepository, pr passkey.CredentialRepository) IdentityUsecase {
+	return &identityUsecase{
+		ur: ur,
+		ir: ir,
+		pr: pr,
+	}
+}
+
+func (iu *identityUsecase) ListIdentities(ctx context.Context, userID uint) ([]api.IdentityResponse, error) {
+	identities, err := iu.ir.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []api.IdentityResponse{}
+	for _, i := range identities {
+		res = append(res, api.IdentityResponse{
+			Id:        int(i.ID),
+			Provider:  i.Provider,
+			CreatedAt: i.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+func (iu *identityUsecase) UnlinkIdentity(ctx context.Context, userID uint, id uint) error {
+	identities, err := iu.ir.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(identities, func(i *identity.Identity) bool { return i.ID == id }) {
+		return fmt.Errorf("identity %d: %w", id, ErrNotFound)
+	}
+
+	if err := ensureOtherLoginMethod(ctx, iu.ur, iu.ir, iu.pr, userID); err != nil {
+		return err
+	}
+
+	deleted, err := iu.ir.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("identity %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
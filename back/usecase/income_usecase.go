@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/yanatoritakuma/budget/back/domain/expense"
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/income"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+type IncomeUsecase interface {
+	CreateIncome(ctx context.Context, userID uint, req api.IncomeRequest) (api.IncomeResponse, error)
+	GetIncomes(ctx context.Context, userID uint, year int, month int, category *string) ([]api.IncomeResponse, error)
+	UpdateIncome(ctx context.Context, userID uint, req api.IncomeRequest, incomeId uint) (api.IncomeResponse, error)
+	DeleteIncome(ctx context.Context, userID uint, incomeId uint) error
+	GetCashFlow(ctx context.Context, userID uint, year int) (api.CashFlowResponse, error)
+}
+
+type incomeUsecase struct {
+	ir income.IncomeRepository
+	er expense.ExpenseRepository
+	ur user.UserRepository
+	hr household.HouseholdRepository
+	mr household.MemberRepository
+}
+
+func NewIncomeUsecase(ir income.IncomeRepository, er expense.ExpenseRepository, ur user.UserRepository, hr household.HouseholdRepository, mr household.MemberRepository) IncomeUsecase {
+	return &incomeUsecase{ir: ir, er: er, ur: ur, hr: hr, mr: mr}
+}
+
+func (iu *incomeUsecase) CreateIncome(ctx context.Context, userID uint, req api.IncomeRequest) (api.IncomeResponse, error) {
+	if _, err := requireEditor(ctx, iu.mr, userID); err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	members, err := iu.householdMembers(ctx, userID)
+	if err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	domainIncome, err := newDomainIncome(userID, req, members)
+	if err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	if err := iu.ir.CreateIncome(ctx, domainIncome); err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	return toIncomeResponse(domainIncome, members), nil
+}
+
+func (iu *incomeUsecase) GetIncomes(ctx context.Context, userID uint, year int, month int, category *string) ([]api.IncomeResponse, error) {
+	currentUser, err := iu.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := iu.householdMembers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := findSettings(ctx, iu.hr, currentUser.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	from, to := settings.MonthRange(year, month)
+
+	incomes, err := iu.ir.GetIncome(ctx, currentUser.HouseholdID, from, to, category)
+	if err != nil {
+		return nil, err
+	}
+
+	incomeResponses := []api.IncomeResponse{}
+	for _, domainIncome := range incomes {
+		incomeResponses = append(incomeResponses, toIncomeResponse(domainIncome, members))
+	}
+	return incomeResponses, nil
+}
+
+func (iu *incomeUsecase) UpdateIncome(ctx context.Context, userID uint, req api.IncomeRequest, incomeId uint) (api.IncomeResponse, error) {
+	if _, err := requireEditor(ctx, iu.mr, userID); err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	members, err := iu.householdMembers(ctx, userID)
+	if err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	existingIncome, err := iu.findOwnIncome(ctx, incomeId, members)
+	if err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	domainIncome, err := newDomainIncome(uint(existingIncome.UserID), req, members)
+	if err != nil {
+		return api.IncomeResponse{}, err
+	}
+	domainIncome.ID = existingIncome.ID
+	domainIncome.CreatedAt = existingIncome.CreatedAt
+
+	if err := iu.ir.UpdateIncome(ctx, domainIncome); err != nil {
+		return api.IncomeResponse{}, err
+	}
+
+	return toIncomeResponse(domainIncome, members), nil
+}
+
+func (iu *incomeUsecase) DeleteIncome(ctx context.Context, userID uint, incomeId uint) error {
+	if _, err := requireEditor(ctx, iu.mr, userID); err != nil {
+		return err
+	}
+
+	members, err := iu.householdMembers(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	existingIncome, err := iu.findOwnIncome(ctx, incomeId, members)
+	if err != nil {
+		return err
+	}
+	return iu.ir.DeleteIncome(ctx, existingIncome.ID)
+}
+
+// GetCashFlow は指定年の月ごとの収入・支出・収支を返します。月の区切りは世帯の設定に従います。
+func (iu *incomeUsecase) GetCashFlow(ctx context.Context, userID uint, year int) (api.CashFlowResponse, error) {
+	currentUser, err := iu.findUser(ctx, userID)
+	if err != nil {
+		return api.CashFlowResponse{}, err
+	}
+
+	settings, err := findSettings(ctx, iu.hr, currentUser.HouseholdID)
+	if err != nil {
+		return api.CashFlowResponse{}, err
+	}
+	from, to := settings.YearRange(year)
+
+	incomeTotals, err := iu.ir.GetMonthlyTotals(ctx, currentUser.HouseholdID, from, to, settings.MonthStartDay)
+	if err != nil {
+		return api.CashFlowResponse{}, err
+	}
+
+	expenseTotals, err := iu.er.GetMonthlyTotals(ctx, currentUser.HouseholdID, from, to, settings.MonthStartDay)
+	if err != nil {
+		return api.CashFlowResponse{}, err
+	}
+
+	res := api.CashFlowResponse{
+		Year:     year,
+		Currency: settings.Currency,
+		Months:   make([]api.CashFlowMonth, 0, 12),
+	}
+	for month := 1; month <= 12; month++ {
+		incomeAmount := incomeTotals[month]
+		expenseAmount := expenseTotals[month]
+		monthFrom, monthTo := settings.MonthRange(year, month)
+		res.Months = append(res.Months, api.CashFlowMonth{
+			Month:   month,
+			From:    openapi_types.Date{Time: monthFrom},
+			To:      openapi_types.Date{Time: monthTo},
+			Income:  incomeAmount,
+			Expense: expenseAmount,
+			Balance: incomeAmount - expenseAmount,
+		})
+		res.TotalIncome += incomeAmount
+		res.TotalExpense += expenseAmount
+	}
+	res.Balance = res.TotalIncome - res.TotalExpense
+
+	return res, nil
+}
+
+func (iu *incomeUsecase) findUser(ctx context.Context, userID uint) (*user.User, error) {
+	currentUser, err := iu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, fmt.Errorf("current user not found")
+	}
+	return currentUser, nil
+}
+
+// householdMembers はユーザーの世帯メンバーをIDをキーにして返します。
+func (iu *incomeUsecase) householdMembers(ctx context.Context, userID uint) (map[uint]*user.User, error) {
+	currentUser, err := iu.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := iu.ur.FindByHouseholdID(ctx, currentUser.HouseholdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household users: %w", err)
+	}
+
+	members := make(map[uint]*user.User, len(users))
+	for _, member := range users {
+		members[member.ID.Value()] = member
+	}
+	return members, nil
+}
+
+// findOwnIncome は世帯メンバーが登録した収入を取得します。
+func (iu *incomeUsecase) findOwnIncome(ctx context.Context, incomeId uint, members map[uint]*user.User) (*income.Income, error) {
+	existingIncome, err := iu.ir.FindByID(ctx, incomeId)
+	if err != nil {
+		return nil, err
+	}
+	if existingIncome == nil {
+		return nil, fmt.Errorf("収入が見つかりません: %w", ErrNotFound)
+	}
+	if _, ok := members[uint(existingIncome.UserID)]; !ok {
+		return nil, fmt.Errorf("収入が見つかりません: %w", ErrNotFound)
+	}
+	return existingIncome, nil
+}
+
+func newDomainIncome(userID uint, req api.IncomeRequest, members map[uint]*user.User) (*income.Income, error) {
+	source := ""
+	if req.Source != nil {
+		source = *req.Source
+	}
+	memo := ""
+	if req.Memo != nil {
+		memo = *req.Memo
+	}
+
+	// 受取人の指定がなければ登録者本人とする
+	recipientID := userID
+	if req.RecipientId != nil {
+		recipientID = uint(*req.RecipientId)
+		if _, ok := members[recipientID]; !ok {
+			return nil, fmt.Errorf("受取人は世帯のメンバーから選択してください: %w", ErrInvalidInput)
+		}
+	}
+
+	domainIncome, err := income.NewIncome(req.Amount, source, req.Date.Time, req.Category, memo, userID, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	return domainIncome, nil
+}
+
+func toIncomeResponse(domainIncome *income.Income, members map[uint]*user.User) api.IncomeResponse {
+	recipientName := "不明"
+	if recipient, ok := members[uint(domainIncome.RecipientID)]; ok {
+		recipientName = recipient.Name.Value()
+	}
+	memo := domainIncome.Memo.Value()
+
+	return api.IncomeResponse{
+		Id:            int(domainIncome.ID.Value()),
+		UserId:        int(domainIncome.UserID),
+		RecipientId:   int(domainIncome.RecipientID),
+		RecipientName: &recipientName,
+		Amount:        domainIncome.Amount.Value(),
+		Source:        domainIncome.Source.Value(),
+		Date:          domainIncome.Date,
+		Category:      domainIncome.Category.Value(),
+		Memo:          &memo,
+		CreatedAt:     domainIncome.CreatedAt,
+	}
+}
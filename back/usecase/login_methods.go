@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/domain/passkey"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+)
+
+// errLastLoginMethod は最後のログイン方法を削除しようとしたときのエラーです。
+var errLastLoginMethod = fmt.Errorf("ログインする方法が無くなるため削除できません: %w", ErrConflict)
+
+// ensureOtherLoginMethod はログイン方法を1つ削除してもログインできる方法が残るかを確認します。
+// ログイン方法はパスワード・外部IDプロバイダ・パスキーです。
+func ensureOtherLoginMethod(ctx context.Context, ur user.UserRepository, ir identity.IdentityRepository, pr passkey.CredentialRepository, userID uint) error {
+	domainUser, err := ur.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+	identities, err := ir.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	credentials, err := pr.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	count := len(identities) + len(credentials)
+	if domainUser.HasPassword() {
+		count++
+	}
+	if count <= 1 {
+		return errLastLoginMethod
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/model"
+)
+
+// TooManyAttemptsError は試行回数の超過により一時的に拒否されたことを表します。
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("too many attempts, retry after %d seconds", retryAfterSeconds(e.RetryAfter))
+}
+
+// Is により errors.Is(err, ErrTooManyAttempts) で判定できます。
+func (e *TooManyAttemptsError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// RetryAfterSeconds は Retry-After ヘッダーに設定する秒数を返します。
+func (e *TooManyAttemptsError) RetryAfterSeconds() int {
+	return retryAfterSeconds(e.RetryAfter)
+}
+
+func retryAfterSeconds(d time.Duration) int {
+	seconds := int((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// throttlePolicy はログイン失敗時のバックオフの設定です。
+// FreeAttempts 回を超えて失敗するたびにロック時間を BaseDelay から倍にし、MaxDelay で打ち止めにします。
+type throttlePolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	// Window は失敗回数を保持する期間です。
+	Window time.Duration
+}
+
+var (
+	// 1つのアカウントに対する失敗は、IPアドレスを変えた攻撃も含めて数える
+	accountThrottlePolicy = throttlePolicy{FreeAttempts: 5, BaseDelay: 30 * time.Second, MaxDelay: 30 * time.Minute, Window: 24 * time.Hour}
+	// 1つのIPアドレスからの失敗は、複数のアカウントに対する攻撃も含めて数える
+	ipThrottlePolicy = throttlePolicy{FreeAttempts: 20, BaseDelay: 10 * time.Second, MaxDelay: 15 * time.Minute, Window: time.Hour}
+)
+
+// lockDuration は失敗回数に応じたロック時間を返します。ロックしない場合は0です。
+func (p throttlePolicy) lockDuration(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// LoginThrottle はパスワード認証の失敗をアカウント・IPアドレスごとに数え、総当たり攻撃を抑止します。
+type LoginThrottle interface {
+	// Check はアカウントまたはIPアドレスがロック中であれば TooManyAttemptsError を返します。
+	Check(ctx context.Context, email, clientIP string) error
+	// RecordFailure は認証の失敗を記録し、回数に応じてロックします。
+	RecordFailure(ctx context.Context, email, clientIP string) error
+	// RecordSuccess は認証の成功によりアカウントの失敗回数をリセットします。
+	RecordSuccess(ctx context.Context, email, clientIP string) error
+}
+
+type loginThrottle struct {
+	store model.AttemptStore
+}
+
+func NewLoginThrottle(store model.AttemptStore) LoginThrottle {
+	return &loginThrottle{store: store}
+}
+
+func (lt *loginThrottle) Check(ctx context.Context, email, clientIP string) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, key := range lt.keys(email, clientIP) {
+		record, err := lt.store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if record.IsLocked(now) {
+			retryAfter = max(retryAfter, record.LockedUntil.Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		return &TooManyAttemptsError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (lt *loginThrottle) RecordFailure(ctx context.Context, email, clientIP string) error {
+	keys := lt.keys(email, clientIP)
+	policies := []throttlePolicy{accountThrottlePolicy, ipThrottlePolicy}
+	for i, key := range keys {
+		record, err := lt.store.Increment(ctx, key, policies[i].Window)
+		if err != nil {
+			return err
+		}
+		if delay := policies[i].lockDuration(record.Count); delay > 0 {
+			if err := lt.store.Lock(ctx, key, time.Now().Add(delay)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RecordSuccess はIPアドレスの失敗回数はリセットしません。
+// 攻撃者が自身のアカウントへのログインでIPアドレスの回数をリセットできないようにするためです。
+func (lt *loginThrottle) RecordSuccess(ctx context.Context, email, clientIP string) error {
+	return lt.store.Reset(ctx, lt.keys(email, clientIP)[0])
+}
+
+// keys はアカウントとIPアドレスのキーを返します。メールアドレスはハッシュ化して保存します。
+func (lt *loginThrottle) keys(email, clientIP string) []string {
+	return []string{
+		"login-account-" + hashToken(strings.ToLower(strings.TrimSpace(email))),
+		"login-ip-" + clientIP,
+	}
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yanatoritakuma/budget/back/domain/mfa"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MFATokenTTL はパスワード認証後、2段階目の確認を行うまでの有効期間です。
+const MFATokenTTL = 5 * time.Minute
+
+const (
+	mfaTokenType = "pre_auth_mfa"
+	totpIssuer   = "Budget"
+	// maxMFATokenFailures は1つのトークンで認証コードを誤れる回数です。超えたトークンは無効にします。
+	maxMFATokenFailures = 5
+)
+
+// 1人のユーザーに対する認証コードの失敗は、トークンを取り直した攻撃も含めて数える
+var mfaThrottlePolicy = throttlePolicy{FreeAttempts: 5, BaseDelay: 30 * time.Second, MaxDelay: 30 * time.Minute, Window: 24 * time.Hour}
+
+type MFAUsecase interface {
+	GetStatus(ctx context.Context, userID uint) (api.TwoFactorStatusResponse, error)
+	// SetupTOTP は新しいシークレットを生成して登録手続きを開始します。
+	SetupTOTP(ctx context.Context, userID uint) (api.TOTPSetupResponse, error)
+	// ConfirmTOTP は認証アプリのコードを検証して登録を完了し、リカバリーコードを発行します。
+	ConfirmTOTP(ctx context.Context, userID uint, req api.TOTPCodeRequest) (api.RecoveryCodesResponse, error)
+	// DisableTOTP はTOTPコードまたはリカバリーコードを確認してTOTPを無効にします。
+	// パスワードを持つユーザーはパスワードも必須です。外部IDプロバイダのみで登録したユーザーはコードのみで解除できます。
+	DisableTOTP(ctx context.Context, userID uint, req api.TOTPDisableRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, req api.TOTPCodeRequest) (api.RecoveryCodesResponse, error)
+	// BeginLogin は2段階認証が有効なユーザーの場合、2段階目のためのトークンを返します。
+	// 無効な場合は空文字を返します。
+	BeginLogin(ctx context.Context, userEntity *user.User) (string, error)
+	// CompleteLogin はトークンとTOTPコードまたはリカバリーコードを検証してユーザーを返します。
+	// トークンは一度だけ使用でき、認証コードを maxMFATokenFailures 回誤ると無効になります。
+	CompleteLogin(ctx context.Context, req api.TwoFactorLoginRequest) (*user.User, error)
+}
+
+type mfaUsecase struct {
+	ur    user.UserRepository
+	mr    mfa.MFARepository
+	store model.AttemptStore
+}
+
+func NewMFAUsecase(ur user.UserRepository, mr mfa.MFARepository, store model.AttemptStore) MFAUsecase {
+	return &mfaUsecase{ur: ur, mr: mr, store: store}
+}
+
+func (mu *mfaUsecase) GetStatus(ctx context.Context, userID uint) (api.TwoFactorStatusResponse, error) {
+	credential, err := mu.mr.FindTOTPByUserID(ctx, userID)
+	if err != nil {
+		return api.TwoFactorStatusResponse{}, err
+	}
+	if credential == nil || !credential.IsEnabled() {
+		return api.TwoFactorStatusResponse{}, nil
+	}
+
+	remaining, err := mu.mr.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return api.TwoFactorStatusResponse{}, err
+	}
+	return api.TwoFactorStatusResponse{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+func (mu *mfaUsecase) SetupTOTP(ctx context.Context, userID uint) (api.TOTPSetupResponse, error) {
+	domainUser, err := mu.findUser(ctx, userID)
+	if err != nil {
+		return api.TOTPSetupResponse{}, err
+	}
+	existing, err := mu.mr.FindTOTPByUserID(ctx, userID)
+	if err != nil {
+		return api.TOTPSetupResponse{}, err
+	}
+	if existing != nil && existing.IsEnabled() {
+		return api.TOTPSetupResponse{}, fmt.Errorf("2段階認証は既に有効です: %w", ErrInvalidInput)
+	}
+
+	secret, err := mfa.GenerateSecret()
+	if err != nil {
+		return api.TOTPSetupResponse{}, err
+	}
+	credential, err := mfa.NewTOTPCredential(userID, secret, time.Now())
+	if err != nil {
+		return api.TOTPSetupResponse{}, err
+	}
+	if err := mu.mr.SaveTOTP(ctx, credential); err != nil {
+		return api.TOTPSetupResponse{}, fmt.Errorf("failed to save totp credential: %w", err)
+	}
+
+	account := domainUser.Email.Value()
+	if account == "" {
+		account = domainUser.Name.Value()
+	}
+	return api.TOTPSetupResponse{
+		Secret:     secret,
+		OtpauthUri: mfa.KeyURI(totpIssuer, account, secret),
+	}, nil
+}
+
+func (mu *mfaUsecase) ConfirmTOTP(ctx context.Context, userID uint, req api.TOTPCodeRequest) (api.RecoveryCodesResponse, error) {
+	credential, err := mu.mr.FindTOTPByUserID(ctx, userID)
+	if err != nil {
+		return api.RecoveryCodesResponse{}, err
+	}
+	if credential == nil {
+		return api.RecoveryCodesResponse{}, fmt.Errorf("2段階認証の登録が開始されていません: %w", ErrInvalidInput)
+	}
+	if credential.IsEnabled() {
+		return api.RecoveryCodesResponse{}, fmt.Errorf("2段階認証は既に有効です: %w", ErrInvalidInput)
+	}
+
+	now := time.Now()
+	step, ok := mfa.VerifyCode(credential.Secret, req.Code, now, credential.LastUsedStep)
+	if !ok {
+		return api.RecoveryCodesResponse{}, fmt.Errorf("確認コードが正しくありません: %w", ErrInvalidInput)
+	}
+	credential.Confirm(step, now)
+	if err := mu.mr.SaveTOTP(ctx, credential); err != nil {
+		return api.RecoveryCodesResponse{}, fmt.Errorf("failed to save totp credential: %w", err)
+	}
+
+	return mu.issueRecoveryCodes(ctx, userID)
+}
+
+func (mu *mfaUsecase) DisableTOTP(ctx context.Context, userID uint, req api.TOTPDisableRequest) error {
+	domainUser, err := mu.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if domainUser.HasPassword() {
+		if req.Password == nil || *req.Password == "" {
+			return fmt.Errorf("パスワードを入力してください: %w", ErrInvalidInput)
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(domainUser.Password.Value()), []byte(*req.Password)); err != nil {
+			return fmt.Errorf("パスワードが正しくありません: %w", ErrInvalidInput)
+		}
+	}
+	if err := mu.verifySecondFactor(ctx, userID, req.Code); err != nil {
+		return err
+	}
+
+	if err := mu.mr.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to disable totp: %w", err)
+	}
+	return nil
+}
+
+func (mu *mfaUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uint, req api.TOTPCodeRequest) (api.RecoveryCodesResponse, error) {
+	if err := mu.verifySecondFactor(ctx, userID, req.Code); err != nil {
+		return api.RecoveryCodesResponse{}, err
+	}
+	return mu.issueRecoveryCodes(ctx, userID)
+}
+
+func (mu *mfaUsecase) BeginLogin(ctx context.Context, userEntity *user.User) (string, error) {
+	credential, err := mu.mr.FindTOTPByUserID(ctx, userEntity.ID.Value())
+	if err != nil {
+		return "", err
+	}
+	if credential == nil || !credential.IsEnabled() {
+		return "", nil
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userEntity.ID.Value(),
+		"type":    mfaTokenType,
+		"exp":     time.Now().Add(MFATokenTTL).Unix(),
+	})
+	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET")))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return tokenString, nil
+}
+
+func (mu *mfaUsecase) CompleteLogin(ctx context.Context, req api.TwoFactorLoginRequest) (*user.User, error) {
+	userID, err := parseMFAToken(req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenKey := "mfa-token-" + hashToken(req.MfaToken)
+	record, err := mu.store.Get(ctx, tokenKey)
+	if err != nil {
+		return nil, err
+	}
+	// 使用済みのトークンと失敗回数を超えたトークンはロックして無効にしている
+	if record.IsLocked(time.Now()) {
+		return nil, fmt.Errorf("2段階認証をやり直してください: %w", ErrUnauthorized)
+	}
+
+	if err := mu.verifySecondFactor(ctx, userID, req.Code); err != nil {
+		if errors.Is(err, ErrInvalidInput) {
+			if err := mu.recordTokenFailure(ctx, tokenKey); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("認証コードが正しくありません: %w", ErrUnauthorized)
+		}
+		return nil, err
+	}
+
+	if err := mu.store.Lock(ctx, tokenKey, time.Now().Add(MFATokenTTL)); err != nil {
+		return nil, err
+	}
+	return mu.findUser(ctx, userID)
+}
+
+// recordTokenFailure はトークンでの失敗を数え、回数を超えたトークンを有効期限まで無効にします。
+func (mu *mfaUsecase) recordTokenFailure(ctx context.Context, tokenKey string) error {
+	record, err := mu.store.Increment(ctx, tokenKey, MFATokenTTL)
+	if err != nil {
+		return err
+	}
+	if record.Count < maxMFATokenFailures {
+		return nil
+	}
+	return mu.store.Lock(ctx, tokenKey, time.Now().Add(MFATokenTTL))
+}
+
+// verifySecondFactor はTOTPコードまたは未使用のリカバリーコードを検証します。
+// 失敗はユーザーごとに数え、回数に応じてロックします。ロック中は TooManyAttemptsError を返します。
+func (mu *mfaUsecase) verifySecondFactor(ctx context.Context, userID uint, code string) error {
+	credential, err := mu.mr.FindTOTPByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if credential == nil || !credential.IsEnabled() {
+		return fmt.Errorf("2段階認証が有効ではありません: %w", ErrInvalidInput)
+	}
+
+	now := time.Now()
+	userKey := fmt.Sprintf("mfa-user-%d", userID)
+	record, err := mu.store.Get(ctx, userKey)
+	if err != nil {
+		return err
+	}
+	if record.IsLocked(now) {
+		return &TooManyAttemptsError{RetryAfter: record.LockedUntil.Sub(now)}
+	}
+
+	used, err := mu.useSecondFactor(ctx, credential, code, now)
+	if err != nil {
+		return err
+	}
+	if used {
+		return mu.store.Reset(ctx, userKey)
+	}
+
+	record, err = mu.store.Increment(ctx, userKey, mfaThrottlePolicy.Window)
+	if err != nil {
+		return err
+	}
+	if delay := mfaThrottlePolicy.lockDuration(record.Count); delay > 0 {
+		if err := mu.store.Lock(ctx, userKey, now.Add(delay)); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("認証コードが正しくありません: %w", ErrInvalidInput)
+}
+
+// useSecondFactor はTOTPコードまたは未使用のリカバリーコードを使用済みにし、使用できたかどうかを返します。
+func (mu *mfaUsecase) useSecondFactor(ctx context.Context, credential *mfa.TOTPCredential, code string, now time.Time) (bool, error) {
+	if step, ok := mfa.VerifyCode(credential.Secret, code, now, credential.LastUsedStep); ok {
+		return mu.mr.UseTOTPStep(ctx, credential.UserID, step)
+	}
+	return mu.mr.UseRecoveryCode(ctx, credential.UserID, hashToken(mfa.NormalizeRecoveryCode(code)), now)
+}
+
+func (mu *mfaUsecase) issueRecoveryCodes(ctx context.Context, userID uint) (api.RecoveryCodesResponse, error) {
+	codes, err := mfa.GenerateRecoveryCodes()
+	if err != nil {
+		return api.RecoveryCodesResponse{}, err
+	}
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, hashToken(mfa.NormalizeRecoveryCode(code)))
+	}
+	if err := mu.mr.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return api.RecoveryCodesResponse{}, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+	return api.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (mu *mfaUsecase) findUser(ctx context.Context, userID uint) (*user.User, error) {
+	domainUser, err := mu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if domainUser == nil {
+		return nil, fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+	return domainUser, nil
+}
+
+func parseMFAToken(tokenString string) (uint, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(os.Getenv("SECRET")), nil
+	}, jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return 0, fmt.Errorf("invalid mfa token: %w", ErrUnauthorized)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["type"] != mfaTokenType {
+		return 0, fmt.Errorf("invalid mfa token: %w", ErrUnauthorized)
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("invalid mfa token: %w", ErrUnauthorized)
+	}
+	return uint(userID), nil
+}
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/domain/passkey"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/model"
+)
+
+// passkeyChallengeTTL はパスキーの登録・ログインのチャレンジの有効期間です。
+const passkeyChallengeTTL = 5 * time.Minute
+
+type PasskeyUsecase interface {
+	// BeginRegistration はパスキー登録のためのオプションとチャレンジを発行します。
+	BeginRegistration(ctx context.Context, userID uint) (api.PasskeyCreationOptions, error)
+	// FinishRegistration は認証器の応答を検証してパスキーを保存します。
+	FinishRegistration(ctx context.Context, userID uint, req api.PasskeyRegistrationRequest) (api.PasskeyResponse, error)
+	ListPasskeys(ctx context.Context, userID uint) ([]api.PasskeyResponse, error)
+	// DeletePasskey はパスキーを削除します。削除するとログインする方法が無くなる場合は ErrConflict を返します。
+	DeletePasskey(ctx context.Context, userID uint, id uint) error
+	// BeginLogin はパスキーでのログインのためのオプションとチャレンジを発行します。
+	// 端末に保存されたパスキー（discoverable credential）を使用するため、ユーザーは指定しません。
+	BeginLogin(ctx context.Context) (api.PasskeyRequestOptions, error)
+	// FinishLogin は署名を検証してログインするユーザーを返します。
+	FinishLogin(ctx context.Context, req api.PasskeyAssertionRequest) (*user.User, error)
+}
+
+type passkeyUsecase struct {
+	ur         user.UserRepository
+	pr         passkey.CredentialRepository
+	ir         identity.IdentityRepository
+	tokenStore model.TokenStore
+	rp         passkey.RelyingParty
+}
+
+func NewPasskeyUsecase(ur user.UserRepository, pr passkey.CredentialRepository, ir identity.IdentityRepository, tokenStore model.TokenStore, rp passkey.RelyingParty) PasskeyUsecase {
+	return &passkeyUsecase{
+		ur:         ur,
+		pr:         pr,
+		ir:         ir,
+		tokenStore: tokenStore,
+		rp:         rp,
+	}
+}
+
+func (pu *passkeyUsecase) BeginRegistration(ctx context.Context, userID uint) (api.PasskeyCreationOptions, error) {
+	domainUser, err := pu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return api.PasskeyCreationOptions{}, err
+	}
+	if domainUser == nil {
+		return api.PasskeyCreationOptions{}, fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+	credentials, err := pu.pr.FindByUserID(ctx, userID)
+	if err != nil {
+		return api.PasskeyCreationOptions{}, err
+	}
+
+	challenge, err := pu.issueChallenge(ctx, func(nonce string) string { return passkeyRegisterSessionID(userID, nonce) })
+	if err != nil {
+		return api.PasskeyCreationOptions{}, err
+	}
+
+	params := []api.PasskeyCredentialParameter{}
+	for _, alg := range passkey.SupportedAlgorithms {
+		params = append(params, api.PasskeyCredentialParameter{Type: "public-key", Alg: int(alg)})
+	}
+	name := domainUser.Email.Value()
+	if name == "" {
+		name = domainUser.Name.Value()
+	}
+	return api.PasskeyCreationOptions{
+		Challenge: challenge,
+		Rp:        api.PasskeyRelyingParty{Id: pu.rp.ID, Name: pu.rp.Name},
+		User: api.PasskeyUserEntity{
+			Id:          base64.RawURLEncoding.EncodeToString(passkeyUserHandle(userID)),
+			Name:        name,
+			DisplayName: domainUser.Name.Value(),
+		},
+		PubKeyCredParams: params,
+		Timeout:          int(passkeyChallengeTTL / time.Millisecond),
+		// 同じ端末に重複して登録しないよう、登録済みのパスキーを除外する
+		ExcludeCredentials: toCredentialDescriptors(credentials),
+		AuthenticatorSelection: api.PasskeyAuthenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}, nil
+}
+
+func (pu *passkeyUsecase) FinishRegistration(ctx context.Context, userID uint, req api.PasskeyRegistrationRequest) (api.PasskeyResponse, error) {
+	rawID, err1 := decodeBase64URL(req.RawId)
+	clientDataJSON, err2 := decodeBase64URL(req.Response.ClientDataJSON)
+	attestationObject, err3 := decodeBase64URL(req.Response.AttestationObject)
+	if err1 != nil || err2 != nil || err3 != nil || req.Type != "public-key" {
+		return api.PasskeyResponse{}, fmt.Errorf("パスキーの応答が不正です: %w", ErrInvalidInput)
+	}
+
+	clientData, authData, err := pu.rp.VerifyRegistration(clientDataJSON, attestationObject)
+	if err != nil {
+		return api.PasskeyResponse{}, fmt.Errorf("パスキーの検証に失敗しました: %s: %w", err.Error(), ErrInvalidInput)
+	}
+	if err := pu.consumeChallenge(ctx, clientData.Challenge, func(nonce string) string { return passkeyRegisterSessionID(userID, nonce) }); err != nil {
+		return api.PasskeyResponse{}, err
+	}
+	if !bytes.Equal(authData.CredentialID, rawID) {
+		return api.PasskeyResponse{}, fmt.Errorf("パスキーの応答が不正です: %w", ErrInvalidInput)
+	}
+
+	existing, err := pu.pr.FindByCredentialID(ctx, authData.CredentialID)
+	if err != nil {
+		return api.PasskeyResponse{}, err
+	}
+	if existing != nil {
+		return api.PasskeyResponse{}, fmt.Errorf("このパスキーは既に登録されています: %w", ErrInvalidInput)
+	}
+
+	var transports []string
+	if req.Response.Transports != nil {
+		transports = *req.Response.Transports
+	}
+	var name string
+	if req.Name != nil {
+		name = *req.Name
+	}
+	credential, err := passkey.NewCredential(userID, authData, transports, name, time.Now())
+	if err != nil {
+		return api.PasskeyResponse{}, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	if err := pu.pr.Create(ctx, credential); err != nil {
+		return api.PasskeyResponse{}, fmt.Errorf("failed to save passkey: %w", err)
+	}
+	return toPasskeyResponse(credential), nil
+}
+
+func (pu *passkeyUsecase) ListPasskeys(ctx context.Context, userID uint) ([]api.PasskeyResponse, error) {
+	credentials, err := pu.pr.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []api.PasskeyResponse{}
+	for _, credential := range credentials {
+		res = append(res, toPasskeyResponse(credential))
+	}
+	return res, nil
+}
+
+func (pu *passkeyUsecase) DeletePasskey(ctx context.Context, userID uint, id uint) error {
+	credentials, err := pu.pr.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(credentials, func(c *passkey.Credential) bool { return c.ID == id }) {
+		return fmt.Errorf("passkey %d: %w", id, ErrNotFound)
+	}
+	if err := ensureOtherLoginMethod(ctx, pu.ur, pu.ir, pu.pr, userID); err != nil {
+		return err
+	}
+
+	deleted, err := pu.pr.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("passkey %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (pu *passkeyUsecase) BeginLogin(ctx context.Context) (api.PasskeyRequestOptions, error) {
+	challenge, err := pu.issueChallenge(ctx, passkeyLoginSessionID)
+	if err != nil {
+		return api.PasskeyRequestOptions{}, err
+	}
+	return api.PasskeyRequestOptions{
+		Challenge:        challenge,
+		RpId:             pu.rp.ID,
+		Timeout:          int(passkeyChallengeTTL / time.Millisecond),
+		UserVerification: "required",
+		AllowCredentials: []api.PasskeyCredentialDescriptor{},
+	}, nil
+}
+
+func (pu *passkeyUsecase) FinishLogin(ctx context.Context, req api.PasskeyAssertionRequest) (*user.User, error) {
+	rawID, err1 := decodeBase64URL(req.RawId)
+	clientDataJSON, err2 := decodeBase64URL(req.Response.ClientDataJSON)
+	authenticatorData, err3 := decodeBase64URL(req.Response.AuthenticatorData)
+	signature, err4 := decodeBase64URL(req.Response.Signature)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || req.Type != "public-key" {
+		return nil, fmt.Errorf("パスキーの応答が不正です: %w", ErrInvalidInput)
+	}
+
+	credential, err := pu.pr.FindByCredentialID(ctx, rawID)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil {
+		return nil, fmt.Errorf("passkey not registered: %w", ErrUnauthorized)
+	}
+	if req.Response.UserHandle != nil && *req.Response.UserHandle != "" {
+		userHandle, err := decodeBase64URL(*req.Response.UserHandle)
+		if err != nil || !bytes.Equal(userHandle, passkeyUserHandle(credential.UserID)) {
+			return nil, fmt.Errorf("user handle mismatch: %w", ErrUnauthorized)
+		}
+	}
+
+	clientData, authData, err := pu.rp.VerifyAssertion(clientDataJSON, authenticatorData, signature, credential.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("passkey verification failed: %s: %w", err.Error(), ErrUnauthorized)
+	}
+	if err := pu.consumeChallenge(ctx, clientData.Challenge, passkeyLoginSessionID); err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrUnauthorized)
+	}
+	if err := credential.RecordUse(authData.SignCount, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrUnauthorized)
+	}
+	if err := pu.pr.UpdateUsage(ctx, credential); err != nil {
+		return nil, fmt.Errorf("failed to update passkey: %w", err)
+	}
+
+	domainUser, err := pu.ur.FindByID(ctx, credential.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if domainUser == nil {
+		return nil, fmt.Errorf("user %d: %w", credential.UserID, ErrUnauthorized)
+	}
+	return domainUser, nil
+}
+
+// issueChallenge はチャレンジを発行し、Base64URLでエンコードして返します。
+// チャレンジは「乱数.トークンストアが発行したトークン」の形式とし、乱数からトークンの保存先を決めます。
+func (pu *passkeyUsecase) issueChallenge(ctx context.Context, sessionID func(nonce string) string) (string, error) {
+	nonce, err := GenerateState()
+	if err != nil {
+		return "", err
+	}
+	token, err := pu.tokenStore.IssueToken(ctx, sessionID(nonce), passkeyChallengeTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue challenge: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(nonce + "." + token)), nil
+}
+
+// consumeChallenge はクライアントデータのチャレンジを検証し、再利用できないよう削除します。
+func (pu *passkeyUsecase) consumeChallenge(ctx context.Context, challenge string, sessionID func(nonce string) string) error {
+	nonce, token, ok := strings.Cut(challenge, ".")
+	if !ok {
+		return fmt.Errorf("チャレンジが無効または期限切れです: %w", ErrInvalidInput)
+	}
+	valid, err := pu.tokenStore.ValidateToken(ctx, sessionID(nonce), token)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("チャレンジが無効または期限切れです: %w", ErrInvalidInput)
+	}
+	return pu.tokenStore.DeleteToken(ctx, sessionID(nonce))
+}
+
+func passkeyRegisterSessionID(userID uint, nonce string) string {
+	return fmt.Sprintf("passkey-register-%d-%s", userID, nonce)
+}
+
+func passkeyLoginSessionID(nonce string) string {
+	return "passkey-login-" + nonce
+}
+
+// passkeyUserHandle は認証器に保存するユーザーハンドルを返します。
+func passkeyUserHandle(userID uint) []byte {
+	return []byte(strconv.FormatUint(uint64(userID), 10))
+}
+
+// decodeBase64URL はパディングの有無に関わらずBase64URLをデコードします。
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func toCredentialDescriptors(credentials []*passkey.Credential) []api.PasskeyCredentialDescriptor {
+	descriptors := []api.PasskeyCredentialDescriptor{}
+	for _, credential := range credentials {
+		descriptor := api.PasskeyCredentialDescriptor{
+			Type: "public-key",
+			Id:   base64.RawURLEncoding.EncodeToString(credential.CredentialID),
+		}
+		if len(credential.Transports) > 0 {
+			transports := credential.Transports
+			descriptor.Transports = &transports
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors
+}
+
+func toPasskeyResponse(credential *passkey.Credential) api.PasskeyResponse {
+	transports := credential.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	return api.PasskeyResponse{
+		Id:         int(credential.ID),
+		Name:       credential.Name,
+		Transports: transports,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
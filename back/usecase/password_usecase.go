@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/passwordreset"
+	"github.com/yanatoritakuma/budget/back/domain/session"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/mailer"
+	"github.com/yanatoritakuma/budget/back/model"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordResetTokenTTL はパスワード再設定トークンの有効期間です。
+const PasswordResetTokenTTL = time.Hour
+
+// passwordResetResponseTime は再設定メールの要求に応答するまでの最短時間です。
+// メールを送信したかどうかで応答時間が変わり、登録の有無が分からないようにします。
+const passwordResetResponseTime = 2 * time.Second
+
+type PasswordUsecase interface {
+	// ChangePassword は現在のパスワードを確認してパスワードを変更し、現在以外のセッションを失効させます。
+	ChangePassword(ctx context.Context, userID uint, sessionID uint, req api.PasswordChangeRequest) error
+	// SetPassword は外部IDプロバイダのアカウントから作成したユーザーにメールアドレスとパスワードを設定します。
+	// 既にパスワードでログインできる場合は ErrConflict を返します。
+	SetPassword(ctx context.Context, userID uint, req api.PasswordSetupRequest) error
+	// RequestPasswordReset は再設定用のリンクをメールで送信します。
+	// 登録の有無が分からないよう、登録されていないメールアドレスの場合や送信に失敗した場合もエラーを返さず、一定時間待ってから応答します。
+	RequestPasswordReset(ctx context.Context, req api.PasswordForgotRequest) error
+	// ResetPassword は再設定トークンを検証してパスワードを変更し、すべてのセッションを失効させます。
+	ResetPassword(ctx context.Context, req api.PasswordResetRequest) error
+}
+
+type passwordUsecase struct {
+	ur     user.UserRepository
+	sr     session.SessionRepository
+	pr     passwordreset.TokenRepository
+	mailer mailer.Mailer
+	evu    EmailVerificationUsecase
+}
+
+func NewPasswordUsecase(ur user.UserRepository, sr session.SessionRepository, pr passwordreset.TokenRepository, m mailer.Mailer, evu EmailVerificationUsecase) PasswordUsecase {
+	return &passwordUsecase{
+		ur:     ur,
+		sr:     sr,
+		pr:     pr,
+		mailer: m,
+		evu:    evu,
+	}
+}
+
+func (pu *passwordUsecase) ChangePassword(ctx context.Context, userID uint, sessionID uint, req api.PasswordChangeRequest) error {
+	domainUser, err := pu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+
+	if domainUser.Password.Value() == "" {
+		return fmt.Errorf("パスワードが設定されていません: %w", ErrConflict)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(domainUser.Password.Value()), []byte(req.CurrentPassword)); err != nil {
+		return fmt.Errorf("現在のパスワードが正しくありません: %w", ErrInvalidInput)
+	}
+	if err := pu.updatePassword(ctx, domainUser, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := pu.sr.RevokeOthersByUserID(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (pu *passwordUsecase) SetPassword(ctx context.Context, userID uint, req api.PasswordSetupRequest) error {
+	domainUser, err := pu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user %d: %w", userID, ErrNotFound)
+	}
+	if domainUser.HasPassword() {
+		return fmt.Errorf("パスワードは既に設定されています: %w", ErrConflict)
+	}
+
+	// メールアドレスが無いユーザーはパスワードと合わせて登録する
+	emailChanged := false
+	if req.Email != nil && string(*req.Email) != domainUser.Email.Value() {
+		newEmail, err := user.NewEmail(strings.TrimSpace(string(*req.Email)))
+		if err != nil {
+			return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+		}
+		if newEmail != nil {
+			duplicate, err := pu.ur.FindByEmail(ctx, newEmail.Value())
+			if err != nil {
+				return err
+			}
+			if duplicate != nil {
+				return fmt.Errorf("このメールアドレスは既に使用されています: %w", ErrInvalidInput)
+			}
+			domainUser.ChangeEmail(newEmail)
+			emailChanged = true
+		}
+	}
+	if domainUser.Email == nil {
+		return fmt.Errorf("メールアドレスは必須です: %w", ErrInvalidInput)
+	}
+
+	if err := pu.updatePassword(ctx, domainUser, req.Password); err != nil {
+		return err
+	}
+
+	if emailChanged {
+		// 確認メールの送信に失敗しても設定は完了させ、再送信で対応する
+		if err := pu.evu.SendVerification(ctx, domainUser); err != nil {
+			log.Printf("failed to send verification mail to user %d: %v", domainUser.ID.Value(), err)
+		}
+	}
+	return nil
+}
+
+func (pu *passwordUsecase) RequestPasswordReset(ctx context.Context, req api.PasswordForgotRequest) error {
+	email := strings.TrimSpace(string(req.Email))
+	if email == "" {
+		return fmt.Errorf("メールアドレスは必須です: %w", ErrInvalidInput)
+	}
+
+	deadline := time.Now().Add(passwordResetResponseTime)
+	if err := pu.sendPasswordReset(ctx, email); err != nil {
+		log.Printf("failed to request password reset: %v", err)
+	}
+	waitUntil(ctx, deadline)
+	return nil
+}
+
+// sendPasswordReset はメールアドレスのユーザーに再設定トークンを発行してメールで送信します。
+// ユーザーが存在しない場合は何もしません。
+func (pu *passwordUsecase) sendPasswordReset(ctx context.Context, email string) error {
+	domainUser, err := pu.ur.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil || domainUser.Email == nil {
+		return nil
+	}
+
+	rawToken, err := model.GenerateTokenValue()
+	if err != nil {
+		return err
+	}
+	token, err := passwordreset.NewToken(domainUser.ID.Value(), hashToken(rawToken), time.Now(), PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	if err := pu.pr.Create(ctx, token); err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	resetURL := os.Getenv("FE_URL") + "/password/reset?" + url.Values{"token": {rawToken}}.Encode()
+	msg := mailer.Message{
+		To:      domainUser.Email.Value(),
+		Subject: "パスワード再設定のご案内",
+		Body: fmt.Sprintf(
+			"%s 様\n\n以下のリンクからパスワードを再設定してください。\n%s\n\nこのリンクの有効期限は%d分です。\nお心当たりのない場合はこのメールを破棄してください。\n",
+			domainUser.Name.Value(), resetURL, int(PasswordResetTokenTTL/time.Minute),
+		),
+	}
+	if err := pu.mailer.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send password reset mail to user %d: %w", domainUser.ID.Value(), err)
+	}
+	return nil
+}
+
+// waitUntil は deadline まで待ちます。ctx がキャンセルされた場合はすぐに戻ります。
+func waitUntil(ctx context.Context, deadline time.Time) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (pu *passwordUsecase) ResetPassword(ctx context.Context, req api.PasswordResetRequest) error {
+	if err := user.ValidatePlainPassword(req.NewPassword); err != nil {
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	token, err := pu.pr.FindByTokenHash(ctx, hashToken(req.Token))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if token == nil || !token.IsUsable(now) {
+		return fmt.Errorf("再設定リンクが無効または期限切れです: %w", ErrInvalidInput)
+	}
+	used, err := pu.pr.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return fmt.Errorf("再設定リンクが無効または期限切れです: %w", ErrInvalidInput)
+	}
+
+	domainUser, err := pu.ur.FindByID(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+	if domainUser == nil {
+		return fmt.Errorf("user %d: %w", token.UserID, ErrNotFound)
+	}
+	if err := pu.updatePassword(ctx, domainUser, req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := pu.sr.RevokeByUserID(ctx, domainUser.ID.Value()); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+func (pu *passwordUsecase) updatePassword(ctx context.Context, domainUser *user.User, newPassword string) error {
+	if err := user.ValidatePlainPassword(newPassword); err != nil {
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), 10)
+	if err != nil {
+		return err
+	}
+	password, err := user.NewPassword(string(hash))
+	if err != nil {
+		return err
+	}
+	domainUser.ChangePassword(password)
+
+	if err := pu.ur.Update(ctx, domainUser); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/recurring"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+)
+
+type RecurringExpenseUsecase interface {
+	CreateRecurringExpense(ctx context.Context, userID uint, req api.RecurringExpenseRequest) (api.RecurringExpenseResponse, error)
+	GetRecurringExpenses(ctx context.Context, userID uint) ([]api.RecurringExpenseResponse, error)
+	UpdateRecurringExpense(ctx context.Context, userID uint, req api.RecurringExpenseRequest, recurringExpenseId uint) (api.RecurringExpenseResponse, error)
+	DeleteRecurringExpense(ctx context.Context, userID uint, recurringExpenseId uint) error
+	GenerateDueExpenses(ctx context.Context, asOf time.Time) (int, error)
+}
+
+type recurringExpenseUsecase struct {
+	rr  recurring.RecurringExpenseRepository
+	ur  user.UserRepository
+	hr  household.HouseholdRepository
+	mr  household.MemberRepository
+	uow UnitOfWork
+}
+
+func NewRecurringExpenseUsecase(rr recurring.RecurringExpenseRepository, ur user.UserRepository, hr household.HouseholdRepository, mr household.MemberRepository, uow UnitOfWork) RecurringExpenseUsecase {
+	return &recurringExpenseUsecase{rr: rr, ur: ur, hr: hr, mr: mr, uow: uow}
+}
+
+func (ru *recurringExpenseUsecase) CreateRecurringExpense(ctx context.Context, userID uint, req api.RecurringExpenseRequest) (api.RecurringExpenseResponse, error) {
+	if _, err := requireEditor(ctx, ru.mr, userID); err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	currentUser, err := ru.findUser(ctx, userID)
+	if err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	domainRecurring, err := ru.newDomainRecurringExpense(ctx, currentUser, req)
+	if err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	if err := ru.rr.Create(ctx, domainRecurring); err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	return toRecurringExpenseResponse(domainRecurring), nil
+}
+
+func (ru *recurringExpenseUsecase) GetRecurringExpenses(ctx context.Context, userID uint) ([]api.RecurringExpenseResponse, error) {
+	currentUser, err := ru.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recurringExpenses, err := ru.rr.FindByHouseholdID(ctx, currentUser.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []api.RecurringExpenseResponse{}
+	for _, domainRecurring := range recurringExpenses {
+		responses = append(responses, toRecurringExpenseResponse(domainRecurring))
+	}
+	return responses, nil
+}
+
+func (ru *recurringExpenseUsecase) UpdateRecurringExpense(ctx context.Context, userID uint, req api.RecurringExpenseRequest, recurringExpenseId uint) (api.RecurringExpenseResponse, error) {
+	if _, err := requireEditor(ctx, ru.mr, userID); err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	currentUser, err := ru.findUser(ctx, userID)
+	if err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	existing, err := ru.findOwnRecurringExpense(ctx, currentUser, recurringExpenseId)
+	if err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	domainRecurring, err := ru.newDomainRecurringExpense(ctx, currentUser, req)
+	if err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+	domainRecurring.ID = existing.ID
+	domainRecurring.UserID = existing.UserID
+	domainRecurring.LastGeneratedDate = existing.LastGeneratedDate
+	domainRecurring.CreatedAt = existing.CreatedAt
+
+	if err := ru.rr.Update(ctx, domainRecurring); err != nil {
+		return api.RecurringExpenseResponse{}, err
+	}
+
+	return toRecurringExpenseResponse(domainRecurring), nil
+}
+
+func (ru *recurringExpenseUsecase) DeleteRecurringExpense(ctx context.Context, userID uint, recurringExpenseId uint) error {
+	if _, err := requireEditor(ctx, ru.mr, userID); err != nil {
+		return err
+	}
+
+	currentUser, err := ru.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ru.findOwnRecurringExpense(ctx, currentUser, recurringExpenseId)
+	if err != nil {
+		return err
+	}
+	return ru.rr.Delete(ctx, existing.ID)
+}
+
+// GenerateDueExpenses は asOf 時点までに発生した定期支出を支出として登録し、登録件数を返します。
+// 発生日は世帯のタイムゾーンでの asOf の日付までとします。
+// 生成済みの日付と一意制約により、何度実行しても同じ支出が重複して登録されることはありません。
+func (ru *recurringExpenseUsecase) GenerateDueExpenses(ctx context.Context, asOf time.Time) (int, error) {
+	recurringExpenses, err := ru.rr.FindActive(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find recurring expenses: %w", err)
+	}
+
+	created := 0
+	settingsByHousehold := make(map[uint]household.Settings)
+	for _, domainRecurring := range recurringExpenses {
+		settings, ok := settingsByHousehold[domainRecurring.HouseholdID]
+		if !ok {
+			settings, err = findSettings(ctx, ru.hr, domainRecurring.HouseholdID)
+			if err != nil {
+				log.Printf("failed to get settings of household %d: %v", domainRecurring.HouseholdID, err)
+				continue
+			}
+			settingsByHousehold[domainRecurring.HouseholdID] = settings
+		}
+
+		dueDates := domainRecurring.DueDates(settings.DateOf(asOf))
+		if len(dueDates) == 0 {
+			continue
+		}
+
+		// ロールバックされた場合に数えないよう、コミット後に件数を加える
+		generated := 0
+		err := ru.uow.Transaction(func(repos Repositories) error {
+			generated = 0
+			for _, date := range dueDates {
+				domainExpense, err := domainRecurring.Materialize(date)
+				if err != nil {
+					return err
+				}
+				// 登録後にカテゴリが削除されていても生成を止めないよう、なければ作成する
+				domainCategory, err := findOrCreateCategory(ctx, repos.Category, domainRecurring.HouseholdID, domainExpense.Category.Value())
+				if err != nil {
+					return err
+				}
+				if err := domainExpense.SetCategory(domainCategory.ID.Value(), domainCategory.Name.Value()); err != nil {
+					return err
+				}
+				ok, err := repos.Expense.CreateExpenseIfNotExists(ctx, domainExpense)
+				if err != nil {
+					return err
+				}
+				if ok {
+					generated++
+				}
+			}
+			domainRecurring.MarkGenerated(dueDates[len(dueDates)-1])
+			return repos.Recurring.Update(ctx, domainRecurring)
+		})
+		if err != nil {
+			// 1件の失敗で他の定期支出の生成を止めないようにログに記録して続行する
+			log.Printf("failed to generate recurring expense %d: %v", domainRecurring.ID.Value(), err)
+			continue
+		}
+		created += generated
+	}
+
+	return created, nil
+}
+
+// newDomainRecurringExpense はリクエストから定期支出エンティティを生成します。
+func (ru *recurringExpenseUsecase) newDomainRecurringExpense(ctx context.Context, currentUser *user.User, req api.RecurringExpenseRequest) (*recurring.RecurringExpense, error) {
+	payerID := currentUser.ID.Value()
+	if req.PayerId != nil {
+		payer, err := ru.ur.FindByID(ctx, uint(*req.PayerId))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payer: %w", err)
+		}
+		if payer == nil || payer.HouseholdID != currentUser.HouseholdID {
+			return nil, fmt.Errorf("支払者は同じ世帯のメンバーである必要があります: %w", ErrInvalidInput)
+		}
+		payerID = payer.ID.Value()
+	}
+
+	rule, err := recurring.NewRule(string(req.Frequency), derefInt(req.Day), derefInt(req.Weekday), derefInt(req.Month))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+
+	memo := ""
+	if req.Memo != nil {
+		memo = *req.Memo
+	}
+
+	domainRecurring, err := recurring.NewRecurringExpense(
+		currentUser.HouseholdID,
+		currentUser.ID.Value(),
+		payerID,
+		req.Amount,
+		req.StoreName,
+		req.Category,
+		memo,
+		rule,
+		req.StartDate,
+		req.EndDate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
+	}
+	if req.Active != nil {
+		domainRecurring.Active = *req.Active
+	}
+	return domainRecurring, nil
+}
+
+func (ru *recurringExpenseUsecase) findUser(ctx context.Context, userID uint) (*user.User, error) {
+	currentUser, err := ru.ur.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	if currentUser == nil {
+		return nil, fmt.Errorf("current user not found")
+	}
+	return currentUser, nil
+}
+
+// findOwnRecurringExpense はユーザーの世帯に属する定期支出を取得します。
+func (ru *recurringExpenseUsecase) findOwnRecurringExpense(ctx context.Context, currentUser *user.User, recurringExpenseId uint) (*recurring.RecurringExpense, error) {
+	existing, err := ru.rr.FindByID(ctx, recurringExpenseId)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.HouseholdID != currentUser.HouseholdID {
+		return nil, fmt.Errorf("定期支出が見つかりません: %w", ErrNotFound)
+	}
+	return existing, nil
+}
+
+func toRecurringExpenseResponse(r *recurring.RecurringExpense) api.RecurringExpenseResponse {
+	memo := r.Memo.Value()
+	res := api.RecurringExpenseResponse{
+		Id:                int(r.ID.Value()),
+		Amount:            r.Amount.Value(),
+		StoreName:         r.StoreName.Value(),
+		Category:          r.Category.Value(),
+		Memo:              &memo,
+		PayerId:           int(r.PayerID),
+		Frequency:         api.RecurringFrequency(r.Rule.Frequency),
+		StartDate:         r.StartDate,
+		EndDate:           r.EndDate,
+		LastGeneratedDate: r.LastGeneratedDate,
+		Active:            r.Active,
+		CreatedAt:         r.CreatedAt,
+	}
+	switch r.Rule.Frequency {
+	case recurring.FrequencyMonthly:
+		res.Day = &r.Rule.Day
+	case recurring.FrequencyWeekly:
+		weekday := int(r.Rule.Weekday)
+		res.Weekday = &weekday
+	case recurring.FrequencyYearly:
+		month := int(r.Rule.Month)
+		res.Day = &r.Rule.Day
+		res.Month = &month
+	}
+	return res
+}
+
+func derefInt(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
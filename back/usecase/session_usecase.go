@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yanatoritakuma/budget/back/domain/session"
+	"github.com/yanatoritakuma/budget/back/domain/user"
+	"github.com/yanatoritakuma/budget/back/internal/api"
+	"github.com/yanatoritakuma/budget/back/model"
+)
+
+// 認証トークンの有効期間
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	// refreshReuseGrace は同時リクエストによる直前のリフレッシュトークンの利用を許容する期間です。
+	refreshReuseGrace = time.Minute
+)
+
+// AuthTokens はログインやトークン更新で発行したトークンです。
+// RefreshToken が空の場合、リフレッシュトークンは更新されていません。
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	UserID       uint
+	SessionID    uint
+}
+
+// AccessClaims はアクセストークンに含まれる情報です。
+type AccessClaims struct {
+	UserID    uint
+	SessionID uint
+}
+
+type SessionUsecase interface {
+	// StartSession はユーザーの新しいセッションを作成してトークンを発行します。
+	StartSession(ctx context.Context, userEntity *user.User, client session.ClientInfo) (AuthTokens, error)
+	// Refresh はリフレッシュトークンを検証し、新しいトークンを発行します。
+	// 失効済みのトークンが再利用された場合はセッションを失効させ ErrUnauthorized を返します。
+	Refresh(ctx context.Context, refreshToken string, client session.ClientInfo) (AuthTokens, error)
+	// Authenticate はアクセストークンを検証し、セッションが失効していないことを確認します。
+	Authenticate(ctx context.Context, accessToken string) (AccessClaims, error)
+	// Logout はアクセストークンまたはリフレッシュトークンのセッションを失効させます。
+	Logout(ctx context.Context, accessToken, refreshToken string) error
+	ListSessions(ctx context.Context, userID uint, currentSessionID uint) ([]api.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID uint, sessionID uint) error
+	// RevokeAllSessions はユーザーのすべてのセッションを失効させます。
+	RevokeAllSessions(ctx context.Context, userID uint) error
+}
+
+type sessionUsecase struct {
+	sr session.SessionRepository
+}
+
+func NewSessionUsecase(sr session.SessionRepository) SessionUsecase {
+	return &sessionUsecase{sr: sr}
+}
+
+func (su *sessionUsecase) StartSession(ctx context.Context, userEntity *user.User, client session.ClientInfo) (AuthTokens, error) {
+	refreshToken, err := model.GenerateTokenValue()
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	domainSession, err := session.NewSession(userEntity.ID.Value(), hashToken(refreshToken), client, time.Now(), RefreshTokenTTL)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	if err := su.sr.Create(ctx, domainSession); err != nil {
+		return AuthTokens{}, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	accessToken, err := generateAccessToken(domainSession.UserID, domainSession.ID)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	return AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		UserID:       domainSession.UserID,
+		SessionID:    domainSession.ID,
+	}, nil
+}
+
+func (su *sessionUsecase) Refresh(ctx context.Context, refreshToken string, client session.ClientInfo) (AuthTokens, error) {
+	if refreshToken == "" {
+		return AuthTokens{}, ErrUnauthorized
+	}
+	hash := hashToken(refreshToken)
+	domainSession, err := su.sr.FindByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	now := time.Now()
+	if domainSession == nil || !domainSession.IsActive(now) {
+		return AuthTokens{}, ErrUnauthorized
+	}
+
+	tokens := AuthTokens{UserID: domainSession.UserID, SessionID: domainSession.ID}
+	if domainSession.RefreshTokenHash == hash {
+		newRefreshToken, err := model.GenerateTokenValue()
+		if err != nil {
+			return AuthTokens{}, err
+		}
+		domainSession.Rotate(hashToken(newRefreshToken), client, now, RefreshTokenTTL)
+		rotated, err := su.sr.Rotate(ctx, domainSession, hash)
+		if err != nil {
+			return AuthTokens{}, err
+		}
+		// 同時に更新された場合は直前のトークンとして扱い、アクセストークンのみ発行する
+		if rotated {
+			tokens.RefreshToken = newRefreshToken
+		}
+	} else {
+		if now.Sub(domainSession.RotatedAt) > refreshReuseGrace {
+			domainSession.Revoke(now)
+			if err := su.sr.Update(ctx, domainSession); err != nil {
+				return AuthTokens{}, err
+			}
+			return AuthTokens{}, fmt.Errorf("refresh token reused: %w", ErrUnauthorized)
+		}
+	}
+
+	accessToken, err := generateAccessToken(tokens.UserID, tokens.SessionID)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+	tokens.AccessToken = accessToken
+	return tokens, nil
+}
+
+func (su *sessionUsecase) Authenticate(ctx context.Context, accessToken string) (AccessClaims, error) {
+	claims, err := ParseAccessToken(accessToken, false)
+	if err != nil {
+		return AccessClaims{}, err
+	}
+
+	domainSession, err := su.sr.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		return AccessClaims{}, err
+	}
+	if domainSession == nil || domainSession.UserID != claims.UserID || !domainSession.IsActive(time.Now()) {
+		return AccessClaims{}, fmt.Errorf("session revoked: %w", ErrUnauthorized)
+	}
+	return claims, nil
+}
+
+func (su *sessionUsecase) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	var domainSession *session.Session
+	var err error
+	if claims, parseErr := ParseAccessToken(accessToken, true); parseErr == nil {
+		domainSession, err = su.sr.FindByID(ctx, claims.SessionID)
+	} else if refreshToken != "" {
+		domainSession, err = su.sr.FindByRefreshTokenHash(ctx, hashToken(refreshToken))
+	}
+	if err != nil || domainSession == nil {
+		return err
+	}
+
+	domainSession.Revoke(time.Now())
+	return su.sr.Update(ctx, domainSession)
+}
+
+func (su *sessionUsecase) ListSessions(ctx context.Context, userID uint, currentSessionID uint) ([]api.SessionResponse, error) {
+	sessions, err := su.sr.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []api.SessionResponse{}
+	for _, s := range sessions {
+		res = append(res, api.SessionResponse{
+			Id:         int(s.ID),
+			DeviceName: s.DeviceName,
+			IpAddress:  s.IPAddress,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == currentSessionID,
+		})
+	}
+	return res, nil
+}
+
+func (su *sessionUsecase) RevokeSession(ctx context.Context, userID uint, sessionID uint) error {
+	domainSession, err := su.sr.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	// 他のユーザーのセッションは存在しないものとして扱う
+	if domainSession == nil || domainSession.UserID != userID {
+		return fmt.Errorf("session %d: %w", sessionID, ErrNotFound)
+	}
+
+	domainSession.Revoke(time.Now())
+	return su.sr.Update(ctx, domainSession)
+}
+
+func (su *sessionUsecase) RevokeAllSessions(ctx context.Context, userID uint) error {
+	return su.sr.RevokeByUserID(ctx, userID)
+}
+
+// ParseAccessToken はアクセストークンの署名を検証して内容を返します。
+// allowExpired が true の場合は有効期限切れのトークンも受け付けます。
+func ParseAccessToken(tokenString string, allowExpired bool) (AccessClaims, error) {
+	var opts []jwt.ParserOption
+	if allowExpired {
+		opts = append(opts, jwt.WithoutClaimsValidation())
+	}
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(os.Getenv("SECRET")), nil
+	}, opts...)
+	if err != nil || !token.Valid {
+		return AccessClaims{}, fmt.Errorf("invalid access token: %w", ErrUnauthorized)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return AccessClaims{}, fmt.Errorf("invalid access token: %w", ErrUnauthorized)
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return AccessClaims{}, fmt.Errorf("invalid user ID in access token: %w", ErrUnauthorized)
+	}
+	sessionID, ok := claims["sid"].(float64)
+	if !ok {
+		return AccessClaims{}, fmt.Errorf("invalid session ID in access token: %w", ErrUnauthorized)
+	}
+	return AccessClaims{UserID: uint(userID), SessionID: uint(sessionID)}, nil
+}
+
+// CSRFSessionKey はCSRFトークンを紐付けるキーを返します。
+// アクセストークンは期限が短く、CSRFの検証より後に再発行されるため、
+// ブラウザごとに発行するリフレッシュトークンと同じ期間有効なCookieの値をキーにします。値が無い場合は空文字を返します。
+func CSRFSessionKey(clientID string) string {
+	if clientID == "" {
+		return ""
+	}
+	return "client-" + clientID
+}
+
+func generateAccessToken(userID uint, sessionID uint) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"sid":     sessionID,
+		"iat":     now.Unix(),
+		"exp":     now.Add(AccessTokenTTL).Unix(),
+	})
+	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET")))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return tokenString, nil
+}
+
+// hashToken はリフレッシュトークンなどのトークンを保存用のハッシュ値に変換します。
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,16 +1,28 @@
 package usecase
 
 import (
+	"github.com/yanatoritakuma/budget/back/domain/budget"
+	"github.com/yanatoritakuma/budget/back/domain/category"
 	"github.com/yanatoritakuma/budget/back/domain/expense"
 	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/identity"
+	"github.com/yanatoritakuma/budget/back/domain/income"
+	"github.com/yanatoritakuma/budget/back/domain/recurring"
 	"github.com/yanatoritakuma/budget/back/domain/user"
 )
 
 // Repositories はトランザクション内で使用されるリポジトリのセットです。
 type Repositories struct {
-	User      user.UserRepository
-	Household household.HouseholdRepository
-	Expense   expense.ExpenseRepository
+	User       user.UserRepository
+	Household  household.HouseholdRepository
+	Member     household.MemberRepository
+	Invitation household.InvitationRepository
+	Expense    expense.ExpenseRepository
+	Budget     budget.BudgetRepository
+	Recurring  recurring.RecurringExpenseRepository
+	Income     income.IncomeRepository
+	Category   category.CategoryRepository
+	Identity   identity.IdentityRepository
 	// 今後他のリポジトリが追加された場合は、ここに追加します。
 }
 
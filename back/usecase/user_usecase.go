@@ -3,47 +3,50 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"os"
+	"log"
+	"sync"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/yanatoritakuma/budget/back/domain/household"
+	"github.com/yanatoritakuma/budget/back/domain/identity"
 	"github.com/yanatoritakuma/budget/back/domain/user"
 	"github.com/yanatoritakuma/budget/back/internal/api"
 	"github.com/yanatoritakuma/budget/back/model"
-	"github.com/yanatoritakuma/budget/back/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserUsecase interface {
 	SignUp(user api.SignUpRequest) (api.UserResponse, error)
-	Login(user api.SignUpRequest) (string, error)
-	GetLoggedInUser(tokenString string) (*api.UserResponse, error)
+	Login(user api.SignUpRequest, clientIP string) (*user.User, error)
+	GetLoggedInUser(userID uint) (*api.UserResponse, error)
 	UpdateUser(id uint, req api.UserUpdate) (api.UserResponse, error)
 	DeleteUser(id uint) error
 	GetHouseholdUsers(userID uint) ([]api.UserResponse, error)
-	JoinHousehold(userID uint, inviteCode string) error
+	JoinHousehold(userID uint, inviteCode string, expenses string) error
 	GetOrGenerateCSRFToken(sessionID string) (string, error)
 	ValidateCSRFToken(sessionID, token string) bool
-	CreateUserFromLine(lineUserID, name, image string) (*user.User, error)
-	LinkLineAccount(email, password, lineUserID string) (*user.User, error)
-	GenerateToken(userEntity *user.User) (string, error)
+	CreateUserFromIdentity(provider, subject, name, image string) (*user.User, error)
+	LinkIdentity(email, password, provider, subject, clientIP string) (*user.User, error)
 }
 
 type userUsecase struct {
 	ur         user.UserRepository
 	hr         household.HouseholdRepository
 	uow        UnitOfWork
-	tokenStore *model.TokenStore
+	tokenStore model.TokenStore
+	evu        EmailVerificationUsecase
+	lt         LoginThrottle
 }
 
-func NewUserUsecase(ur user.UserRepository, hr household.HouseholdRepository, uow UnitOfWork) UserUsecase {
+func NewUserUsecase(ur user.UserRepository, hr household.HouseholdRepository, uow UnitOfWork, tokenStore model.TokenStore, evu EmailVerificationUsecase, lt LoginThrottle) UserUsecase {
 	return &userUsecase{
 		ur:         ur,
 		hr:         hr,
 		uow:        uow,
-		tokenStore: model.NewTokenStore(),
+		tokenStore: tokenStore,
+		evu:        evu,
+		lt:         lt,
 	}
 }
 
@@ -52,10 +55,7 @@ func (uu *userUsecase) SignUp(req api.SignUpRequest) (api.UserResponse, error) {
 
 	err := uu.uow.Transaction(func(repos Repositories) error {
 		// Create a new domain household
-		domainHousehold, err := household.NewHousehold(
-			fmt.Sprintf("%s's Household", req.Name),
-			utils.GenerateRandomString(household.InviteCodeLength),
-		)
+		domainHousehold, err := household.NewHousehold(fmt.Sprintf("%s's Household", req.Name))
 		if err != nil {
 			return err
 		}
@@ -64,6 +64,10 @@ func (uu *userUsecase) SignUp(req api.SignUpRequest) (api.UserResponse, error) {
 			return err
 		}
 
+		if err := createDefaultCategories(context.Background(), repos.Category, domainHousehold.ID.Value()); err != nil {
+			return err
+		}
+
 		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 10)
 		if err != nil {
 			return err
@@ -88,13 +92,19 @@ func (uu *userUsecase) SignUp(req api.SignUpRequest) (api.UserResponse, error) {
 			return err
 		}
 
-		return nil
+		// 世帯を作成したユーザーを所有者とする
+		return repos.Member.Save(context.Background(), household.NewMember(domainHousehold.ID.Value(), domainUser.ID.Value(), household.RoleOwner))
 	})
 
 	if err != nil {
 		return api.UserResponse{}, err
 	}
 
+	// 確認メールの送信に失敗しても登録は完了させ、再送信で対応する
+	if err := uu.evu.SendVerification(context.Background(), domainUser); err != nil {
+		log.Printf("failed to send verification mail to user %d: %v", domainUser.ID.Value(), err)
+	}
+
 	var emailPtr *openapi_types.Email
 	if domainUser.Email != nil {
 		emailVal := openapi_types.Email(domainUser.Email.Value())
@@ -102,89 +112,102 @@ func (uu *userUsecase) SignUp(req api.SignUpRequest) (api.UserResponse, error) {
 	}
 
 	resUser := api.UserResponse{
-		Id:        int(domainUser.ID.Value()),
-		Email:     emailPtr,
-		Name:      domainUser.Name.Value(),
-		Image:     &domainUser.Image,
-		Admin:     domainUser.Admin,
-		CreatedAt: domainUser.CreatedAt,
+		Id:            int(domainUser.ID.Value()),
+		Email:         emailPtr,
+		EmailVerified: domainUser.IsEmailVerified(),
+		Name:          domainUser.Name.Value(),
+		Image:         &domainUser.Image,
+		Admin:         domainUser.Admin,
+		CreatedAt:     domainUser.CreatedAt,
 	}
 	return resUser, nil
 }
 
-func (uu *userUsecase) Login(req api.SignUpRequest) (string, error) {
-	ctx := context.Background()
+// errInvalidCredentials はメールアドレスの登録有無を推測されないよう、認証失敗時に共通で返すエラーです。
+var errInvalidCredentials = fmt.Errorf("invalid email or password: %w", ErrUnauthorized)
 
-	storedUser, err := uu.ur.FindByEmail(ctx, string(req.Email))
-	if err != nil {
-		return "", err
-	}
-	if storedUser == nil {
-		return "", fmt.Errorf("user not found")
-	}
-
-	err = bcrypt.CompareHashAndPassword([]byte(storedUser.Password.Value()), []byte(req.Password))
-	if err != nil {
-		return "", fmt.Errorf("invalid credentials")
-	}
+var (
+	dummyPasswordHash     []byte
+	dummyPasswordHashOnce sync.Once
+)
 
-	// 共通化したGenerateTokenを呼び出す
-	tokenString, err := uu.GenerateToken(storedUser)
-	if err != nil {
-		return "", err
-	}
-	return tokenString, nil
+// Login はメールアドレスとパスワードを検証し、認証されたユーザーを返します。
+func (uu *userUsecase) Login(req api.SignUpRequest, clientIP string) (*user.User, error) {
+	return uu.authenticatePassword(context.Background(), string(req.Email), req.Password, clientIP)
 }
 
-func (uu *userUsecase) GetLoggedInUser(tokenString string) (*api.UserResponse, error) {
-	ctx := context.Background()
+// authenticatePassword は試行回数を制限しながらメールアドレスとパスワードを検証します。
+// ユーザーが存在しない場合もパスワードの比較を行い、応答時間の差から登録有無を推測されないようにします。
+func (uu *userUsecase) authenticatePassword(ctx context.Context, email, password, clientIP string) (*user.User, error) {
+	if err := uu.lt.Check(ctx, email, clientIP); err != nil {
+		return nil, err
+	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(os.Getenv("SECRET")), nil
-	})
+	storedUser, err := uu.ur.FindByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			return nil, fmt.Errorf("invalid user ID in JWT token")
-		}
 
-		domainUser, err := uu.ur.FindByID(ctx, uint(userID))
-		if err != nil {
+	hash := dummyHash()
+	if storedUser != nil && storedUser.Password.Value() != "" {
+		hash = []byte(storedUser.Password.Value())
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || storedUser == nil {
+		if err := uu.lt.RecordFailure(ctx, email, clientIP); err != nil {
 			return nil, err
 		}
-		if domainUser == nil {
-			return nil, fmt.Errorf("user not found")
-		}
+		return nil, errInvalidCredentials
+	}
 
-		var emailPtr *openapi_types.Email
-		if domainUser.Email != nil {
-			emailVal := openapi_types.Email(domainUser.Email.Value())
-			emailPtr = &emailVal
+	if err := uu.lt.RecordSuccess(ctx, email, clientIP); err != nil {
+		return nil, err
+	}
+	return storedUser, nil
+}
+
+// dummyHash は存在しないユーザーとの比較に使うハッシュを返します。
+func dummyHash() []byte {
+	dummyPasswordHashOnce.Do(func() {
+		hash, err := bcrypt.GenerateFromPassword([]byte("dummy-password"), 10)
+		if err == nil {
+			dummyPasswordHash = hash
 		}
+	})
+	return dummyPasswordHash
+}
 
-		id := int(domainUser.ID.Value())
-		name := domainUser.Name.Value()
-		image := domainUser.Image
-		admin := domainUser.Admin
-		createdAt := domainUser.CreatedAt
+func (uu *userUsecase) GetLoggedInUser(userID uint) (*api.UserResponse, error) {
+	ctx := context.Background()
 
-		return &api.UserResponse{
-			Id:        id,
-			Email:     emailPtr,
-			Name:      name,
-			Image:     &image,
-			Admin:     admin,
-			CreatedAt: createdAt,
-		}, nil
-	} else {
-		return nil, fmt.Errorf("invalid JWT token")
+	domainUser, err := uu.ur.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
+	if domainUser == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	var emailPtr *openapi_types.Email
+	if domainUser.Email != nil {
+		emailVal := openapi_types.Email(domainUser.Email.Value())
+		emailPtr = &emailVal
+	}
+
+	id := int(domainUser.ID.Value())
+	name := domainUser.Name.Value()
+	image := domainUser.Image
+	admin := domainUser.Admin
+	createdAt := domainUser.CreatedAt
+
+	return &api.UserResponse{
+		Id:            id,
+		Email:         emailPtr,
+		EmailVerified: domainUser.IsEmailVerified(),
+		Name:          name,
+		Image:         &image,
+		Admin:         admin,
+		CreatedAt:     createdAt,
+	}, nil
 }
 
 func (uu *userUsecase) UpdateUser(id uint, req api.UserUpdate) (api.UserResponse, error) {
@@ -208,11 +231,36 @@ func (uu *userUsecase) UpdateUser(id uint, req api.UserUpdate) (api.UserResponse
 	if req.Image != nil {
 		existingUser.Image = *req.Image
 	}
+	emailChanged := false
+	if req.Email != nil && string(*req.Email) != existingUser.Email.Value() {
+		newEmail, err := user.NewEmail(string(*req.Email))
+		if err != nil {
+			return api.UserResponse{}, err
+		}
+		if newEmail == nil {
+			return api.UserResponse{}, fmt.Errorf("メールアドレスは必須です")
+		}
+		duplicate, err := uu.ur.FindByEmail(ctx, newEmail.Value())
+		if err != nil {
+			return api.UserResponse{}, err
+		}
+		if duplicate != nil {
+			return api.UserResponse{}, fmt.Errorf("このメールアドレスは既に使用されています")
+		}
+		existingUser.ChangeEmail(newEmail)
+		emailChanged = true
+	}
 
 	if err := uu.ur.Update(ctx, existingUser); err != nil {
 		return api.UserResponse{}, err
 	}
 
+	if emailChanged {
+		if err := uu.evu.SendVerification(ctx, existingUser); err != nil {
+			log.Printf("failed to send verification mail to user %d: %v", existingUser.ID.Value(), err)
+		}
+	}
+
 	var emailPtr *openapi_types.Email
 	if existingUser.Email != nil {
 		emailVal := openapi_types.Email(existingUser.Email.Value())
@@ -220,22 +268,36 @@ func (uu *userUsecase) UpdateUser(id uint, req api.UserUpdate) (api.UserResponse
 	}
 
 	resUser := api.UserResponse{
-		Id:        int(existingUser.ID.Value()),
-		Email:     emailPtr,
-		Name:      existingUser.Name.Value(),
-		Image:     &existingUser.Image,
-		Admin:     existingUser.Admin,
-		CreatedAt: existingUser.CreatedAt,
+		Id:            int(existingUser.ID.Value()),
+		Email:         emailPtr,
+		EmailVerified: existingUser.IsEmailVerified(),
+		Name:          existingUser.Name.Value(),
+		Image:         &existingUser.Image,
+		Admin:         existingUser.Admin,
+		CreatedAt:     existingUser.CreatedAt,
 	}
 	return resUser, nil
 }
 
+// DeleteUser はユーザーを削除します。ユーザーが登録した支出は世帯の記録として残し、定期支出と収入は世帯の所有者に引き継ぎます。
+// 他のメンバーがいる世帯の所有者は、所有権を移譲するまで削除できません。
 func (uu *userUsecase) DeleteUser(id uint) error {
 	ctx := context.Background()
-	if err := uu.ur.Delete(ctx, id); err != nil {
-		return err
-	}
-	return nil
+	return uu.uow.Transaction(func(repos Repositories) error {
+		d, err := beginDeparture(ctx, repos, id)
+		if err != nil {
+			return err
+		}
+		if d.owner != nil {
+			if err := handOffRecords(ctx, repos, d, 0, household.HandoffStay); err != nil {
+				return err
+			}
+		}
+		if err := repos.User.Delete(ctx, id); err != nil {
+			return err
+		}
+		return d.finish(ctx, repos)
+	})
 }
 
 func (uu *userUsecase) GetHouseholdUsers(userID uint) ([]api.UserResponse, error) {
@@ -274,64 +336,88 @@ func (uu *userUsecase) GetHouseholdUsers(userID uint) ([]api.UserResponse, error
 		createdAt := domainUser.CreatedAt
 
 		resUsers = append(resUsers, api.UserResponse{
-			Id:        id,
-			Email:     emailPtr,
-			Name:      name,
-			Image:     &image,
-			Admin:     admin,
-			CreatedAt: createdAt,
+			Id:            id,
+			Email:         emailPtr,
+			EmailVerified: domainUser.IsEmailVerified(),
+			Name:          name,
+			Image:         &image,
+			Admin:         admin,
+			CreatedAt:     createdAt,
 		})
 	}
 
 	return resUsers, nil
 }
 
-func (uu *userUsecase) JoinHousehold(userID uint, inviteCode string) error {
+// JoinHousehold は招待コードで世帯に参加します。元の世帯で登録した記録は expenses に従って引き継ぎます。
+// 招待が無効・期限切れ・上限到達の場合は ErrNotFound、招待の対象ではない場合は ErrForbidden を返します。
+func (uu *userUsecase) JoinHousehold(userID uint, inviteCode string, expenses string) error {
 	ctx := context.Background()
-
-	domainHousehold, err := uu.hr.FindByInviteCode(ctx, inviteCode) // Use FindByInviteCode
+	invalidInvitation := fmt.Errorf("招待コードが無効か、有効期限が切れています: %w", ErrNotFound)
+	handoff, err := household.NewHandoff(expenses)
 	if err != nil {
-		return fmt.Errorf("invalid invite code: %w", err)
-	}
-	if domainHousehold == nil {
-		return fmt.Errorf("invalid invite code: household not found")
+		return fmt.Errorf("%s: %w", err.Error(), ErrInvalidInput)
 	}
 
-	domainUser, err := uu.ur.FindByID(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("could not find user: %w", err)
-	}
-	if domainUser == nil {
-		return fmt.Errorf("user not found")
-	}
+	return uu.uow.Transaction(func(repos Repositories) error {
+		invitation, err := repos.Invitation.FindByCode(ctx, inviteCode)
+		if err != nil {
+			return fmt.Errorf("failed to find invitation: %w", err)
+		}
+		now := time.Now()
+		if invitation == nil || invitation.Status(now) != household.InvitationStatusActive {
+			return invalidInvitation
+		}
 
-	domainUser.HouseholdID = domainHousehold.ID.Value()
-	if err := uu.ur.Update(ctx, domainUser); err != nil {
-		return fmt.Errorf("failed to update user's household: %w", err)
-	}
+		domainUser, err := repos.User.FindByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("could not find user: %w", err)
+		}
+		if domainUser == nil {
+			return fmt.Errorf("user not found")
+		}
+		if domainUser.HouseholdID == invitation.HouseholdID {
+			return nil
+		}
 
-	return nil
+		// 対象を限定した招待は、確認済みのメールアドレスか紐付け済みのアカウントで本人を確認する
+		if invitation.TargetEmail != "" && (!domainUser.IsEmailVerified() || !invitation.AllowsEmail(domainUser.Email.Value())) {
+			return fmt.Errorf("この招待の対象ではありません: %w", ErrForbidden)
+		}
+		if invitation.IsTargetedToIdentity() {
+			linked, err := repos.Identity.FindByProviderSubject(ctx, invitation.TargetProvider, invitation.TargetSubject)
+			if err != nil {
+				return err
+			}
+			if linked == nil || linked.UserID != userID {
+				return fmt.Errorf("この招待の対象ではありません: %w", ErrForbidden)
+			}
+		}
+
+		redeemed, err := repos.Invitation.Redeem(ctx, invitation.ID, userID, now)
+		if err != nil {
+			return fmt.Errorf("failed to redeem invitation: %w", err)
+		}
+		if !redeemed {
+			return invalidInvitation
+		}
+
+		// 元の世帯を抜けて参加する。他のメンバーがいる世帯の所有者は所有権を移譲するまで参加できない
+		return moveMember(ctx, repos, userID, invitation.HouseholdID, invitation.Role, handoff)
+	})
 }
 
-// CreateUserFromLine はLINEログインからの新規ユーザー登録を処理します（紐付けなし）。
-func (uu *userUsecase) CreateUserFromLine(lineUserIDStr, name, image string) (*user.User, error) {
+// CreateUserFromIdentity は外部IDプロバイダでのログインからの新規ユーザー登録を処理し、そのアカウントを紐付けます。
+func (uu *userUsecase) CreateUserFromIdentity(provider, subject, name, image string) (*user.User, error) {
 	var domainUser *user.User
 
-	lineUserIDVo, err := user.NewLineUserID(lineUserIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid line user id: %w", err)
-	}
-
-	err = uu.uow.Transaction(func(repos Repositories) error {
+	err := uu.uow.Transaction(func(repos Repositories) error {
 		// 新しい世帯を作成
 		householdName := name
 		if householdName == "" {
 			householdName = "Unknown"
 		}
-		domainHousehold, err := household.NewHousehold(
-			fmt.Sprintf("%s's Household", householdName),
-			utils.GenerateRandomString(household.InviteCodeLength),
-		)
+		domainHousehold, err := household.NewHousehold(fmt.Sprintf("%s's Household", householdName))
 		if err != nil {
 			return err
 		}
@@ -340,15 +426,14 @@ func (uu *userUsecase) CreateUserFromLine(lineUserIDStr, name, image string) (*u
 			return err
 		}
 
-		// LINEユーザーのメールアドレスは空文字、パスワードは仮のものを設定
-		dummyPasswordHash, err := bcrypt.GenerateFromPassword([]byte(utils.GenerateRandomString(16)), 10)
-		if err != nil {
+		if err := createDefaultCategories(context.Background(), repos.Category, domainHousehold.ID.Value()); err != nil {
 			return err
 		}
 
+		// 外部IDプロバイダから作成したユーザーはメールアドレスとパスワードを持たない
 		domainUser, err = user.NewUser(
 			"", // emailは空文字
-			string(dummyPasswordHash),
+			"", // パスワードは後から設定できる
 			name,
 			image,
 			false, // Admin権限なし
@@ -357,99 +442,87 @@ func (uu *userUsecase) CreateUserFromLine(lineUserIDStr, name, image string) (*u
 		if err != nil {
 			return err
 		}
-		domainUser.LineUserID = lineUserIDVo // LINE User IDを設定
 
 		if err := repos.User.Create(context.Background(), domainUser); err != nil {
 			return err
 		}
+		if err := repos.Member.Save(context.Background(), household.NewMember(domainHousehold.ID.Value(), domainUser.ID.Value(), household.RoleOwner)); err != nil {
+			return err
+		}
 
-		return nil
+		userIdentity, err := identity.NewIdentity(domainUser.ID.Value(), provider, subject)
+		if err != nil {
+			return err
+		}
+		return repos.Identity.Create(context.Background(), userIdentity)
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create user for LINE login: %w", err)
+		return nil, fmt.Errorf("failed to create user for %s login: %w", provider, err)
 	}
 
 	return domainUser, nil
 }
 
-// LinkLineAccount は既存のメールアドレス/パスワード認証ユーザーにLINE IDを紐付けます。
-func (uu *userUsecase) LinkLineAccount(email, password, lineUserIDStr string) (*user.User, error) {
+// LinkIdentity は既存のメールアドレス/パスワード認証ユーザーに外部IDプロバイダのアカウントを紐付けます。
+func (uu *userUsecase) LinkIdentity(email, password, provider, subject, clientIP string) (*user.User, error) {
 	ctx := context.Background()
 
-	// メールアドレスでユーザーを検索
-	existingUser, err := uu.ur.FindByEmail(ctx, email)
+	existingUser, err := uu.authenticatePassword(ctx, email, password, clientIP)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find user: %w", err)
-	}
-	if existingUser == nil {
-		return nil, fmt.Errorf("user not found")
-	}
-
-	// パスワード検証
-	if err := bcrypt.CompareHashAndPassword([]byte(existingUser.Password.Value()), []byte(password)); err != nil {
-		return nil, fmt.Errorf("invalid password")
+		return nil, err
 	}
 
-	// LINE IDの形式検証
-	lineUserIDVo, err := user.NewLineUserID(lineUserIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid line user id: %w", err)
-	}
+	err = uu.uow.Transaction(func(repos Repositories) error {
+		linked, err := repos.Identity.FindByProviderSubject(ctx, provider, subject)
+		if err != nil {
+			return fmt.Errorf("failed to check existing link: %w", err)
+		}
+		if linked != nil {
+			if linked.UserID != existingUser.ID.Value() {
+				return fmt.Errorf("%s account already linked to another user", provider)
+			}
+			return nil
+		}
 
-	// LINE IDの紐付け更新
-	checkUser, err := uu.ur.FindByLineUserID(ctx, lineUserIDVo)
+		userIdentity, err := identity.NewIdentity(existingUser.ID.Value(), provider, subject)
+		if err != nil {
+			return err
+		}
+		if err := repos.Identity.Create(ctx, userIdentity); err != nil {
+			return fmt.Errorf("failed to update user linkage: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to check existing link: %w", err)
-	}
-	if checkUser != nil && checkUser.ID.Value() != existingUser.ID.Value() {
-		return nil, fmt.Errorf("line account already linked to another user")
-	}
-
-	existingUser.LineUserID = lineUserIDVo
-
-	if err := uu.ur.Update(ctx, existingUser); err != nil {
-		return nil, fmt.Errorf("failed to update user linkage: %w", err)
+		return nil, err
 	}
 
 	return existingUser, nil
 }
 
-// generateCSRFToken は新しいCSRFトークンを生成し、保存します（内部メソッド）
-func (uu *userUsecase) generateCSRFToken(sessionID string) (string, error) {
-	token := utils.GenerateRandomString(32)
-	uu.tokenStore.SaveToken(sessionID, model.CSRFToken{
-		Token:     token,
-		ExpiresAt: time.Now().Add(24 * time.Hour), // 24時間有効
-	})
-	return token, nil
-}
+// CSRFTokenTTL はCSRFトークンの有効期間です。
+const CSRFTokenTTL = 24 * time.Hour
 
 // GetOrGenerateCSRFToken は既存のトークンを返すか、新しいトークンを生成します
 func (uu *userUsecase) GetOrGenerateCSRFToken(sessionID string) (string, error) {
+	ctx := context.Background()
+
 	// 既存のトークンを確認
-	if token, exists := uu.tokenStore.GetToken(sessionID); exists {
+	token, exists, err := uu.tokenStore.GetToken(ctx, sessionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get CSRF token: %w", err)
+	}
+	if exists {
 		return token, nil
 	}
 
 	// 新しいトークンを生成
-	return uu.generateCSRFToken(sessionID)
+	return uu.tokenStore.IssueToken(ctx, sessionID, CSRFTokenTTL)
 }
 
 // ValidateCSRFToken はCSRFトークンを検証します
 func (uu *userUsecase) ValidateCSRFToken(sessionID, token string) bool {
-	return uu.tokenStore.ValidateToken(sessionID, token)
-}
-
-// GenerateToken は与えられたユーザーエンティティからJWTを生成します。
-func (uu *userUsecase) GenerateToken(userEntity *user.User) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": userEntity.ID.Value(),
-		"exp":     time.Now().Add(time.Hour * 12).Unix(),
-	})
-	tokenString, err := token.SignedString([]byte(os.Getenv("SECRET")))
-	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
-	}
-	return tokenString, nil
+	valid, err := uu.tokenStore.ValidateToken(context.Background(), sessionID, token)
+	return err == nil && valid
 }
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// 文字コードの指定値
+const (
+	EncodingAuto     = "auto"
+	EncodingUTF8     = "utf-8"
+	EncodingShiftJIS = "shift_jis"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// DecodeText は指定の文字コードのテキストをUTF-8に変換します。
+// auto の場合はUTF-8として正しければUTF-8、そうでなければShift_JISとして扱います。
+func DecodeText(data []byte, enc string) ([]byte, error) {
+	switch enc {
+	case "", EncodingAuto:
+		if bytes.HasPrefix(data, utf8BOM) || utf8.Valid(data) {
+			return bytes.TrimPrefix(data, utf8BOM), nil
+		}
+		return japanese.ShiftJIS.NewDecoder().Bytes(data)
+	case EncodingUTF8:
+		return bytes.TrimPrefix(data, utf8BOM), nil
+	case EncodingShiftJIS:
+		return japanese.ShiftJIS.NewDecoder().Bytes(data)
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", enc)
+	}
+}
+
+// NewEncodingWriter はUTF-8で書き込んだテキストを指定の文字コードに変換して w へ出力する Writer を返します。
+// UTF-8 の場合は表計算ソフトで文字化けしないよう先頭にBOMを付与します。
+// Shift_JIS で表現できない文字は置換文字に変換されます。書き込み後は必ず Close を呼んでください。
+func NewEncodingWriter(w io.Writer, enc string) (io.WriteCloser, error) {
+	switch enc {
+	case "", EncodingUTF8:
+		if _, err := w.Write(utf8BOM); err != nil {
+			return nil, err
+		}
+		return nopWriteCloser{w}, nil
+	case EncodingShiftJIS:
+		return transform.NewWriter(w, encoding.ReplaceUnsupported(japanese.ShiftJIS.NewEncoder())), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", enc)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -42,7 +42,7 @@ const EditModal: React.FC<EditModalProps> = ({
         ...expense,
         amount,
         store_name: storeName,
-        date: `${transactionDate}T00:00:00Z`,
+        date: transactionDate,
         category,
         memo,
         user_id: parseInt(payerId, 10),
@@ -1,6 +1,6 @@
 import { format, parseISO } from "date-fns";
 
-// date-fnsを使用して日付を適切なフォーマットに変換
+// date-fnsを使用して日付をAPIの日付形式（YYYY-MM-DD）に変換
 export const formattedDate = (date: string) => {
-  return format(parseISO(date), "yyyy-MM-dd'T'HH:mm:ss'Z'");
+  return format(parseISO(date), "yyyy-MM-dd");
 };